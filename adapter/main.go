@@ -44,9 +44,11 @@ import (
 	generatedopenapi "github.com/kedacore/keda/v2/adapter/generated/openapi"
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollers "github.com/kedacore/keda/v2/controllers/keda"
+	kedaconfig "github.com/kedacore/keda/v2/pkg/config"
 	prommetrics "github.com/kedacore/keda/v2/pkg/metrics"
 	kedaprovider "github.com/kedacore/keda/v2/pkg/provider"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 	"github.com/kedacore/keda/v2/version"
 )
@@ -66,6 +68,8 @@ var (
 	prometheusMetricsPath     string
 	adapterClientRequestQPS   float32
 	adapterClientRequestBurst int
+	tracingConfig             tracing.Config
+	configFile                string
 )
 
 func (a *Adapter) makeProvider(ctx context.Context, globalHTTPTimeout time.Duration, maxConcurrentReconciles int) (provider.MetricsProvider, <-chan struct{}, error) {
@@ -107,7 +111,7 @@ func (a *Adapter) makeProvider(ctx context.Context, globalHTTPTimeout time.Durat
 
 	broadcaster := record.NewBroadcaster()
 	recorder := broadcaster.NewRecorder(scheme, corev1.EventSource{Component: "keda-metrics-adapter"})
-	handler := scaling.NewScaleHandler(mgr.GetClient(), nil, scheme, globalHTTPTimeout, recorder)
+	handler := scaling.NewScaleHandler(mgr.GetClient(), nil, scheme, globalHTTPTimeout, recorder, nil)
 	externalMetricsInfo := &[]provider.ExternalMetricInfo{}
 	externalMetricsInfoLock := &sync.RWMutex{}
 
@@ -183,6 +187,10 @@ func main() {
 	cmd.Flags().StringVar(&prometheusMetricsPath, "metrics-path", "/metrics", "Set the path for the prometheus metrics endpoint")
 	cmd.Flags().Float32Var(&adapterClientRequestQPS, "kube-api-qps", 20.0, "Set the QPS rate for throttling requests sent to the apiserver")
 	cmd.Flags().IntVar(&adapterClientRequestBurst, "kube-api-burst", 30, "Set the burst for throttling requests sent to the apiserver")
+	cmd.Flags().StringVar(&tracingConfig.Endpoint, "tracing-otlp-endpoint", "", "Set the host:port of the OTLP gRPC collector traces are exported to, tracing is disabled when empty")
+	cmd.Flags().BoolVar(&tracingConfig.Insecure, "tracing-otlp-insecure", false, "Disable TLS for the connection to the OTLP collector")
+	cmd.Flags().Float64Var(&tracingConfig.SampleRatio, "tracing-sample-ratio", 0.1, "Set the fraction (0-1) of traces that are sampled")
+	cmd.Flags().StringVar(&configFile, "config-file", "", "Set the OperatorConfig file, the metrics server applies its http settings and reloads them when it changes")
 	if err := cmd.Flags().Parse(os.Args); err != nil {
 		return
 	}
@@ -204,7 +212,42 @@ func main() {
 		return
 	}
 
-	kedaProvider, stopCh, err := cmd.makeProvider(ctx, time.Duration(globalHTTPTimeoutMS)*time.Millisecond, controllerMaxReconciles)
+	baseConfig := kedaconfig.Default()
+	baseConfig.HTTP.Timeout.Duration = time.Duration(globalHTTPTimeoutMS) * time.Millisecond
+	configWatcher, err := kedaconfig.NewWatcher(configFile, baseConfig, logger.WithName("config"))
+	if err != nil {
+		logger.Error(err, "Invalid configuration", "path", configFile)
+		return
+	}
+	operatorConfig := configWatcher.Current()
+	if err = operatorConfig.ApplyHTTP(); err != nil {
+		logger.Error(err, "Invalid HTTP configuration")
+		return
+	}
+	configWatcher.OnReload(func(operatorConfig *kedaconfig.OperatorConfig) {
+		if err := operatorConfig.ApplyHTTP(); err != nil {
+			logger.Error(err, "unable to apply HTTP configuration")
+		}
+	})
+	go func() {
+		if err := configWatcher.Start(ctx); err != nil {
+			logger.Error(err, "configuration watcher failed")
+		}
+	}()
+
+	tracingConfig.ServiceName = "keda-metrics-apiserver"
+	shutdownTracing, err := tracing.Init(ctx, tracingConfig)
+	if err != nil {
+		logger.Error(err, "unable to set up tracing")
+		return
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error(err, "error shutting down tracing")
+		}
+	}()
+
+	kedaProvider, stopCh, err := cmd.makeProvider(ctx, operatorConfig.HTTP.Timeout.Duration, controllerMaxReconciles)
 	if err != nil {
 		logger.Error(err, "making provider")
 		return
@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=clustercloudeventsinks,scope=Cluster,shortName=cces
+// +kubebuilder:printcolumn:name="URI",type="string",JSONPath=".spec.destination.http.uri"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterCloudEventSink defines a sink the operator publishes CloudEvents about scaling decisions to
+type ClusterCloudEventSink struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterCloudEventSinkSpec `json:"spec"`
+	// +optional
+	Status ClusterCloudEventSinkStatus `json:"status,omitempty"`
+}
+
+// ClusterCloudEventSinkSpec is the spec for a ClusterCloudEventSink
+type ClusterCloudEventSinkSpec struct {
+	Destination CloudEventDestination `json:"destination"`
+
+	// ClusterName is used in the source attribute of the emitted CloudEvents
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// +optional
+	EventSubscription CloudEventSubscription `json:"eventSubscription,omitempty"`
+
+	// Namespaces limits the emitted CloudEvents to objects in the listed namespaces, all namespaces when empty
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// +optional
+	Delivery *CloudEventDelivery `json:"delivery,omitempty"`
+}
+
+// CloudEventDestination is the endpoint the CloudEvents are delivered to
+type CloudEventDestination struct {
+	HTTP CloudEventHTTPDestination `json:"http"`
+}
+
+// CloudEventHTTPDestination delivers the CloudEvents in HTTP binary content mode
+type CloudEventHTTPDestination struct {
+	URI string `json:"uri"`
+}
+
+// CloudEventSubscription selects the CloudEvent types that are emitted, all types when both lists are empty
+type CloudEventSubscription struct {
+	// +optional
+	IncludedEventTypes []string `json:"includedEventTypes,omitempty"`
+	// +optional
+	ExcludedEventTypes []string `json:"excludedEventTypes,omitempty"`
+}
+
+// CloudEventDelivery configures the retries and the buffer of a sink
+type CloudEventDelivery struct {
+	// MaxRetries is the number of times a failed delivery is retried with an exponential backoff
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+	// BufferSize is the number of CloudEvents waiting for delivery, further CloudEvents are dropped
+	// +optional
+	BufferSize *int32 `json:"bufferSize,omitempty"`
+}
+
+// ClusterCloudEventSinkStatus is the status for a ClusterCloudEventSink
+type ClusterCloudEventSinkStatus struct {
+	// +optional
+	Conditions Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterCloudEventSinkList is a list of ClusterCloudEventSink resources
+type ClusterCloudEventSinkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ClusterCloudEventSink `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterCloudEventSink{}, &ClusterCloudEventSinkList{})
+}
@@ -39,6 +39,19 @@ const (
 	ScaledObjectConditionReadySucccesReason = "ScaledObjectReady"
 	// ScaledObjectConditionReadySuccessMessage defines the default Message for correct ScaledObject
 	ScaledObjectConditionReadySuccessMessage = "ScaledObject is defined correctly and is ready for scaling"
+	// ConditionReadyAuthResolutionFailedReason defines the Reason for a ScaledObject or ScaledJob whose TriggerAuthentication can't be resolved
+	ConditionReadyAuthResolutionFailedReason = "TriggerAuthenticationResolutionFailed"
+	// ScaledObjectConditionReadyHPAConflictReason defines the Reason for a ScaledObject whose scale target is already scaled by another HPA
+	ScaledObjectConditionReadyHPAConflictReason = "HPAConflict"
+	// ScaledObjectConditionReadyReplicasPathForbiddenReason defines the Reason for a ScaledObject whose scale target KEDA isn't allowed to patch
+	ScaledObjectConditionReadyReplicasPathForbiddenReason = "ReplicasPathForbidden"
+)
+
+const (
+	// TriggerAuthenticationConditionReadySuccessReason defines the Reason for a TriggerAuthentication that can be resolved
+	TriggerAuthenticationConditionReadySuccessReason = "TriggerAuthenticationReady"
+	// TriggerAuthenticationConditionReadySuccessMessage defines the Message for a TriggerAuthentication that can be resolved
+	TriggerAuthenticationConditionReadySuccessMessage = "All secrets and the pod identity of the TriggerAuthentication can be resolved"
 )
 
 // Condition to store the condition state
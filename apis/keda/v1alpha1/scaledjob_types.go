@@ -47,6 +47,12 @@ type ScaledJobSpec struct {
 	// +optional
 	PollingInterval *int32 `json:"pollingInterval,omitempty"`
 	// +optional
+	ActivePollingInterval *int32 `json:"activePollingInterval,omitempty"`
+	// +optional
+	IdlePollingInterval *int32 `json:"idlePollingInterval,omitempty"`
+	// +optional
+	MaxIdlePollingInterval *int32 `json:"maxIdlePollingInterval,omitempty"`
+	// +optional
 	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
 	// +optional
 	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
@@ -68,6 +74,10 @@ type ScaledJobStatus struct {
 	LastActiveTime *metav1.Time `json:"lastActiveTime,omitempty"`
 	// +optional
 	Conditions Conditions `json:"conditions,omitempty"`
+	// +optional
+	EffectivePollingInterval *int32 `json:"effectivePollingInterval,omitempty"`
+	// +optional
+	CircuitBreakers map[string]TriggerCircuitBreakerStatus `json:"circuitBreakers,omitempty"`
 }
 
 // ScaledJobList contains a list of ScaledJob
@@ -17,7 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
-	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -65,12 +65,42 @@ const (
 	HealthStatusFailing HealthStatusType = "Failing"
 )
 
+// TriggerCircuitBreakerStatus is the status of the circuit breaker guarding a trigger
+type TriggerCircuitBreakerStatus struct {
+	// +optional
+	State CircuitBreakerState `json:"state,omitempty"`
+	// +optional
+	ConsecutiveFailures *int32 `json:"consecutiveFailures,omitempty"`
+	// +optional
+	NextProbeTime *metav1.Time `json:"nextProbeTime,omitempty"`
+}
+
+// CircuitBreakerState is the state of a trigger circuit breaker
+type CircuitBreakerState string
+
+const (
+	// CircuitBreakerClosed means the trigger is healthy and checked on every polling interval
+	CircuitBreakerClosed CircuitBreakerState = "Closed"
+
+	// CircuitBreakerOpen means the trigger is failing and is not checked until the backoff expires
+	CircuitBreakerOpen CircuitBreakerState = "Open"
+
+	// CircuitBreakerHalfOpen means the backoff expired and a single check probes whether the trigger recovered
+	CircuitBreakerHalfOpen CircuitBreakerState = "HalfOpen"
+)
+
 // ScaledObjectSpec is the spec for a ScaledObject resource
 type ScaledObjectSpec struct {
 	ScaleTargetRef *ScaleTarget `json:"scaleTargetRef"`
 	// +optional
 	PollingInterval *int32 `json:"pollingInterval,omitempty"`
 	// +optional
+	ActivePollingInterval *int32 `json:"activePollingInterval,omitempty"`
+	// +optional
+	IdlePollingInterval *int32 `json:"idlePollingInterval,omitempty"`
+	// +optional
+	MaxIdlePollingInterval *int32 `json:"maxIdlePollingInterval,omitempty"`
+	// +optional
 	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
 	// +optional
 	IdleReplicaCount *int32 `json:"idleReplicaCount,omitempty"`
@@ -103,7 +133,10 @@ type AdvancedConfig struct {
 // HorizontalPodAutoscalerConfig specifies horizontal scale config
 type HorizontalPodAutoscalerConfig struct {
 	// +optional
-	Behavior *autoscalingv2beta2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+	// Name of the HPA managed for the ScaledObject, keda-hpa-<ScaledObject name> by default
+	// +optional
+	Name string `json:"name,omitempty"`
 }
 
 // ScaleTarget holds the a reference to the scale target Object
@@ -115,18 +148,59 @@ type ScaleTarget struct {
 	Kind string `json:"kind,omitempty"`
 	// +optional
 	EnvSourceContainerName string `json:"envSourceContainerName,omitempty"`
+	// ReplicasPath is the path of the replica count field of a scale target without /scale subresource, like .spec.replicas.
+	// KEDA sets the field with server-side apply and calculates the replica count from the triggers itself, no HPA is created.
+	// The patch verb on the resource is granted with a ClusterRole labeled keda.sh/aggregate-to-replicas-path: "true".
+	// +optional
+	ReplicasPath string `json:"replicasPath,omitempty"`
+	// StatusReplicasPath is the path of the observed replica count in the status of the scale target, like .status.replicas,
+	// it is only used together with replicasPath
+	// +optional
+	StatusReplicasPath string `json:"statusReplicasPath,omitempty"`
 }
 
 // ScaleTriggers reference the scaler that will be used
 type ScaleTriggers struct {
 	Type string `json:"type"`
 	// +optional
-	Name     string            `json:"name,omitempty"`
-	Metadata map[string]string `json:"metadata"`
+	Name string `json:"name,omitempty"`
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// +optional
+	MetadataFrom []TriggerMetadataSource `json:"metadataFrom,omitempty"`
 	// +optional
 	AuthenticationRef *ScaledObjectAuthRef `json:"authenticationRef,omitempty"`
 	// +optional
-	MetricType autoscalingv2beta2.MetricTargetType `json:"metricType,omitempty"`
+	MetricType autoscalingv2.MetricTargetType `json:"metricType,omitempty"`
+}
+
+// TriggerMetadataSource reads trigger metadata from a ConfigMap in the namespace of the ScaledObject or ScaledJob,
+// exactly one of ConfigMapRef and ConfigMapKeyRef has to be set
+type TriggerMetadataSource struct {
+	// ConfigMapRef adds all keys of the ConfigMap to the metadata
+	// +optional
+	ConfigMapRef *TriggerMetadataConfigMapRef `json:"configMapRef,omitempty"`
+	// ConfigMapKeyRef sets a single metadata parameter to the value of a ConfigMap key
+	// +optional
+	ConfigMapKeyRef *TriggerMetadataConfigMapKeyRef `json:"configMapKeyRef,omitempty"`
+}
+
+// TriggerMetadataConfigMapRef references a ConfigMap whose keys are trigger metadata parameters
+type TriggerMetadataConfigMapRef struct {
+	Name string `json:"name"`
+	// Optional ignores the ConfigMap when it doesn't exist
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+// TriggerMetadataConfigMapKeyRef references a ConfigMap key holding the value of a trigger metadata parameter
+type TriggerMetadataConfigMapKeyRef struct {
+	Parameter string `json:"parameter"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+	// Optional ignores the parameter when the ConfigMap or the key doesn't exist
+	// +optional
+	Optional bool `json:"optional,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -152,6 +226,10 @@ type ScaledObjectStatus struct {
 	Health map[string]HealthStatus `json:"health,omitempty"`
 	// +optional
 	PausedReplicaCount *int32 `json:"pausedReplicaCount,omitempty"`
+	// +optional
+	EffectivePollingInterval *int32 `json:"effectivePollingInterval,omitempty"`
+	// +optional
+	CircuitBreakers map[string]TriggerCircuitBreakerStatus `json:"circuitBreakers,omitempty"`
 }
 
 // +kubebuilder:object:root=true
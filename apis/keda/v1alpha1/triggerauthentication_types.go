@@ -30,11 +30,15 @@ import (
 // +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".spec.secretTargetRef[*].name"
 // +kubebuilder:printcolumn:name="Env",type="string",JSONPath=".spec.env[*].name"
 // +kubebuilder:printcolumn:name="VaultAddress",type="string",JSONPath=".spec.hashiCorpVault.address"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:subresource:status
 type ClusterTriggerAuthentication struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Spec TriggerAuthenticationSpec `json:"spec"`
+	// +optional
+	Status TriggerAuthenticationStatus `json:"status,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -55,11 +59,15 @@ type ClusterTriggerAuthenticationList struct {
 // +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".spec.secretTargetRef[*].name"
 // +kubebuilder:printcolumn:name="Env",type="string",JSONPath=".spec.env[*].name"
 // +kubebuilder:printcolumn:name="VaultAddress",type="string",JSONPath=".spec.hashiCorpVault.address"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:subresource:status
 type TriggerAuthentication struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Spec TriggerAuthenticationSpec `json:"spec"`
+	// +optional
+	Status TriggerAuthenticationStatus `json:"status,omitempty"`
 }
 
 // TriggerAuthenticationSpec defines the various ways to authenticate
@@ -70,6 +78,9 @@ type TriggerAuthenticationSpec struct {
 	// +optional
 	SecretTargetRef []AuthSecretTargetRef `json:"secretTargetRef,omitempty"`
 
+	// +optional
+	ConfigMapTargetRef []AuthConfigMapTargetRef `json:"configMapTargetRef,omitempty"`
+
 	// +optional
 	Env []AuthEnvironment `json:"env,omitempty"`
 
@@ -78,6 +89,30 @@ type TriggerAuthenticationSpec struct {
 
 	// +optional
 	AzureKeyVault *AzureKeyVault `json:"azureKeyVault,omitempty"`
+
+	// +optional
+	AwsSecretManager *AwsSecretManager `json:"awsSecretManager,omitempty"`
+
+	// +optional
+	AwsParameterStore *AwsParameterStore `json:"awsParameterStore,omitempty"`
+
+	// +optional
+	GcpSecretManager *GcpSecretManager `json:"gcpSecretManager,omitempty"`
+}
+
+// TriggerAuthenticationStatus defines the observed state of TriggerAuthentication and ClusterTriggerAuthentication
+type TriggerAuthenticationStatus struct {
+	// Conditions has a Ready condition telling whether all secrets and the pod identity of the spec can be resolved
+	// +optional
+	Conditions Conditions `json:"conditions,omitempty"`
+	// ScaledObjects lists the names of the ScaledObjects referencing the TriggerAuthentication,
+	// ClusterTriggerAuthentications list them as <namespace>/<name>
+	// +optional
+	ScaledObjects []string `json:"scaledObjects,omitempty"`
+	// ScaledJobs lists the names of the ScaledJobs referencing the TriggerAuthentication,
+	// ClusterTriggerAuthentications list them as <namespace>/<name>
+	// +optional
+	ScaledJobs []string `json:"scaledJobs,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -104,6 +139,17 @@ const (
 	PodIdentityProviderAwsKiam       PodIdentityProvider = "aws-kiam"
 )
 
+// IsValid reports whether the provider is one of the known pod identity providers
+func (p PodIdentityProvider) IsValid() bool {
+	switch p {
+	case PodIdentityProviderNone, PodIdentityProviderAzure, PodIdentityProviderAzureWorkload, PodIdentityProviderGCP,
+		PodIdentityProviderSpiffe, PodIdentityProviderAwsEKS, PodIdentityProviderAwsKiam:
+		return true
+	default:
+		return false
+	}
+}
+
 // PodIdentityAnnotationEKS specifies aws role arn for aws-eks Identity Provider
 // PodIdentityAnnotationKiam specifies aws role arn for aws-iam Identity Provider
 const (
@@ -122,6 +168,21 @@ type AuthSecretTargetRef struct {
 	Parameter string `json:"parameter"`
 	Name      string `json:"name"`
 	Key       string `json:"key"`
+
+	// Optional parameters that can't be resolved are left out instead of failing the trigger
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+// AuthConfigMapTargetRef is used to read non-secret parameters, like CA bundles, from a config map
+type AuthConfigMapTargetRef struct {
+	Parameter string `json:"parameter"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+
+	// Optional parameters that can't be resolved are left out instead of failing the trigger
+	// +optional
+	Optional bool `json:"optional,omitempty"`
 }
 
 // AuthEnvironment is used to authenticate using environment variables
@@ -132,6 +193,10 @@ type AuthEnvironment struct {
 
 	// +optional
 	ContainerName string `json:"containerName,omitempty"`
+
+	// Optional parameters that can't be resolved are left out instead of failing the trigger
+	// +optional
+	Optional bool `json:"optional,omitempty"`
 }
 
 // HashiCorpVault is used to authenticate using Hashicorp Vault
@@ -158,8 +223,27 @@ type Credential struct {
 	// +optional
 	Token string `json:"token,omitempty"`
 
+	// ServiceAccount is the path of the service account token used by the kubernetes and jwt authentication,
+	// the jwt authentication defaults to the projected token of the KEDA pods with the vault audience
 	// +optional
 	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// RoleID is the Secret key holding the role_id of the approle authentication
+	// +optional
+	RoleID *ValueFromSecret `json:"roleId,omitempty"`
+
+	// SecretID is the Secret key holding the secret_id of the approle authentication
+	// +optional
+	SecretID *ValueFromSecret `json:"secretId,omitempty"`
+
+	// ClientCertSecret is the name of the kubernetes.io/tls Secret holding the client certificate of the cert authentication,
+	// its optional ca.crt is trusted as well
+	// +optional
+	ClientCertSecret string `json:"clientCertSecret,omitempty"`
+
+	// IAMServerID is the value of the X-Vault-AWS-IAM-Server-ID header signed by the aws authentication
+	// +optional
+	IAMServerID string `json:"iamServerId,omitempty"`
 }
 
 // VaultAuthentication contains the list of Hashicorp Vault authentication methods
@@ -169,7 +253,10 @@ type VaultAuthentication string
 const (
 	VaultAuthenticationToken      VaultAuthentication = "token"
 	VaultAuthenticationKubernetes VaultAuthentication = "kubernetes"
-	// VaultAuthenticationAWS                            = "aws"
+	VaultAuthenticationAppRole    VaultAuthentication = "approle"
+	VaultAuthenticationJWT        VaultAuthentication = "jwt"
+	VaultAuthenticationCert       VaultAuthentication = "cert"
+	VaultAuthenticationAWS        VaultAuthentication = "aws"
 )
 
 // VaultSecret defines the mapping between the path of the secret in Vault to the parameter
@@ -177,6 +264,14 @@ type VaultSecret struct {
 	Parameter string `json:"parameter"`
 	Path      string `json:"path"`
 	Key       string `json:"key"`
+
+	// Version pins the version of a KV v2 secret, the latest version is read when it isn't set
+	// +optional
+	Version int `json:"version,omitempty"`
+
+	// Optional parameters that can't be resolved are left out instead of failing the trigger
+	// +optional
+	Optional bool `json:"optional,omitempty"`
 }
 
 // AzureKeyVault is used to authenticate using Azure Key Vault
@@ -213,6 +308,10 @@ type AzureKeyVaultSecret struct {
 	Name      string `json:"name"`
 	// +optional
 	Version string `json:"version,omitempty"`
+
+	// Optional parameters that can't be resolved are left out instead of failing the trigger
+	// +optional
+	Optional bool `json:"optional,omitempty"`
 }
 
 type AzureKeyVaultCloudInfo struct {
@@ -223,6 +322,97 @@ type AzureKeyVaultCloudInfo struct {
 	ActiveDirectoryEndpoint string `json:"activeDirectoryEndpoint"`
 }
 
+// AwsSecretManager is used to authenticate using AWS Secrets Manager
+type AwsSecretManager struct {
+	Secrets []AwsSecretManagerSecret `json:"secrets"`
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Endpoint overrides the Secrets Manager endpoint of the region
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// +optional
+	Credentials *AwsCredentials `json:"credentials,omitempty"`
+	// RoleArn is assumed with the credentials, or with the pod identity of KEDA if there are none
+	// +optional
+	RoleArn string `json:"roleArn,omitempty"`
+}
+
+type AwsSecretManagerSecret struct {
+	Parameter string `json:"parameter"`
+	// Name is the name or ARN of the secret
+	Name string `json:"name"`
+	// +optional
+	VersionID string `json:"versionId,omitempty"`
+	// +optional
+	VersionStage string `json:"versionStage,omitempty"`
+	// JSONKey is the key of the value to use when the secret is a JSON object
+	// +optional
+	JSONKey string `json:"jsonKey,omitempty"`
+
+	// Optional parameters that can't be resolved are left out instead of failing the trigger
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+// AwsParameterStore is used to authenticate using AWS Systems Manager Parameter Store
+type AwsParameterStore struct {
+	Parameters []AwsParameterStoreParameter `json:"parameters"`
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Endpoint overrides the Systems Manager endpoint of the region
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// +optional
+	Credentials *AwsCredentials `json:"credentials,omitempty"`
+	// RoleArn is assumed with the credentials, or with the pod identity of KEDA if there are none
+	// +optional
+	RoleArn string `json:"roleArn,omitempty"`
+}
+
+type AwsParameterStoreParameter struct {
+	Parameter string `json:"parameter"`
+	// Name is the name or ARN of the Parameter Store parameter, SecureString parameters are decrypted
+	Name string `json:"name"`
+
+	// Optional parameters that can't be resolved are left out instead of failing the trigger
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+// AwsCredentials are static AWS credentials read from Secrets
+type AwsCredentials struct {
+	AccessKeyID     ValueFromSecret `json:"accessKeyId"`
+	SecretAccessKey ValueFromSecret `json:"secretAccessKey"`
+	// +optional
+	SessionToken *ValueFromSecret `json:"sessionToken,omitempty"`
+}
+
+// GcpSecretManager is used to authenticate using GCP Secret Manager
+type GcpSecretManager struct {
+	Secrets []GcpSecretManagerSecret `json:"secrets"`
+	// Credentials are used instead of the gcp pod identity of KEDA
+	// +optional
+	Credentials *GcpCredentials `json:"credentials,omitempty"`
+	// Endpoint overrides the Secret Manager endpoint, like the address of an emulator
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+type GcpSecretManagerSecret struct {
+	Parameter string `json:"parameter"`
+	// Name is the name of the secret version, projects/<project>/secrets/<secret>/versions/<version>
+	Name string `json:"name"`
+
+	// Optional parameters that can't be resolved are left out instead of failing the trigger
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+// GcpCredentials is the JSON key of a service account read from a Secret
+type GcpCredentials struct {
+	ClientSecret ValueFromSecret `json:"clientSecret"`
+}
+
 func init() {
 	SchemeBuilder.Register(&ClusterTriggerAuthentication{}, &ClusterTriggerAuthenticationList{})
 	SchemeBuilder.Register(&TriggerAuthentication{}, &TriggerAuthenticationList{})
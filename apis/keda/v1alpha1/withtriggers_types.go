@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,10 +28,19 @@ import (
 	"knative.dev/pkg/apis/duck"
 )
 
-const (
-	// Default polling interval for a ScaledObject triggers if no pollingInterval is defined.
-	defaultPollingInterval = 30
-)
+// defaultPollingInterval is the polling interval of triggers if no pollingInterval is defined,
+// it is stored atomically as it can be changed while the scale loops run
+var defaultPollingInterval = int64(30 * time.Second)
+
+// SetDefaultPollingInterval changes the polling interval used for objects without a pollingInterval
+func SetDefaultPollingInterval(interval time.Duration) {
+	atomic.StoreInt64(&defaultPollingInterval, int64(interval))
+}
+
+// GetDefaultPollingInterval returns the polling interval used for objects without a pollingInterval
+func GetDefaultPollingInterval() time.Duration {
+	return time.Duration(atomic.LoadInt64(&defaultPollingInterval))
+}
 
 // +kubebuilder:object:root=true
 
@@ -44,8 +54,11 @@ type WithTriggers struct {
 
 // WithTriggersSpec is the spec for a an object with triggers resource
 type WithTriggersSpec struct {
-	PollingInterval *int32          `json:"pollingInterval,omitempty"`
-	Triggers        []ScaleTriggers `json:"triggers"`
+	PollingInterval        *int32          `json:"pollingInterval,omitempty"`
+	ActivePollingInterval  *int32          `json:"activePollingInterval,omitempty"`
+	IdlePollingInterval    *int32          `json:"idlePollingInterval,omitempty"`
+	MaxIdlePollingInterval *int32          `json:"maxIdlePollingInterval,omitempty"`
+	Triggers               []ScaleTriggers `json:"triggers"`
 }
 
 // Assert that we implement the interfaces necessary to
@@ -86,7 +99,36 @@ func (t *WithTriggers) GetPollingInterval() time.Duration {
 		return time.Second * time.Duration(*t.Spec.PollingInterval)
 	}
 
-	return time.Second * time.Duration(defaultPollingInterval)
+	return GetDefaultPollingInterval()
+}
+
+// GetAdaptivePollingInterval returns the polling interval to be used after a check that found the triggers active or idle.
+// idleChecks is the number of consecutive checks with idle triggers, it is used to back-off the idle polling interval
+// exponentially up to MaxIdlePollingInterval, if defined
+func (t *WithTriggers) GetAdaptivePollingInterval(isActive bool, idleChecks int) time.Duration {
+	if isActive {
+		if t.Spec.ActivePollingInterval != nil && *t.Spec.ActivePollingInterval > 0 {
+			return time.Second * time.Duration(*t.Spec.ActivePollingInterval)
+		}
+		return t.GetPollingInterval()
+	}
+
+	interval := t.GetPollingInterval()
+	if t.Spec.IdlePollingInterval != nil && *t.Spec.IdlePollingInterval > 0 {
+		interval = time.Second * time.Duration(*t.Spec.IdlePollingInterval)
+	}
+
+	if t.Spec.MaxIdlePollingInterval == nil {
+		return interval
+	}
+	maxInterval := time.Second * time.Duration(*t.Spec.MaxIdlePollingInterval)
+	for i := 1; i < idleChecks && interval < maxInterval; i++ {
+		interval *= 2
+	}
+	if interval > maxInterval && maxInterval > 0 {
+		return maxInterval
+	}
+	return interval
 }
 
 // GenerateIdenitifier returns identifier for the object in for "kind.namespace.name"
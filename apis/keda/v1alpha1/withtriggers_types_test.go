@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type adaptivePollingIntervalTestData struct {
+	name       string
+	spec       WithTriggersSpec
+	isActive   bool
+	idleChecks int
+	expected   time.Duration
+}
+
+func int32Ptr(value int32) *int32 {
+	return &value
+}
+
+var adaptivePollingIntervalTestDataset = []adaptivePollingIntervalTestData{
+	{"default active", WithTriggersSpec{}, true, 0, 30 * time.Second},
+	{"default idle", WithTriggersSpec{}, false, 5, 30 * time.Second},
+	{"polling interval", WithTriggersSpec{PollingInterval: int32Ptr(10)}, false, 1, 10 * time.Second},
+	{"active interval", WithTriggersSpec{PollingInterval: int32Ptr(10), ActivePollingInterval: int32Ptr(2)}, true, 0, 2 * time.Second},
+	{"active interval when idle", WithTriggersSpec{PollingInterval: int32Ptr(10), ActivePollingInterval: int32Ptr(2)}, false, 1, 10 * time.Second},
+	{"idle interval", WithTriggersSpec{PollingInterval: int32Ptr(10), IdlePollingInterval: int32Ptr(60)}, false, 3, 60 * time.Second},
+	{"idle interval when active", WithTriggersSpec{PollingInterval: int32Ptr(10), IdlePollingInterval: int32Ptr(60)}, true, 0, 10 * time.Second},
+	{"idle back-off first check", WithTriggersSpec{IdlePollingInterval: int32Ptr(10), MaxIdlePollingInterval: int32Ptr(100)}, false, 1, 10 * time.Second},
+	{"idle back-off third check", WithTriggersSpec{IdlePollingInterval: int32Ptr(10), MaxIdlePollingInterval: int32Ptr(100)}, false, 3, 40 * time.Second},
+	{"idle back-off capped", WithTriggersSpec{IdlePollingInterval: int32Ptr(10), MaxIdlePollingInterval: int32Ptr(100)}, false, 10, 100 * time.Second},
+	{"idle back-off max lower than idle", WithTriggersSpec{IdlePollingInterval: int32Ptr(10), MaxIdlePollingInterval: int32Ptr(5)}, false, 3, 5 * time.Second},
+}
+
+func TestGetAdaptivePollingInterval(t *testing.T) {
+	for _, testData := range adaptivePollingIntervalTestDataset {
+		withTriggers := WithTriggers{Spec: testData.spec}
+		result := withTriggers.GetAdaptivePollingInterval(testData.isActive, testData.idleChecks)
+		assert.Equal(t, testData.expected, result, testData.name)
+	}
+}
@@ -22,7 +22,7 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/api/batch/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -47,6 +47,21 @@ func (in *AdvancedConfig) DeepCopy() *AdvancedConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthConfigMapTargetRef) DeepCopyInto(out *AuthConfigMapTargetRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthConfigMapTargetRef.
+func (in *AuthConfigMapTargetRef) DeepCopy() *AuthConfigMapTargetRef {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthConfigMapTargetRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthEnvironment) DeepCopyInto(out *AuthEnvironment) {
 	*out = *in
@@ -92,6 +107,108 @@ func (in *AuthSecretTargetRef) DeepCopy() *AuthSecretTargetRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsCredentials) DeepCopyInto(out *AwsCredentials) {
+	*out = *in
+	out.AccessKeyID = in.AccessKeyID
+	out.SecretAccessKey = in.SecretAccessKey
+	if in.SessionToken != nil {
+		in, out := &in.SessionToken, &out.SessionToken
+		*out = new(ValueFromSecret)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsCredentials.
+func (in *AwsCredentials) DeepCopy() *AwsCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsParameterStore) DeepCopyInto(out *AwsParameterStore) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]AwsParameterStoreParameter, len(*in))
+		copy(*out, *in)
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(AwsCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsParameterStore.
+func (in *AwsParameterStore) DeepCopy() *AwsParameterStore {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsParameterStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsParameterStoreParameter) DeepCopyInto(out *AwsParameterStoreParameter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsParameterStoreParameter.
+func (in *AwsParameterStoreParameter) DeepCopy() *AwsParameterStoreParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsParameterStoreParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsSecretManager) DeepCopyInto(out *AwsSecretManager) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]AwsSecretManagerSecret, len(*in))
+		copy(*out, *in)
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(AwsCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsSecretManager.
+func (in *AwsSecretManager) DeepCopy() *AwsSecretManager {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsSecretManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsSecretManagerSecret) DeepCopyInto(out *AwsSecretManagerSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsSecretManagerSecret.
+func (in *AwsSecretManagerSecret) DeepCopy() *AwsSecretManagerSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsSecretManagerSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureKeyVault) DeepCopyInto(out *AzureKeyVault) {
 	*out = *in
@@ -188,12 +305,200 @@ func (in *AzureKeyVaultSecret) DeepCopy() *AzureKeyVaultSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventDelivery) DeepCopyInto(out *CloudEventDelivery) {
+	*out = *in
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BufferSize != nil {
+		in, out := &in.BufferSize, &out.BufferSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventDelivery.
+func (in *CloudEventDelivery) DeepCopy() *CloudEventDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventDestination) DeepCopyInto(out *CloudEventDestination) {
+	*out = *in
+	out.HTTP = in.HTTP
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventDestination.
+func (in *CloudEventDestination) DeepCopy() *CloudEventDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventHTTPDestination) DeepCopyInto(out *CloudEventHTTPDestination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventHTTPDestination.
+func (in *CloudEventHTTPDestination) DeepCopy() *CloudEventHTTPDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventHTTPDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventSubscription) DeepCopyInto(out *CloudEventSubscription) {
+	*out = *in
+	if in.IncludedEventTypes != nil {
+		in, out := &in.IncludedEventTypes, &out.IncludedEventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedEventTypes != nil {
+		in, out := &in.ExcludedEventTypes, &out.ExcludedEventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventSubscription.
+func (in *CloudEventSubscription) DeepCopy() *CloudEventSubscription {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventSubscription)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCloudEventSink) DeepCopyInto(out *ClusterCloudEventSink) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCloudEventSink.
+func (in *ClusterCloudEventSink) DeepCopy() *ClusterCloudEventSink {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCloudEventSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterCloudEventSink) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCloudEventSinkList) DeepCopyInto(out *ClusterCloudEventSinkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterCloudEventSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCloudEventSinkList.
+func (in *ClusterCloudEventSinkList) DeepCopy() *ClusterCloudEventSinkList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCloudEventSinkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterCloudEventSinkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCloudEventSinkSpec) DeepCopyInto(out *ClusterCloudEventSinkSpec) {
+	*out = *in
+	out.Destination = in.Destination
+	in.EventSubscription.DeepCopyInto(&out.EventSubscription)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = new(CloudEventDelivery)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCloudEventSinkSpec.
+func (in *ClusterCloudEventSinkSpec) DeepCopy() *ClusterCloudEventSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCloudEventSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCloudEventSinkStatus) DeepCopyInto(out *ClusterCloudEventSinkStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(Conditions, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCloudEventSinkStatus.
+func (in *ClusterCloudEventSinkStatus) DeepCopy() *ClusterCloudEventSinkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCloudEventSinkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterTriggerAuthentication) DeepCopyInto(out *ClusterTriggerAuthentication) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTriggerAuthentication.
@@ -283,6 +588,16 @@ func (in Conditions) DeepCopy() Conditions {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Credential) DeepCopyInto(out *Credential) {
 	*out = *in
+	if in.RoleID != nil {
+		in, out := &in.RoleID, &out.RoleID
+		*out = new(ValueFromSecret)
+		**out = **in
+	}
+	if in.SecretID != nil {
+		in, out := &in.SecretID, &out.SecretID
+		*out = new(ValueFromSecret)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Credential.
@@ -310,6 +625,62 @@ func (in *Fallback) DeepCopy() *Fallback {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GcpCredentials) DeepCopyInto(out *GcpCredentials) {
+	*out = *in
+	out.ClientSecret = in.ClientSecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GcpCredentials.
+func (in *GcpCredentials) DeepCopy() *GcpCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(GcpCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GcpSecretManager) DeepCopyInto(out *GcpSecretManager) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]GcpSecretManagerSecret, len(*in))
+		copy(*out, *in)
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(GcpCredentials)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GcpSecretManager.
+func (in *GcpSecretManager) DeepCopy() *GcpSecretManager {
+	if in == nil {
+		return nil
+	}
+	out := new(GcpSecretManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GcpSecretManagerSecret) DeepCopyInto(out *GcpSecretManagerSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GcpSecretManagerSecret.
+func (in *GcpSecretManagerSecret) DeepCopy() *GcpSecretManagerSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(GcpSecretManagerSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GroupVersionKindResource) DeepCopyInto(out *GroupVersionKindResource) {
 	*out = *in
@@ -336,7 +707,7 @@ func (in *HashiCorpVault) DeepCopyInto(out *HashiCorpVault) {
 	if in.Credential != nil {
 		in, out := &in.Credential, &out.Credential
 		*out = new(Credential)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -375,7 +746,7 @@ func (in *HorizontalPodAutoscalerConfig) DeepCopyInto(out *HorizontalPodAutoscal
 	*out = *in
 	if in.Behavior != nil {
 		in, out := &in.Behavior, &out.Behavior
-		*out = new(v2beta2.HorizontalPodAutoscalerBehavior)
+		*out = new(v2.HorizontalPodAutoscalerBehavior)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -415,6 +786,13 @@ func (in *ScaleTriggers) DeepCopyInto(out *ScaleTriggers) {
 			(*out)[key] = val
 		}
 	}
+	if in.MetadataFrom != nil {
+		in, out := &in.MetadataFrom, &out.MetadataFrom
+		*out = make([]TriggerMetadataSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.AuthenticationRef != nil {
 		in, out := &in.AuthenticationRef, &out.AuthenticationRef
 		*out = new(ScaledObjectAuthRef)
@@ -504,6 +882,21 @@ func (in *ScaledJobSpec) DeepCopyInto(out *ScaledJobSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ActivePollingInterval != nil {
+		in, out := &in.ActivePollingInterval, &out.ActivePollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdlePollingInterval != nil {
+		in, out := &in.IdlePollingInterval, &out.IdlePollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxIdlePollingInterval != nil {
+		in, out := &in.MaxIdlePollingInterval, &out.MaxIdlePollingInterval
+		*out = new(int32)
+		**out = **in
+	}
 	if in.SuccessfulJobsHistoryLimit != nil {
 		in, out := &in.SuccessfulJobsHistoryLimit, &out.SuccessfulJobsHistoryLimit
 		*out = new(int32)
@@ -551,6 +944,18 @@ func (in *ScaledJobStatus) DeepCopyInto(out *ScaledJobStatus) {
 		*out = make(Conditions, len(*in))
 		copy(*out, *in)
 	}
+	if in.EffectivePollingInterval != nil {
+		in, out := &in.EffectivePollingInterval, &out.EffectivePollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CircuitBreakers != nil {
+		in, out := &in.CircuitBreakers, &out.CircuitBreakers
+		*out = make(map[string]TriggerCircuitBreakerStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledJobStatus.
@@ -650,6 +1055,21 @@ func (in *ScaledObjectSpec) DeepCopyInto(out *ScaledObjectSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ActivePollingInterval != nil {
+		in, out := &in.ActivePollingInterval, &out.ActivePollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdlePollingInterval != nil {
+		in, out := &in.IdlePollingInterval, &out.IdlePollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxIdlePollingInterval != nil {
+		in, out := &in.MaxIdlePollingInterval, &out.MaxIdlePollingInterval
+		*out = new(int32)
+		**out = **in
+	}
 	if in.CooldownPeriod != nil {
 		in, out := &in.CooldownPeriod, &out.CooldownPeriod
 		*out = new(int32)
@@ -743,6 +1163,18 @@ func (in *ScaledObjectStatus) DeepCopyInto(out *ScaledObjectStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.EffectivePollingInterval != nil {
+		in, out := &in.EffectivePollingInterval, &out.EffectivePollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CircuitBreakers != nil {
+		in, out := &in.CircuitBreakers, &out.CircuitBreakers
+		*out = make(map[string]TriggerCircuitBreakerStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectStatus.
@@ -801,6 +1233,7 @@ func (in *TriggerAuthentication) DeepCopyInto(out *TriggerAuthentication) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthentication.
@@ -866,6 +1299,11 @@ func (in *TriggerAuthenticationSpec) DeepCopyInto(out *TriggerAuthenticationSpec
 		*out = make([]AuthSecretTargetRef, len(*in))
 		copy(*out, *in)
 	}
+	if in.ConfigMapTargetRef != nil {
+		in, out := &in.ConfigMapTargetRef, &out.ConfigMapTargetRef
+		*out = make([]AuthConfigMapTargetRef, len(*in))
+		copy(*out, *in)
+	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]AuthEnvironment, len(*in))
@@ -881,6 +1319,21 @@ func (in *TriggerAuthenticationSpec) DeepCopyInto(out *TriggerAuthenticationSpec
 		*out = new(AzureKeyVault)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AwsSecretManager != nil {
+		in, out := &in.AwsSecretManager, &out.AwsSecretManager
+		*out = new(AwsSecretManager)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AwsParameterStore != nil {
+		in, out := &in.AwsParameterStore, &out.AwsParameterStore
+		*out = new(AwsParameterStore)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GcpSecretManager != nil {
+		in, out := &in.GcpSecretManager, &out.GcpSecretManager
+		*out = new(GcpSecretManager)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationSpec.
@@ -893,6 +1346,115 @@ func (in *TriggerAuthenticationSpec) DeepCopy() *TriggerAuthenticationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerAuthenticationStatus) DeepCopyInto(out *TriggerAuthenticationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(Conditions, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScaledObjects != nil {
+		in, out := &in.ScaledObjects, &out.ScaledObjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScaledJobs != nil {
+		in, out := &in.ScaledJobs, &out.ScaledJobs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuthenticationStatus.
+func (in *TriggerAuthenticationStatus) DeepCopy() *TriggerAuthenticationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerAuthenticationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerCircuitBreakerStatus) DeepCopyInto(out *TriggerCircuitBreakerStatus) {
+	*out = *in
+	if in.ConsecutiveFailures != nil {
+		in, out := &in.ConsecutiveFailures, &out.ConsecutiveFailures
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NextProbeTime != nil {
+		in, out := &in.NextProbeTime, &out.NextProbeTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerCircuitBreakerStatus.
+func (in *TriggerCircuitBreakerStatus) DeepCopy() *TriggerCircuitBreakerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerCircuitBreakerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerMetadataConfigMapKeyRef) DeepCopyInto(out *TriggerMetadataConfigMapKeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerMetadataConfigMapKeyRef.
+func (in *TriggerMetadataConfigMapKeyRef) DeepCopy() *TriggerMetadataConfigMapKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerMetadataConfigMapKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerMetadataConfigMapRef) DeepCopyInto(out *TriggerMetadataConfigMapRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerMetadataConfigMapRef.
+func (in *TriggerMetadataConfigMapRef) DeepCopy() *TriggerMetadataConfigMapRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerMetadataConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerMetadataSource) DeepCopyInto(out *TriggerMetadataSource) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(TriggerMetadataConfigMapRef)
+		**out = **in
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(TriggerMetadataConfigMapKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerMetadataSource.
+func (in *TriggerMetadataSource) DeepCopy() *TriggerMetadataSource {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerMetadataSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValueFromSecret) DeepCopyInto(out *ValueFromSecret) {
 	*out = *in
@@ -990,6 +1552,21 @@ func (in *WithTriggersSpec) DeepCopyInto(out *WithTriggersSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ActivePollingInterval != nil {
+		in, out := &in.ActivePollingInterval, &out.ActivePollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdlePollingInterval != nil {
+		in, out := &in.IdlePollingInterval, &out.IdlePollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxIdlePollingInterval != nil {
+		in, out := &in.MaxIdlePollingInterval, &out.MaxIdlePollingInterval
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Triggers != nil {
 		in, out := &in.Triggers, &out.Triggers
 		*out = make([]ScaleTriggers, len(*in))
@@ -0,0 +1,213 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// keda-simulate replays a recorded or synthetic time series of trigger values through the scaling decisions
+// of a ScaledObject and prints the resulting replica timeline, without deploying to a cluster.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kedacore/keda/v2/pkg/simulation"
+	"github.com/kedacore/keda/v2/pkg/triggertest"
+)
+
+type stringSlice []string
+
+func (s *stringSlice) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSlice) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+type config struct {
+	files, targets, activations stringSlice
+	seriesFile, namespace, name string
+	output                      string
+	changesOnly                 bool
+	options                     simulation.Options
+}
+
+func main() {
+	var cfg config
+	var initialReplicas int
+	flag.Var(&cfg.files, "f", "Manifest file with the ScaledObject and the TriggerAuthentications, Secrets and ConfigMaps its triggers need, can be repeated.")
+	flag.StringVar(&cfg.seriesFile, "series", "", "CSV or JSON file with the trigger values over time.")
+	flag.Var(&cfg.targets, "target", "Metric target of a trigger, as <trigger name>=<value>, required for triggers that can't be built offline, can be repeated.")
+	flag.Var(&cfg.activations, "activation", "Value a trigger has to exceed to be active, as <trigger name>=<value>, 0 by default, can be repeated.")
+	flag.StringVar(&cfg.namespace, "namespace", "default", "The namespace of the manifests without a namespace.")
+	flag.StringVar(&cfg.name, "name", "", "The name of the ScaledObject to simulate, required when the manifests contain several.")
+	flag.DurationVar(&cfg.options.Duration, "duration", 0, "The simulated time span, the time of the last sample by default.")
+	flag.DurationVar(&cfg.options.HPASyncPeriod, "hpa-sync-period", 15*time.Second, "The sync period of the HPA controller.")
+	flag.IntVar(&initialReplicas, "initial-replicas", 0, "The replica count of the scale target at the start.")
+	flag.BoolVar(&cfg.changesOnly, "changes-only", false, "Only print the steps that changed the replica count or recorded events.")
+	flag.StringVar(&cfg.output, "o", "table", "The output format, table, yaml or json.")
+	opts := zap.Options{}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts), zap.WriteTo(os.Stderr)))
+
+	cfg.options.InitialReplicas = int32(initialReplicas)
+	if err := run(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(cfg config) error {
+	if len(cfg.files) == 0 {
+		return fmt.Errorf("at least one manifest file has to be passed with -f")
+	}
+	if cfg.seriesFile == "" {
+		return fmt.Errorf("the series has to be passed with --series")
+	}
+	if cfg.output != "table" && cfg.output != "yaml" && cfg.output != "json" {
+		return fmt.Errorf("unsupported output format %q", cfg.output)
+	}
+	targets, err := parseValues(cfg.targets)
+	if err != nil {
+		return fmt.Errorf("invalid target: %s", err)
+	}
+	activations, err := parseValues(cfg.activations)
+	if err != nil {
+		return fmt.Errorf("invalid activation threshold: %s", err)
+	}
+
+	manifests := triggertest.NewManifests(cfg.namespace)
+	for _, file := range cfg.files {
+		if err := manifests.LoadFile(file); err != nil {
+			return err
+		}
+	}
+	series, err := simulation.LoadSeriesFile(cfg.seriesFile)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	scaledObject, triggers, err := simulation.LoadScaledObject(ctx, manifests, cfg.name, targets, activations)
+	if err != nil {
+		return err
+	}
+	timeline, err := simulation.Run(ctx, scaledObject, triggers, series, cfg.options)
+	if err != nil {
+		return err
+	}
+	if cfg.changesOnly {
+		steps := timeline.Steps[:0]
+		for _, step := range timeline.Steps {
+			if step.Replicas != step.PreviousReplicas || len(step.Events) > 0 {
+				steps = append(steps, step)
+			}
+		}
+		timeline.Steps = steps
+	}
+
+	switch cfg.output {
+	case "json":
+		out, err := json.MarshalIndent(timeline, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(append(out, '\n'))
+		return err
+	case "yaml":
+		out, err := yaml.Marshal(timeline)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(out)
+		return err
+	default:
+		return printTable(os.Stdout, timeline, triggers)
+	}
+}
+
+// parseValues parses <name>=<value> definitions
+func parseValues(definitions []string) (map[string]float64, error) {
+	result := map[string]float64{}
+	for _, definition := range definitions {
+		i := strings.LastIndex(definition, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("%q, expected <trigger name>=<value>", definition)
+		}
+		value, err := strconv.ParseFloat(definition[i+1:], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q, expected <trigger name>=<value>", definition)
+		}
+		result[definition[:i]] = value
+	}
+	return result, nil
+}
+
+func printTable(out io.Writer, timeline *simulation.Timeline, triggers []simulation.Trigger) error {
+	writer := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	names := make([]string, 0, len(triggers))
+	for _, trigger := range triggers {
+		names = append(names, trigger.Name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(writer, "OFFSET\tSOURCE\t%s\tACTIVE\tACTION\tDESIRED\tREPLICAS\tNOTES\n", strings.ToUpper(strings.Join(names, "\t")))
+	for _, step := range timeline.Steps {
+		values := make([]string, 0, len(names))
+		for _, name := range names {
+			if value := step.Values[name]; value != nil {
+				values = append(values, strconv.FormatFloat(*value, 'f', -1, 64))
+			} else {
+				values = append(values, "error")
+			}
+		}
+		active, desired := "", ""
+		if step.Source == simulation.SourceScaleLoop {
+			active = strconv.FormatBool(step.IsActive)
+		}
+		if step.DesiredReplicas != nil {
+			desired = strconv.Itoa(int(*step.DesiredReplicas))
+		}
+		var notes []string
+		if step.Fallback {
+			notes = append(notes, "fallback")
+		}
+		if step.Message != "" {
+			notes = append(notes, step.Message)
+		}
+		for _, event := range step.Events {
+			notes = append(notes, event.Reason+": "+event.Message)
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%d -> %d\t%s\n", step.Offset.Duration, step.Source, strings.Join(values, "\t"),
+			active, step.Action, desired, step.PreviousReplicas, step.Replicas, strings.Join(notes, "; "))
+	}
+	return writer.Flush()
+}
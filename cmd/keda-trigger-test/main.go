@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// keda-trigger-test builds the scalers of a ScaledObject or ScaledJob from manifest files and prints
+// their resolved configuration, metric specs and optionally their live values, without deploying to a cluster.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kedacore/keda/v2/pkg/triggertest"
+)
+
+type stringSlice []string
+
+func (s *stringSlice) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSlice) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+func main() {
+	var files, secretFiles stringSlice
+	var namespace, clusterObjectNamespace, output string
+	var options triggertest.Options
+	flag.Var(&files, "f", "Manifest file with the ScaledObject or ScaledJob, TriggerAuthentications, Secrets, ConfigMaps and the scale target, can be repeated.")
+	flag.Var(&secretFiles, "secret-file", "Secret value read from a file, as <secret name>/<key>=<file>, can be repeated.")
+	flag.StringVar(&namespace, "namespace", "default", "The namespace of the manifests without a namespace.")
+	flag.StringVar(&clusterObjectNamespace, "cluster-object-namespace", "keda", "The namespace Secrets referenced by ClusterTriggerAuthentications are read from.")
+	flag.StringVar(&options.Name, "name", "", "The name of the ScaledObject or ScaledJob to test, required when the manifests contain several.")
+	flag.BoolVar(&options.Offline, "offline", false, "Only validate the triggers without connecting to their event sources.")
+	flag.BoolVar(&options.Live, "live", false, "Query the activity and the metric values of the triggers.")
+	flag.BoolVar(&options.ShowSecrets, "show-secrets", false, "Print resolved authentication parameters and environment values instead of redacting them.")
+	flag.DurationVar(&options.Timeout, "timeout", 30*time.Second, "The timeout of building and querying a single scaler.")
+	flag.StringVar(&output, "o", "yaml", "The output format, yaml or json.")
+	opts := zap.Options{}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts), zap.WriteTo(os.Stderr)))
+
+	if err := run(files, secretFiles, namespace, clusterObjectNamespace, output, options); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(files, secretFiles []string, namespace, clusterObjectNamespace, output string, options triggertest.Options) error {
+	if len(files) == 0 {
+		return fmt.Errorf("at least one manifest file has to be passed with -f")
+	}
+	if output != "yaml" && output != "json" {
+		return fmt.Errorf("unsupported output format %q", output)
+	}
+	if os.Getenv("KEDA_CLUSTER_OBJECT_NAMESPACE") == "" {
+		if err := os.Setenv("KEDA_CLUSTER_OBJECT_NAMESPACE", clusterObjectNamespace); err != nil {
+			return err
+		}
+	}
+
+	manifests := triggertest.NewManifests(namespace)
+	for _, file := range files {
+		if err := manifests.LoadFile(file); err != nil {
+			return err
+		}
+	}
+	for _, secretFile := range secretFiles {
+		if err := manifests.AddSecretValueFromFile(secretFile); err != nil {
+			return err
+		}
+	}
+
+	report, err := triggertest.Run(context.Background(), manifests, options)
+	if err != nil {
+		return err
+	}
+
+	var out []byte
+	if output == "json" {
+		out, err = json.MarshalIndent(report, "", "  ")
+		out = append(out, '\n')
+	} else {
+		out, err = yaml.Marshal(report)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stdout.Write(out); err != nil {
+		return err
+	}
+
+	if report.HasErrors() {
+		return fmt.Errorf("some triggers are invalid")
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/cloudevents"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+// ClusterCloudEventSinkReconciler reconciles a ClusterCloudEventSink object
+type ClusterCloudEventSinkReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	Emitter  *cloudevents.Emitter
+}
+
+// +kubebuilder:rbac:groups=keda.sh,resources=clustercloudeventsinks;clustercloudeventsinks/status,verbs="*"
+
+// Reconcile starts, updates or stops the delivery of CloudEvents to the sink defined by the ClusterCloudEventSink resource.
+func (r *ClusterCloudEventSinkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+
+	sink := &kedav1alpha1.ClusterCloudEventSink{}
+	err := r.Client.Get(ctx, req.NamespacedName, sink)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.Emitter.RemoveSink(req.Name)
+			return ctrl.Result{}, nil
+		}
+		reqLogger.Error(err, "Failed to get ClusterCloudEventSink")
+		return ctrl.Result{}, err
+	}
+
+	if sink.GetDeletionTimestamp() != nil {
+		r.Emitter.RemoveSink(sink.Name)
+		return ctrl.Result{}, nil
+	}
+
+	readyCondition := kedav1alpha1.Condition{
+		Type:    kedav1alpha1.ConditionReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  "SinkReady",
+		Message: "CloudEvents are delivered to the sink",
+	}
+	if err := r.Emitter.UpdateSink(sink.Name, sink.Spec); err != nil {
+		reqLogger.Error(err, "ClusterCloudEventSink is invalid")
+		r.Recorder.Event(sink, corev1.EventTypeWarning, eventreason.ClusterCloudEventSinkInvalid, err.Error())
+		r.Emitter.RemoveSink(sink.Name)
+		readyCondition.Status = metav1.ConditionFalse
+		readyCondition.Reason = "SinkInvalid"
+		readyCondition.Message = err.Error()
+	}
+
+	conditions := kedav1alpha1.Conditions{readyCondition}
+	if equality.Semantic.DeepEqual(sink.Status.Conditions, conditions) {
+		return ctrl.Result{}, nil
+	}
+	patch := client.MergeFrom(sink.DeepCopy())
+	sink.Status.Conditions = conditions
+	if err := r.Client.Status().Patch(ctx, sink, patch); err != nil {
+		reqLogger.Error(err, "Failed to update ClusterCloudEventSink status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterCloudEventSinkReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kedav1alpha1.ClusterCloudEventSink{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
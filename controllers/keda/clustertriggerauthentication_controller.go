@@ -22,15 +22,20 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
 )
 
 // ClusterTriggerAuthenticationReconciler reconciles a ClusterTriggerAuthentication object
@@ -50,26 +55,83 @@ func (r *ClusterTriggerAuthenticationReconciler) Reconcile(ctx context.Context,
 	err := r.Client.Get(ctx, req.NamespacedName, clusterTriggerAuthentication)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			metricsServer.DeleteResource(req.Namespace, "ClusterTriggerAuthentication", req.Name)
 			return ctrl.Result{}, nil
 		}
 		reqLogger.Error(err, "Failed ot get ClusterTriggerAuthentication")
 		return ctrl.Result{}, err
 	}
 
+	clusterObjectNamespace, err := resolver.GetClusterObjectNamespace()
+	if err != nil {
+		reqLogger.Error(err, "Failed to get the namespace of the ClusterTriggerAuthentication secrets")
+		return ctrl.Result{}, err
+	}
+	auth := authentication{
+		object:          clusterTriggerAuthentication,
+		kind:            "ClusterTriggerAuthentication",
+		spec:            &clusterTriggerAuthentication.Spec,
+		status:          &clusterTriggerAuthentication.Status,
+		secretNamespace: clusterObjectNamespace,
+		inUseReason:     eventreason.ClusterTriggerAuthenticationInUse,
+	}
+
 	if clusterTriggerAuthentication.GetDeletionTimestamp() != nil {
+		finalized, err := finalizeAuthentication(ctx, r.Client, r.Recorder, reqLogger, auth)
+		if err != nil || !finalized {
+			return ctrl.Result{}, err
+		}
+		metricsServer.DeleteResource(clusterTriggerAuthentication.Namespace, "ClusterTriggerAuthentication", clusterTriggerAuthentication.Name)
 		r.Recorder.Event(clusterTriggerAuthentication, corev1.EventTypeNormal, eventreason.ClusterTriggerAuthenticationDeleted, "ClusterTriggerAuthentication was deleted")
 		return ctrl.Result{}, nil
 	}
 
+	if err := ensureAuthenticationFinalizer(ctx, r.Client, reqLogger, auth); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	metricsServer.RecordResource(clusterTriggerAuthentication.Namespace, "ClusterTriggerAuthentication", clusterTriggerAuthentication.Name)
+
 	if clusterTriggerAuthentication.ObjectMeta.Generation == 1 {
 		r.Recorder.Event(clusterTriggerAuthentication, corev1.EventTypeNormal, eventreason.ClusterTriggerAuthenticationAdded, "New ClusterTriggerAuthentication configured")
 	}
-	return ctrl.Result{}, nil
+
+	return updateAuthenticationStatus(ctx, r.Client, reqLogger, auth)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterTriggerAuthenticationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	scalableObjectRequests := handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		return authenticationRequestsForScalableObject(obj, "ClusterTriggerAuthentication")
+	})
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kedav1alpha1.ClusterTriggerAuthentication{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// the status lists the referencing ScaledObjects and ScaledJobs and the referenced Secrets and ConfigMaps have to be resolvable
+		Watches(&source.Kind{Type: &kedav1alpha1.ScaledObject{}}, scalableObjectRequests, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &kedav1alpha1.ScaledJob{}}, scalableObjectRequests, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.requestsForReferenced(referencesSecret))).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.requestsForReferenced(referencesConfigMap))).
 		Complete(r)
 }
+
+// requestsForReferenced maps a Secret or ConfigMap of the cluster object namespace to the ClusterTriggerAuthentications reading it
+func (r *ClusterTriggerAuthenticationReconciler) requestsForReferenced(references func(*kedav1alpha1.TriggerAuthenticationSpec, string) bool) handler.MapFunc {
+	return func(obj client.Object) []reconcile.Request {
+		clusterObjectNamespace, err := resolver.GetClusterObjectNamespace()
+		if err != nil || obj.GetNamespace() != clusterObjectNamespace {
+			return nil
+		}
+		clusterTriggerAuthentications := &kedav1alpha1.ClusterTriggerAuthenticationList{}
+		if err := r.Client.List(context.Background(), clusterTriggerAuthentications); err != nil {
+			log.Log.Error(err, "Failed to list ClusterTriggerAuthentications")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, clusterTriggerAuthentication := range clusterTriggerAuthentications.Items {
+			if references(&clusterTriggerAuthentication.Spec, obj.GetName()) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: clusterTriggerAuthentication.Name}})
+			}
+		}
+		return requests
+	}
+}
@@ -18,19 +18,28 @@ package keda
 
 import (
 	"context"
+	"encoding/json"
+	goerrors "errors"
 	"fmt"
 	"sort"
 	"strings"
 	"unicode"
 
 	"github.com/go-logr/logr"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/scaling/executor"
 	version "github.com/kedacore/keda/v2/version"
 )
@@ -50,7 +59,7 @@ func (r *ScaledObjectReconciler) createAndDeployNewHPA(ctx context.Context, logg
 		return err
 	}
 
-	err = r.Client.Create(ctx, hpa)
+	err = r.createHPA(ctx, hpa)
 	if err != nil {
 		logger.Error(err, "Failed to create new HPA in cluster", "HPA.Namespace", scaledObject.Namespace, "HPA.Name", hpaName)
 		return err
@@ -60,13 +69,13 @@ func (r *ScaledObjectReconciler) createAndDeployNewHPA(ctx context.Context, logg
 }
 
 // newHPAForScaledObject returns HPA as it is specified in ScaledObject
-func (r *ScaledObjectReconciler) newHPAForScaledObject(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource) (*autoscalingv2beta2.HorizontalPodAutoscaler, error) {
+func (r *ScaledObjectReconciler) newHPAForScaledObject(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource) (*autoscalingv2.HorizontalPodAutoscaler, error) {
 	scaledObjectMetricSpecs, err := r.getScaledObjectMetricSpecs(ctx, logger, scaledObject)
 	if err != nil {
 		return nil, err
 	}
 
-	var behavior *autoscalingv2beta2.HorizontalPodAutoscalerBehavior
+	var behavior *autoscalingv2.HorizontalPodAutoscalerBehavior
 	if r.kubeVersion.MinorVersion >= 18 && scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig != nil {
 		behavior = scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig.Behavior
 	} else {
@@ -107,13 +116,13 @@ func (r *ScaledObjectReconciler) newHPAForScaledObject(ctx context.Context, logg
 		maxReplicas = *pausedCount
 	}
 
-	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{
-		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
 			MinReplicas: minReplicas,
 			MaxReplicas: maxReplicas,
 			Metrics:     scaledObjectMetricSpecs,
 			Behavior:    behavior,
-			ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
 				Name:       scaledObject.Spec.ScaleTargetRef.Name,
 				Kind:       gvkr.Kind,
 				APIVersion: gvkr.GroupVersion().String(),
@@ -124,9 +133,6 @@ func (r *ScaledObjectReconciler) newHPAForScaledObject(ctx context.Context, logg
 			Labels:      labels,
 			Annotations: scaledObject.Annotations,
 		},
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: "v2beta2",
-		},
 	}
 
 	// Set ScaledObject instance as the owner and controller
@@ -138,17 +144,30 @@ func (r *ScaledObjectReconciler) newHPAForScaledObject(ctx context.Context, logg
 }
 
 // updateHPAIfNeeded checks whether update of HPA is needed
-func (r *ScaledObjectReconciler) updateHPAIfNeeded(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, foundHpa *autoscalingv2beta2.HorizontalPodAutoscaler, gvkr *kedav1alpha1.GroupVersionKindResource) error {
+func (r *ScaledObjectReconciler) updateHPAIfNeeded(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, foundHpa *autoscalingv2.HorizontalPodAutoscaler, gvkr *kedav1alpha1.GroupVersionKindResource) error {
 	hpa, err := r.newHPAForScaledObject(ctx, logger, scaledObject, gvkr)
 	if err != nil {
 		logger.Error(err, "Failed to create new HPA resource", "HPA.Namespace", scaledObject.Namespace, "HPA.Name", getHPAName(scaledObject))
 		return err
 	}
 
+	// an adopted HPA keeps its behavior unless the ScaledObject sets one
+	if adoptsHPA(scaledObject) && hpa.Spec.Behavior == nil {
+		hpa.Spec.Behavior = foundHpa.Spec.Behavior
+	}
+	if !metav1.IsControlledBy(foundHpa, scaledObject) {
+		logger.Info("Adopting HPA", "HPA.Namespace", foundHpa.Namespace, "HPA.Name", foundHpa.Name)
+		if err = r.updateHPA(ctx, hpa); err != nil {
+			logger.Error(err, "Failed to adopt HPA", "HPA.Namespace", foundHpa.Namespace, "HPA.Name", foundHpa.Name)
+			return err
+		}
+		return nil
+	}
+
 	// DeepDerivative ignores extra entries in arrays which makes removing the last trigger not update things, so trigger and update any time the metrics count is different.
 	if len(hpa.Spec.Metrics) != len(foundHpa.Spec.Metrics) || !equality.Semantic.DeepDerivative(hpa.Spec, foundHpa.Spec) {
 		logger.V(1).Info("Found difference in the HPA spec accordint to ScaledObject", "currentHPA", foundHpa.Spec, "newHPA", hpa.Spec)
-		if err = r.Client.Update(ctx, hpa); err != nil {
+		if err = r.updateHPA(ctx, hpa); err != nil {
 			foundHpa.Spec = hpa.Spec
 			logger.Error(err, "Failed to update HPA", "HPA.Namespace", foundHpa.Namespace, "HPA.Name", foundHpa.Name)
 			return err
@@ -161,7 +180,7 @@ func (r *ScaledObjectReconciler) updateHPAIfNeeded(ctx context.Context, logger l
 
 	if !equality.Semantic.DeepDerivative(hpa.ObjectMeta.Labels, foundHpa.ObjectMeta.Labels) {
 		logger.V(1).Info("Found difference in the HPA labels accordint to ScaledObject", "currentHPA", foundHpa.ObjectMeta.Labels, "newHPA", hpa.ObjectMeta.Labels)
-		if err = r.Client.Update(ctx, hpa); err != nil {
+		if err = r.updateHPA(ctx, hpa); err != nil {
 			foundHpa.ObjectMeta.Labels = hpa.ObjectMeta.Labels
 			logger.Error(err, "Failed to update HPA", "HPA.Namespace", foundHpa.Namespace, "HPA.Name", foundHpa.Name)
 			return err
@@ -172,9 +191,200 @@ func (r *ScaledObjectReconciler) updateHPAIfNeeded(ctx context.Context, logger l
 	return nil
 }
 
+// hpaObject returns an empty HPA of the autoscaling API version served by the cluster, autoscaling/v2 or else autoscaling/v2beta2
+func (r *ScaledObjectReconciler) hpaObject() client.Object {
+	if r.kubeVersion.SupportsAutoscalingV2() {
+		return &autoscalingv2.HorizontalPodAutoscaler{}
+	}
+	return &autoscalingv2beta2.HorizontalPodAutoscaler{}
+}
+
+// getHPA reads an HPA with the autoscaling API version served by the cluster, HPAs created as autoscaling/v2beta2 are read
+// as autoscaling/v2 once the cluster serves it, both versions are stored the same way
+func (r *ScaledObjectReconciler) getHPA(ctx context.Context, key types.NamespacedName) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	obj := r.hpaObject()
+	if err := r.Client.Get(ctx, key, obj); err != nil {
+		return nil, err
+	}
+	if hpa, ok := obj.(*autoscalingv2.HorizontalPodAutoscaler); ok {
+		return hpa, nil
+	}
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	return hpa, convertHPA(obj, hpa)
+}
+
+// createHPA creates the HPA with the autoscaling API version served by the cluster
+func (r *ScaledObjectReconciler) createHPA(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler) error {
+	obj, err := r.servedHPA(hpa)
+	if err != nil {
+		return err
+	}
+	return r.Client.Create(ctx, obj)
+}
+
+// updateHPA updates the HPA with the autoscaling API version served by the cluster
+func (r *ScaledObjectReconciler) updateHPA(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler) error {
+	obj, err := r.servedHPA(hpa)
+	if err != nil {
+		return err
+	}
+	return r.Client.Update(ctx, obj)
+}
+
+func (r *ScaledObjectReconciler) servedHPA(hpa *autoscalingv2.HorizontalPodAutoscaler) (client.Object, error) {
+	obj := r.hpaObject()
+	if _, ok := obj.(*autoscalingv2.HorizontalPodAutoscaler); ok {
+		return hpa, nil
+	}
+	return obj, convertHPA(hpa, obj)
+}
+
+// listHPAs lists the HPAs of the namespace with the autoscaling API version served by the cluster
+func (r *ScaledObjectReconciler) listHPAs(ctx context.Context, namespace string) ([]autoscalingv2.HorizontalPodAutoscaler, error) {
+	hpas := &autoscalingv2.HorizontalPodAutoscalerList{}
+	if r.kubeVersion.SupportsAutoscalingV2() {
+		err := r.Client.List(ctx, hpas, client.InNamespace(namespace))
+		return hpas.Items, err
+	}
+	v2beta2HPAs := &autoscalingv2beta2.HorizontalPodAutoscalerList{}
+	if err := r.Client.List(ctx, v2beta2HPAs, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	return hpas.Items, convertHPA(v2beta2HPAs, hpas)
+}
+
+// deleteHPA deletes the HPA with the autoscaling API version served by the cluster
+func (r *ScaledObjectReconciler) deleteHPA(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler) error {
+	obj := r.hpaObject()
+	obj.SetNamespace(hpa.Namespace)
+	obj.SetName(hpa.Name)
+	return r.Client.Delete(ctx, obj)
+}
+
+// convertHPA converts between autoscaling/v2 and autoscaling/v2beta2 HPAs or HPA lists, which have the same fields and differ only in their version
+func convertHPA(in, out runtime.Object) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("error converting HPA: %s", err)
+	}
+	// the client sets the version of the target type
+	out.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+	return nil
+}
+
+// hpaConflictError is returned when the scale target of a ScaledObject is already scaled by an HPA the ScaledObject doesn't manage
+type hpaConflictError struct {
+	msg string
+}
+
+func (e *hpaConflictError) Error() string {
+	return e.msg
+}
+
+// scaledObjectCheckFailedReasons returns the reasons and the message recorded when the check of a ScaledObject failed like
+// checkFailedReasons, HPAs conflicting with the HPA of the ScaledObject get their own reason and are named in the message
+func scaledObjectCheckFailedReasons(err error, msg string) (string, string, string) {
+	var conflict *hpaConflictError
+	if goerrors.As(err, &conflict) {
+		return kedav1alpha1.ScaledObjectConditionReadyHPAConflictReason, eventreason.ScaledObjectCheckFailed, fmt.Sprintf("%s: %s", msg, err)
+	}
+	return checkFailedReasons(err, "ScaledObjectCheckFailed", eventreason.ScaledObjectCheckFailed, msg)
+}
+
+// checkExistingHPAs looks for HPAs other than the HPA managed by the ScaledObject targeting its scale target, so that two HPAs don't
+// fight over the replicas. HPAs the ScaledObject created with a previous name are deleted, any other HPA is a conflict. An HPA named
+// like the managed HPA that isn't controlled by the ScaledObject is a conflict too, unless the ScaledObject adopts it with the
+// autoscaling.keda.sh/adopt-hpa annotation.
+func (r *ScaledObjectReconciler) checkExistingHPAs(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource) error {
+	hpas, err := r.listHPAs(ctx, scaledObject.Namespace)
+	if err != nil {
+		return err
+	}
+	hpaName := getHPAName(scaledObject)
+	for i := range hpas {
+		hpa := &hpas[i]
+		controlled := isHPAOfScaledObject(hpa, scaledObject)
+		switch {
+		case hpa.Name == hpaName && !controlled:
+			if owner := metav1.GetControllerOf(hpa); owner != nil {
+				return &hpaConflictError{fmt.Sprintf("HPA %s is already controlled by %s %s", hpa.Name, owner.Kind, owner.Name)}
+			}
+			if !adoptsHPA(scaledObject) {
+				return &hpaConflictError{fmt.Sprintf("HPA %s already exists, annotate the ScaledObject with %s: \"true\" to adopt it", hpa.Name, kedacontrollerutil.AdoptHPAAnnotation)}
+			}
+		case hpa.Name == hpaName || !targetsScaleTarget(hpa, scaledObject, gvkr):
+		case controlled:
+			logger.Info("Deleting the HPA of the previous name of the ScaledObject HPA", "HPA.Namespace", hpa.Namespace, "HPA.Name", hpa.Name)
+			if err := r.deleteHPA(ctx, hpa); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		default:
+			return &hpaConflictError{fmt.Sprintf("HPA %s already scales %s %s, set spec.advanced.horizontalPodAutoscalerConfig.name to %s and annotate the ScaledObject with %s: \"true\" to adopt it",
+				hpa.Name, gvkr.Kind, scaledObject.Spec.ScaleTargetRef.Name, hpa.Name, kedacontrollerutil.AdoptHPAAnnotation)}
+		}
+	}
+	return nil
+}
+
+// ensureNoHPAForScaledObject makes sure a ScaledObject scaling its target through scaleTargetRef.replicasPath has no HPA, the HPA
+// it created for a previous scale target is deleted. The triggers are limited to External metrics, KEDA can't read the Resource
+// metrics of the pods the HPA scales on.
+func (r *ScaledObjectReconciler) ensureNoHPAForScaledObject(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
+	metricSpecs, err := r.getScaledObjectMetricSpecs(ctx, logger, scaledObject)
+	if err != nil {
+		return err
+	}
+	for _, metricSpec := range metricSpecs {
+		if metricSpec.External == nil {
+			return fmt.Errorf("%s metrics are not supported for a scale target with scaleTargetRef.replicasPath", metricSpec.Type)
+		}
+	}
+
+	foundHpa, err := r.getHPA(ctx, types.NamespacedName{Name: getHPAName(scaledObject), Namespace: scaledObject.Namespace})
+	switch {
+	case errors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return err
+	case !isHPAOfScaledObject(foundHpa, scaledObject):
+		return nil
+	}
+	logger.Info("Deleting the HPA of the ScaledObject, the scale target is scaled through scaleTargetRef.replicasPath", "HPA.Namespace", foundHpa.Namespace, "HPA.Name", foundHpa.Name)
+	if err := r.deleteHPA(ctx, foundHpa); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// isHPAOfScaledObject returns whether the HPA is controlled by the ScaledObject, or by a deleted ScaledObject of the same name
+// whose HPA wasn't garbage collected yet
+func isHPAOfScaledObject(hpa *autoscalingv2.HorizontalPodAutoscaler, scaledObject *kedav1alpha1.ScaledObject) bool {
+	owner := metav1.GetControllerOf(hpa)
+	if owner == nil {
+		return false
+	}
+	gv, err := schema.ParseGroupVersion(owner.APIVersion)
+	return owner.UID == scaledObject.UID ||
+		(err == nil && gv.Group == kedav1alpha1.GroupVersion.Group && owner.Kind == "ScaledObject" && owner.Name == scaledObject.Name)
+}
+
+// targetsScaleTarget returns whether the HPA scales the scale target of the ScaledObject, in any version of its API group
+func targetsScaleTarget(hpa *autoscalingv2.HorizontalPodAutoscaler, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource) bool {
+	ref := hpa.Spec.ScaleTargetRef
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	return err == nil && gv.Group == gvkr.Group && ref.Kind == gvkr.Kind && ref.Name == scaledObject.Spec.ScaleTargetRef.Name
+}
+
+func adoptsHPA(scaledObject *kedav1alpha1.ScaledObject) bool {
+	return scaledObject.Annotations[kedacontrollerutil.AdoptHPAAnnotation] == "true"
+}
+
 // getScaledObjectMetricSpecs returns MetricSpec for HPA, generater from Triggers defitinion in ScaledObject
-func (r *ScaledObjectReconciler) getScaledObjectMetricSpecs(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) ([]autoscalingv2beta2.MetricSpec, error) {
-	var scaledObjectMetricSpecs []autoscalingv2beta2.MetricSpec
+func (r *ScaledObjectReconciler) getScaledObjectMetricSpecs(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) ([]autoscalingv2.MetricSpec, error) {
+	var scaledObjectMetricSpecs []autoscalingv2.MetricSpec
 	var externalMetricNames []string
 	var resourceMetricNames []string
 
@@ -248,8 +458,12 @@ func (r *ScaledObjectReconciler) checkMinK8sVersionforHPABehavior(logger logr.Lo
 	}
 }
 
-// getHPAName returns generated HPA name for ScaledObject specified in the parameter
+// getHPAName returns the HPA name set in ScaledObject specified in the parameter or else the generated one
 func getHPAName(scaledObject *kedav1alpha1.ScaledObject) string {
+	if scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig != nil &&
+		scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig.Name != "" {
+		return scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig.Name
+	}
 	return fmt.Sprintf("keda-hpa-%s", scaledObject.Name)
 }
 
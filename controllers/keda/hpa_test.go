@@ -23,15 +23,22 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/api/autoscaling/v2beta2"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
 	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_scaling"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 var _ = Describe("hpa", func() {
@@ -118,6 +125,181 @@ var _ = Describe("hpa", func() {
 		Expect(capturedScaledObject.Status.Health).To(Equal(expectedHealth))
 	})
 
+	It("should read and update autoscaling/v2 HPAs on clusters serving it", func() {
+		reconciler.kubeVersion = kedautil.NewK8sVersion(&version.Info{Major: "1", Minor: "23"})
+		key := types.NamespacedName{Namespace: "default", Name: "keda-hpa-test"}
+		client.EXPECT().Get(gomock.Any(), key, gomock.AssignableToTypeOf(&v2.HorizontalPodAutoscaler{})).Return(nil)
+		client.EXPECT().Update(gomock.Any(), gomock.AssignableToTypeOf(&v2.HorizontalPodAutoscaler{})).Return(nil)
+
+		hpa, err := reconciler.getHPA(context.Background(), key)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reconciler.updateHPA(context.Background(), hpa)).To(Succeed())
+	})
+
+	It("should read and update autoscaling/v2beta2 HPAs on clusters not serving autoscaling/v2", func() {
+		reconciler.kubeVersion = kedautil.NewK8sVersion(&version.Info{Major: "1", Minor: "22"})
+		key := types.NamespacedName{Namespace: "default", Name: "keda-hpa-test"}
+		stabilization := int32(60)
+		client.EXPECT().Get(gomock.Any(), key, gomock.AssignableToTypeOf(&v2beta2.HorizontalPodAutoscaler{})).
+			DoAndReturn(func(_ context.Context, _ types.NamespacedName, obj runtimeclient.Object) error {
+				*obj.(*v2beta2.HorizontalPodAutoscaler) = v2beta2.HorizontalPodAutoscaler{
+					ObjectMeta: v1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+					Spec: v2beta2.HorizontalPodAutoscalerSpec{
+						MaxReplicas: 10,
+						Metrics:     []v2beta2.MetricSpec{{Type: v2beta2.ExternalMetricSourceType, External: &v2beta2.ExternalMetricSource{Metric: v2beta2.MetricIdentifier{Name: "s0-queue"}}}},
+						Behavior:    &v2beta2.HorizontalPodAutoscalerBehavior{ScaleDown: &v2beta2.HPAScalingRules{StabilizationWindowSeconds: &stabilization}},
+					},
+				}
+				return nil
+			})
+		var updated *v2beta2.HorizontalPodAutoscaler
+		client.EXPECT().Update(gomock.Any(), gomock.AssignableToTypeOf(&v2beta2.HorizontalPodAutoscaler{})).
+			Do(func(_ context.Context, obj runtimeclient.Object, _ ...runtimeclient.UpdateOption) {
+				updated = obj.(*v2beta2.HorizontalPodAutoscaler)
+			})
+
+		hpa, err := reconciler.getHPA(context.Background(), key)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hpa.Spec.MaxReplicas).To(Equal(int32(10)))
+		Expect(hpa.Spec.Metrics[0].External.Metric.Name).To(Equal("s0-queue"))
+		Expect(*hpa.Spec.Behavior.ScaleDown.StabilizationWindowSeconds).To(Equal(stabilization))
+
+		hpa.Spec.MaxReplicas = 20
+		Expect(reconciler.updateHPA(context.Background(), hpa)).To(Succeed())
+		Expect(updated.Name).To(Equal(key.Name))
+		Expect(updated.Spec.MaxReplicas).To(Equal(int32(20)))
+		Expect(updated.Spec.Metrics[0].External.Metric.Name).To(Equal("s0-queue"))
+		Expect(*updated.Spec.Behavior.ScaleDown.StabilizationWindowSeconds).To(Equal(stabilization))
+	})
+
+	It("should delete the HPA of a ScaledObject scaling through its replicasPath", func() {
+		reconciler.kubeVersion = kedautil.NewK8sVersion(&version.Info{Major: "1", Minor: "24"})
+		scaledObject := setupTest(nil, scaler, scaleHandler)
+		scaledObject.Namespace = "default"
+		scaledObject.UID = "so-uid"
+		scaledObject.Spec.ScaleTargetRef = &v1alpha1.ScaleTarget{Name: "db", Kind: "Database", ReplicasPath: ".spec.instances"}
+		client.EXPECT().Status().Return(statusWriter)
+		statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any())
+		controller := true
+		key := types.NamespacedName{Namespace: "default", Name: "keda-hpa-some scaled object name"}
+		client.EXPECT().Get(gomock.Any(), key, gomock.AssignableToTypeOf(&v2.HorizontalPodAutoscaler{})).
+			DoAndReturn(func(_ context.Context, _ types.NamespacedName, obj runtimeclient.Object) error {
+				obj.SetName(key.Name)
+				obj.SetNamespace(key.Namespace)
+				obj.SetOwnerReferences([]v1.OwnerReference{{APIVersion: "keda.sh/v1alpha1", Kind: "ScaledObject", Name: scaledObject.Name, UID: scaledObject.UID, Controller: &controller}})
+				return nil
+			})
+		client.EXPECT().Delete(gomock.Any(), gomock.AssignableToTypeOf(&v2.HorizontalPodAutoscaler{})).
+			Do(func(_ context.Context, obj runtimeclient.Object, _ ...runtimeclient.DeleteOption) {
+				Expect(obj.GetName()).To(Equal(key.Name))
+			})
+
+		Expect(reconciler.ensureNoHPAForScaledObject(context.Background(), logger, scaledObject)).To(Succeed())
+	})
+
+	It("should refuse resource metrics for a ScaledObject scaling through its replicasPath", func() {
+		scaledObject := &v1alpha1.ScaledObject{
+			ObjectMeta: v1.ObjectMeta{Namespace: "default", Name: "db"},
+			Spec:       v1alpha1.ScaledObjectSpec{ScaleTargetRef: &v1alpha1.ScaleTarget{Name: "db", Kind: "Database", ReplicasPath: ".spec.instances"}},
+		}
+		scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{{Type: v2.ResourceMetricSourceType, Resource: &v2.ResourceMetricSource{Name: "cpu"}}})
+		scaleHandler.EXPECT().GetScalersCache(gomock.Any(), gomock.Eq(scaledObject)).Return(&cache.ScalersCache{
+			Scalers: []cache.ScalerBuilder{{Scaler: scaler}},
+			Logger:  logr.Discard(),
+		}, nil)
+		client.EXPECT().Status().Return(statusWriter)
+		statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any())
+
+		err := reconciler.ensureNoHPAForScaledObject(context.Background(), logger, scaledObject)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Resource metrics are not supported"))
+	})
+
+	Context("with an existing HPA scaling the scale target", func() {
+		var (
+			scaledObject *v1alpha1.ScaledObject
+			gvkr         *v1alpha1.GroupVersionKindResource
+			existing     []v2.HorizontalPodAutoscaler
+		)
+		stabilization := int32(120)
+
+		BeforeEach(func() {
+			reconciler.kubeVersion = kedautil.NewK8sVersion(&version.Info{Major: "1", Minor: "24"})
+			scaledObject = &v1alpha1.ScaledObject{
+				ObjectMeta: v1.ObjectMeta{Namespace: "default", Name: "web", UID: "so-uid"},
+				Spec:       v1alpha1.ScaledObjectSpec{ScaleTargetRef: &v1alpha1.ScaleTarget{Name: "web"}},
+			}
+			gvkr = &v1alpha1.GroupVersionKindResource{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments"}
+			existing = []v2.HorizontalPodAutoscaler{{
+				ObjectMeta: v1.ObjectMeta{Namespace: "default", Name: "web"},
+				Spec: v2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: v2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"},
+					MaxReplicas:    10,
+					Behavior:       &v2.HorizontalPodAutoscalerBehavior{ScaleDown: &v2.HPAScalingRules{StabilizationWindowSeconds: &stabilization}},
+				},
+			}}
+			client.EXPECT().List(gomock.Any(), gomock.AssignableToTypeOf(&v2.HorizontalPodAutoscalerList{}), gomock.Any()).
+				DoAndReturn(func(_ context.Context, list runtimeclient.ObjectList, _ ...runtimeclient.ListOption) error {
+					list.(*v2.HorizontalPodAutoscalerList).Items = existing
+					return nil
+				})
+		})
+
+		It("should refuse it without the adopt annotation", func() {
+			scaledObject.Spec.Advanced = &v1alpha1.AdvancedConfig{HorizontalPodAutoscalerConfig: &v1alpha1.HorizontalPodAutoscalerConfig{Name: "web"}}
+
+			err := reconciler.checkExistingHPAs(context.Background(), logger, scaledObject, gvkr)
+			Expect(err).To(BeAssignableToTypeOf(&hpaConflictError{}))
+			Expect(err.Error()).To(ContainSubstring(kedacontrollerutil.AdoptHPAAnnotation))
+			reason, _, _ := scaledObjectCheckFailedReasons(err, "Failed to ensure HPA is correctly created for ScaledObject")
+			Expect(reason).To(Equal(v1alpha1.ScaledObjectConditionReadyHPAConflictReason))
+		})
+
+		It("should refuse it when it isn't named by the ScaledObject", func() {
+			scaledObject.Annotations = map[string]string{kedacontrollerutil.AdoptHPAAnnotation: "true"}
+
+			err := reconciler.checkExistingHPAs(context.Background(), logger, scaledObject, gvkr)
+			Expect(err).To(BeAssignableToTypeOf(&hpaConflictError{}))
+			Expect(err.Error()).To(ContainSubstring("horizontalPodAutoscalerConfig.name to web"))
+		})
+
+		It("should adopt it keeping its behavior", func() {
+			adopting := setupTest(nil, scaler, scaleHandler)
+			adopting.ObjectMeta = scaledObject.ObjectMeta
+			adopting.Spec = scaledObject.Spec
+			scaledObject = adopting
+			scaledObject.Annotations = map[string]string{kedacontrollerutil.AdoptHPAAnnotation: "true"}
+			scaledObject.Spec.Advanced = &v1alpha1.AdvancedConfig{HorizontalPodAutoscalerConfig: &v1alpha1.HorizontalPodAutoscalerConfig{Name: "web"}}
+			reconciler.Scheme = runtime.NewScheme()
+			Expect(v1alpha1.AddToScheme(reconciler.Scheme)).To(Succeed())
+			client.EXPECT().Status().Return(statusWriter)
+			statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any())
+			var updated *v2.HorizontalPodAutoscaler
+			client.EXPECT().Update(gomock.Any(), gomock.AssignableToTypeOf(&v2.HorizontalPodAutoscaler{})).
+				Do(func(_ context.Context, obj runtimeclient.Object, _ ...runtimeclient.UpdateOption) {
+					updated = obj.(*v2.HorizontalPodAutoscaler)
+				})
+
+			Expect(reconciler.checkExistingHPAs(context.Background(), logger, scaledObject, gvkr)).To(Succeed())
+			Expect(reconciler.updateHPAIfNeeded(context.Background(), logger, scaledObject, &existing[0], gvkr)).To(Succeed())
+			Expect(updated.Name).To(Equal("web"))
+			Expect(v1.IsControlledBy(updated, scaledObject)).To(BeTrue())
+			Expect(updated.Spec.Metrics[0].External.Metric.Name).To(Equal("some metric name"))
+			Expect(*updated.Spec.Behavior.ScaleDown.StabilizationWindowSeconds).To(Equal(stabilization))
+		})
+
+		It("should delete its own HPA of a previous name", func() {
+			scaledObject.Spec.Advanced = &v1alpha1.AdvancedConfig{HorizontalPodAutoscalerConfig: &v1alpha1.HorizontalPodAutoscalerConfig{Name: "web-keda"}}
+			controller := true
+			existing[0].OwnerReferences = []v1.OwnerReference{{APIVersion: "keda.sh/v1alpha1", Kind: "ScaledObject", Name: scaledObject.Name, UID: "previous-so-uid", Controller: &controller}}
+			client.EXPECT().Delete(gomock.Any(), gomock.AssignableToTypeOf(&v2.HorizontalPodAutoscaler{})).
+				Do(func(_ context.Context, obj runtimeclient.Object, _ ...runtimeclient.DeleteOption) {
+					Expect(obj.GetName()).To(Equal("web"))
+				})
+
+			Expect(reconciler.checkExistingHPAs(context.Background(), logger, scaledObject, gvkr)).To(Succeed())
+		})
+	})
 })
 
 func setupTest(health map[string]v1alpha1.HealthStatus, scaler *mock_scalers.MockScaler, scaleHandler *mock_scaling.MockScaleHandler) *v1alpha1.ScaledObject {
@@ -133,21 +315,21 @@ func setupTest(health map[string]v1alpha1.HealthStatus, scaler *mock_scalers.Moc
 	scalersCache := cache.ScalersCache{
 		Scalers: []cache.ScalerBuilder{{
 			Scaler: scaler,
-			Factory: func() (scalers.Scaler, error) {
+			Factory: func(context.Context) (scalers.Scaler, error) {
 				return scaler, nil
 			},
 		}},
 		Logger:   logr.Discard(),
 		Recorder: nil,
 	}
-	metricSpec := v2beta2.MetricSpec{
-		External: &v2beta2.ExternalMetricSource{
-			Metric: v2beta2.MetricIdentifier{
+	metricSpec := v2.MetricSpec{
+		External: &v2.ExternalMetricSource{
+			Metric: v2.MetricIdentifier{
 				Name: "some metric name",
 			},
 		},
 	}
-	metricSpecs := []v2beta2.MetricSpec{metricSpec}
+	metricSpecs := []v2.MetricSpec{metricSpec}
 	ctx := context.Background()
 	scaler.EXPECT().GetMetricSpecForScaling(ctx).Return(metricSpecs)
 	scaleHandler.EXPECT().GetScalersCache(context.Background(), gomock.Eq(scaledObject)).Return(&scalersCache, nil)
@@ -97,11 +97,11 @@ func (r *MetricsScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.
 }
 
 func (r *MetricsScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&kedav1alpha1.ScaledObject{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Owns(&kedav1alpha1.ScaledObject{}).
-		WithOptions(options).
-		Complete(r)
+		WithOptions(options)
+	return watchScalerDependencies(b, r.ScaleHandler).Complete(r)
 }
 
 func (r *MetricsScaledObjectReconciler) addToMetricsCache(namespacedName string, metrics []string) {
@@ -23,8 +23,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
-	autoscalingv1 "k8s.io/api/autoscaling/v1"
-	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -47,8 +46,12 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+	"github.com/kedacore/keda/v2/pkg/debug"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/metrics"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling/scheduler"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -64,10 +67,12 @@ import (
 
 // ScaledObjectReconciler reconciles a ScaledObject object
 type ScaledObjectReconciler struct {
-	Client            client.Client
-	Scheme            *runtime.Scheme
-	GlobalHTTPTimeout time.Duration
-	Recorder          record.EventRecorder
+	Client             client.Client
+	Scheme             *runtime.Scheme
+	GlobalHTTPTimeout  time.Duration
+	Recorder           record.EventRecorder
+	ScaleLoopScheduler *scheduler.Scheduler
+	DebugServer        *debug.Server
 
 	scaleClient              scale.ScalesGetter
 	restMapper               meta.RESTMapper
@@ -76,6 +81,8 @@ type ScaledObjectReconciler struct {
 	kubeVersion              kedautil.K8sVersion
 }
 
+var metricsServer metrics.PrometheusMetricServer
+
 // A cache mapping "resource.group" to true or false if we know if this resource is scalable.
 var isScalableCache *sync.Map
 
@@ -106,6 +113,9 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 	} else {
 		setupLog.Error(err, "Not able to get Kubernetes version")
 	}
+	if !r.kubeVersion.SupportsAutoscalingV2() {
+		setupLog.Info("autoscaling/v2 isn't served, managing autoscaling/v2beta2 HPAs", "kubernetes.version", r.kubeVersion.PrettyVersion)
+	}
 
 	// Create Scale Client
 	scaleClient := initScaleClient(mgr, clientset)
@@ -114,19 +124,22 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 	// Init the rest of ScaledObjectReconciler
 	r.restMapper = mgr.GetRESTMapper()
 	r.scaledObjectsGenerations = &sync.Map{}
-	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), r.scaleClient, mgr.GetScheme(), r.GlobalHTTPTimeout, r.Recorder)
+	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), r.scaleClient, mgr.GetScheme(), r.GlobalHTTPTimeout, r.Recorder, r.ScaleLoopScheduler)
+	if r.DebugServer != nil {
+		r.DebugServer.RegisterScaleHandler("ScaledObject", r.scaleHandler)
+	}
 
 	// Start controller
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		// predicate.GenerationChangedPredicate{} ignore updates to ScaledObject Status
 		// (in this case metadata.Generation does not change)
 		// so reconcile loop is not started on Status updates
 		For(&kedav1alpha1.ScaledObject{}, builder.WithPredicates(
-			predicate.Or(kedacontrollerutil.PausedReplicasPredicate{}, predicate.GenerationChangedPredicate{}),
+			predicate.Or(kedacontrollerutil.PausedReplicasPredicate{}, kedacontrollerutil.AdoptHPAPredicate{}, predicate.GenerationChangedPredicate{}),
 		)).
-		Owns(&autoscalingv2beta2.HorizontalPodAutoscaler{}).
-		Complete(r)
+		Owns(r.hpaObject())
+	return watchScalerDependencies(b, r.scaleHandler).Complete(r)
 }
 
 func initScaleClient(mgr manager.Manager, clientset *discovery.DiscoveryClient) scale.ScalesGetter {
@@ -138,8 +151,15 @@ func initScaleClient(mgr manager.Manager, clientset *discovery.DiscoveryClient)
 	)
 }
 
-//  Reconcile performs reconciliation on the identified ScaledObject resource based on the request information passed, returns the result and an error (if any).
+// Reconcile performs reconciliation on the identified ScaledObject resource based on the request information passed, returns the result and an error (if any).
 func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartSpan(ctx, "ScaledObject.Reconcile", trace.WithAttributes(tracing.ScalableObjectAttributes("ScaledObject", req.Namespace, req.Name)...))
+	result, err := r.reconcile(ctx, req)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+func (r *ScaledObjectReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	reqLogger := log.FromContext(ctx)
 
 	// Fetch the ScaledObject instance
@@ -147,6 +167,7 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	err := r.Client.Get(ctx, req.NamespacedName, scaledObject)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			metricsServer.DeleteResource(req.Namespace, "ScaledObject", req.Name)
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
@@ -162,9 +183,12 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	// Check if the ScaledObject instance is marked to be deleted, which is
 	// indicated by the deletion timestamp being set.
 	if scaledObject.GetDeletionTimestamp() != nil {
+		metricsServer.DeleteResource(scaledObject.Namespace, "ScaledObject", scaledObject.Name)
 		return ctrl.Result{}, r.finalizeScaledObject(ctx, reqLogger, scaledObject)
 	}
 
+	metricsServer.RecordResource(scaledObject.Namespace, "ScaledObject", scaledObject.Name)
+
 	// ensure finalizer is set on this CR
 	if err := r.ensureFinalizer(ctx, reqLogger, scaledObject); err != nil {
 		return ctrl.Result{}, err
@@ -183,9 +207,10 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	conditions := scaledObject.Status.Conditions.DeepCopy()
 	if err != nil {
 		reqLogger.Error(err, msg)
-		conditions.SetReadyCondition(metav1.ConditionFalse, "ScaledObjectCheckFailed", msg)
+		conditionReason, eventReason, message := scaledObjectCheckFailedReasons(err, msg)
+		conditions.SetReadyCondition(metav1.ConditionFalse, conditionReason, message)
 		conditions.SetActiveCondition(metav1.ConditionUnknown, "UnkownState", "ScaledObject check failed")
-		r.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.ScaledObjectCheckFailed, msg)
+		r.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventReason, message)
 	} else {
 		wasReady := conditions.GetReadyCondition()
 		if wasReady.IsFalse() || wasReady.IsUnknown() {
@@ -216,7 +241,7 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 		return "Failed to update ScaledObject with scaledObjectName label", err
 	}
 
-	// Check if resource targeted for scaling exists and exposes /scale subresource
+	// Check if resource targeted for scaling exists and exposes /scale subresource or the replicasPath
 	gvkr, err := r.checkTargetResourceIsScalable(ctx, logger, scaledObject)
 	if err != nil {
 		return "ScaledObject doesn't have correct scaleTargetRef specification", err
@@ -227,10 +252,19 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 		return "ScaledObject doesn't have correct Idle/Min/Max Replica Counts specification", err
 	}
 
-	// Create a new HPA or update existing one according to ScaledObject
-	newHPACreated, err := r.ensureHPAForScaledObjectExists(ctx, logger, scaledObject, &gvkr)
-	if err != nil {
-		return "Failed to ensure HPA is correctly created for ScaledObject", err
+	newHPACreated := false
+	if scaledObject.Spec.ScaleTargetRef.ReplicasPath == "" {
+		// Create a new HPA or update existing one according to ScaledObject
+		newHPACreated, err = r.ensureHPAForScaledObjectExists(ctx, logger, scaledObject, &gvkr)
+		if err != nil {
+			return "Failed to ensure HPA is correctly created for ScaledObject", err
+		}
+	} else {
+		// No HPA can scale a target through its replicasPath, KEDA scales it from the scale loop
+		err = r.ensureNoHPAForScaledObject(ctx, logger, scaledObject)
+		if err != nil {
+			return "Failed to ensure ScaledObject scaling through scaleTargetRef.replicasPath has no HPA", err
+		}
 	}
 	scaleObjectSpecChanged := false
 	if !newHPACreated {
@@ -272,7 +306,8 @@ func (r *ScaledObjectReconciler) ensureScaledObjectLabel(ctx context.Context, lo
 	return r.Client.Update(ctx, scaledObject)
 }
 
-// checkTargetResourceIsScalable checks if resource targeted for scaling exists and exposes /scale subresource
+// checkTargetResourceIsScalable checks if resource targeted for scaling exists and exposes /scale subresource,
+// or a replica count at the replicasPath of the ScaledObject
 func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) (kedav1alpha1.GroupVersionKindResource, error) {
 	gvkr, err := kedautil.ParseGVKR(r.restMapper, scaledObject.Spec.ScaleTargetRef.APIVersion, scaledObject.Spec.ScaleTargetRef.Kind)
 	if err != nil {
@@ -288,14 +323,30 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 	wantStatusUpdate := scaledObject.Status.ScaleTargetKind != gvkString || scaledObject.Status.OriginalReplicaCount == nil || removePausedStatus
 
 	// check if we already know.
-	var scale *autoscalingv1.Scale
+	var originalReplicas int32
 	gr := gvkr.GroupResource()
 	_, isScalable := isScalableCache.Load(gr.String())
-	if !isScalable || wantStatusUpdate {
+	if path := scaledObject.Spec.ScaleTargetRef.ReplicasPath; path != "" {
+		// the /scale subresource isn't needed, the replica count is read from the replicasPath of the resource
+		unstruct := &unstructured.Unstructured{}
+		unstruct.SetGroupVersionKind(gvkr.GroupVersionKind())
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: scaledObject.Namespace, Name: scaledObject.Spec.ScaleTargetRef.Name}, unstruct); err != nil {
+			logger.Error(err, "Target resource doesn't exist", "resource", gvkString, "name", scaledObject.Spec.ScaleTargetRef.Name)
+			return gvkr, err
+		}
+		replicas, _, err := kedautil.GetReplicasFromPath(unstruct, path)
+		if err == nil && scaledObject.Spec.ScaleTargetRef.StatusReplicasPath != "" {
+			_, _, err = kedautil.GetReplicasFromPath(unstruct, scaledObject.Spec.ScaleTargetRef.StatusReplicasPath)
+		}
+		if err != nil {
+			logger.Error(err, "Target resource doesn't expose a replica count at the replicas path", "resource", gvkString, "name", scaledObject.Spec.ScaleTargetRef.Name)
+			return gvkr, err
+		}
+		originalReplicas = replicas
+	} else if !isScalable || wantStatusUpdate {
 		// not cached, let's try to detect /scale subresource
 		// also rechecks when we need to update the status.
-		var errScale error
-		scale, errScale = (r.scaleClient).Scales(scaledObject.Namespace).Get(ctx, gr, scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
+		scale, errScale := (r.scaleClient).Scales(scaledObject.Namespace).Get(ctx, gr, scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
 		if errScale != nil {
 			// not able to get /scale subresource -> let's check if the resource even exist in the cluster
 			unstruct := &unstructured.Unstructured{}
@@ -310,6 +361,7 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 			return gvkr, errScale
 		}
 		isScalableCache.Store(gr.String(), true)
+		originalReplicas = scale.Spec.Replicas
 	}
 
 	// if it is not already present in ScaledObject Status:
@@ -322,7 +374,7 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 			status.ScaleTargetGVKR = &gvkr
 		}
 		if scaledObject.Status.OriginalReplicaCount == nil {
-			status.OriginalReplicaCount = &scale.Spec.Replicas
+			status.OriginalReplicaCount = &originalReplicas
 		}
 
 		if removePausedStatus {
@@ -360,10 +412,13 @@ func (r *ScaledObjectReconciler) checkReplicaCountBoundsAreValid(scaledObject *k
 
 // ensureHPAForScaledObjectExists ensures that in cluster exist up-to-date HPA for specified ScaledObject, returns true if a new HPA was created
 func (r *ScaledObjectReconciler) ensureHPAForScaledObjectExists(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource) (bool, error) {
+	if err := r.checkExistingHPAs(ctx, logger, scaledObject, gvkr); err != nil {
+		return false, err
+	}
+
 	hpaName := getHPAName(scaledObject)
-	foundHpa := &autoscalingv2beta2.HorizontalPodAutoscaler{}
 	// Check if HPA for this ScaledObject already exists
-	err := r.Client.Get(ctx, types.NamespacedName{Name: hpaName, Namespace: scaledObject.Namespace}, foundHpa)
+	foundHpa, err := r.getHPA(ctx, types.NamespacedName{Name: hpaName, Namespace: scaledObject.Namespace})
 	if err != nil && errors.IsNotFound(err) {
 		// HPA wasn't found -> let's create a new one
 		err = r.createAndDeployNewHPA(ctx, logger, scaledObject, gvkr)
@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+// watchScalerDependencies watches the objects scalers are built from and rebuilds the scalers of the triggers built from changed objects
+func watchScalerDependencies(b *builder.Builder, scaleHandler scaling.ScaleHandler) *builder.Builder {
+	return b.
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, scalerDependencyHandler(scaleHandler, "ConfigMap")).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, scalerDependencyHandler(scaleHandler, "Secret")).
+		Watches(&source.Kind{Type: &kedav1alpha1.TriggerAuthentication{}}, scalerDependencyHandler(scaleHandler, "TriggerAuthentication")).
+		Watches(&source.Kind{Type: &kedav1alpha1.ClusterTriggerAuthentication{}}, scalerDependencyHandler(scaleHandler, "ClusterTriggerAuthentication"))
+}
+
+// scalerDependencyHandler refreshes the scalers depending on the object of the event, it doesn't enqueue any request
+func scalerDependencyHandler(scaleHandler scaling.ScaleHandler, kind string) handler.EventHandler {
+	refresh := func(obj client.Object) {
+		scaleHandler.RefreshDependentScalers(context.Background(), cache.Dependency{Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName()})
+	}
+	return handler.Funcs{
+		CreateFunc: func(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+			refresh(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+			if dependencyChanged(e.ObjectOld, e.ObjectNew) {
+				refresh(e.ObjectNew)
+			}
+		},
+		DeleteFunc: func(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+			refresh(e.Object)
+		},
+	}
+}
+
+// dependencyChanged ignores periodic resyncs and, for objects with a generation, updates of the status or the metadata
+func dependencyChanged(old, new client.Object) bool {
+	if new.GetGeneration() != 0 {
+		return old.GetGeneration() != new.GetGeneration()
+	}
+	return old.GetResourceVersion() != new.GetResourceVersion()
+}
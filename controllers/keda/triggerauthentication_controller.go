@@ -22,12 +22,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
@@ -50,27 +54,75 @@ func (r *TriggerAuthenticationReconciler) Reconcile(ctx context.Context, req ctr
 	err := r.Client.Get(ctx, req.NamespacedName, triggerAuthentication)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			metricsServer.DeleteResource(req.Namespace, "TriggerAuthentication", req.Name)
 			return ctrl.Result{}, nil
 		}
 		reqLogger.Error(err, "Failed ot get TriggerAuthentication")
 		return ctrl.Result{}, err
 	}
 
+	auth := authentication{
+		object:             triggerAuthentication,
+		kind:               "TriggerAuthentication",
+		spec:               &triggerAuthentication.Spec,
+		status:             &triggerAuthentication.Status,
+		secretNamespace:    triggerAuthentication.Namespace,
+		referenceNamespace: triggerAuthentication.Namespace,
+		inUseReason:        eventreason.TriggerAuthenticationInUse,
+	}
+
 	if triggerAuthentication.GetDeletionTimestamp() != nil {
+		finalized, err := finalizeAuthentication(ctx, r.Client, r.Recorder, reqLogger, auth)
+		if err != nil || !finalized {
+			return ctrl.Result{}, err
+		}
+		metricsServer.DeleteResource(triggerAuthentication.Namespace, "TriggerAuthentication", triggerAuthentication.Name)
 		r.Recorder.Event(triggerAuthentication, corev1.EventTypeNormal, eventreason.TriggerAuthenticationDeleted, "TriggerAuthentication was deleted")
 		return ctrl.Result{}, nil
 	}
 
+	if err := ensureAuthenticationFinalizer(ctx, r.Client, reqLogger, auth); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	metricsServer.RecordResource(triggerAuthentication.Namespace, "TriggerAuthentication", triggerAuthentication.Name)
+
 	if triggerAuthentication.ObjectMeta.Generation == 1 {
 		r.Recorder.Event(triggerAuthentication, corev1.EventTypeNormal, eventreason.TriggerAuthenticationAdded, "New TriggerAuthentication configured")
 	}
 
-	return ctrl.Result{}, nil
+	return updateAuthenticationStatus(ctx, r.Client, reqLogger, auth)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *TriggerAuthenticationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	scalableObjectRequests := handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		return authenticationRequestsForScalableObject(obj, "TriggerAuthentication")
+	})
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kedav1alpha1.TriggerAuthentication{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// the status lists the referencing ScaledObjects and ScaledJobs and the referenced Secrets and ConfigMaps have to be resolvable
+		Watches(&source.Kind{Type: &kedav1alpha1.ScaledObject{}}, scalableObjectRequests, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &kedav1alpha1.ScaledJob{}}, scalableObjectRequests, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.requestsForReferenced(referencesSecret))).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.requestsForReferenced(referencesConfigMap))).
 		Complete(r)
 }
+
+// requestsForReferenced maps a Secret or ConfigMap to the TriggerAuthentications of its namespace reading it
+func (r *TriggerAuthenticationReconciler) requestsForReferenced(references func(*kedav1alpha1.TriggerAuthenticationSpec, string) bool) handler.MapFunc {
+	return func(obj client.Object) []reconcile.Request {
+		triggerAuthentications := &kedav1alpha1.TriggerAuthenticationList{}
+		if err := r.Client.List(context.Background(), triggerAuthentications, client.InNamespace(obj.GetNamespace())); err != nil {
+			log.Log.Error(err, "Failed to list TriggerAuthentications", "namespace", obj.GetNamespace())
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, triggerAuthentication := range triggerAuthentications.Items {
+			if references(&triggerAuthentication.Spec, obj.GetName()) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: triggerAuthentication.Namespace, Name: triggerAuthentication.Name}})
+			}
+		}
+		return requests
+	}
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/controllers/keda/util"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
+)
+
+const (
+	triggerAuthenticationFinalizer = "finalizer.keda.sh"
+
+	// authenticationRecheckInterval is the interval a TriggerAuthentication that can't be resolved is checked again in,
+	// Vault and Key Vault secrets aren't watched
+	authenticationRecheckInterval = 5 * time.Minute
+)
+
+// authentication is a TriggerAuthentication or a ClusterTriggerAuthentication
+type authentication struct {
+	object client.Object
+	kind   string
+	spec   *kedav1alpha1.TriggerAuthenticationSpec
+	status *kedav1alpha1.TriggerAuthenticationStatus
+	// secretNamespace is the namespace the referenced Secrets and ConfigMaps are read from
+	secretNamespace string
+	// referenceNamespace is the namespace of the referencing ScaledObjects and ScaledJobs, empty for ClusterTriggerAuthentications
+	referenceNamespace string
+	// inUseReason is the reason of the event recorded while a referenced authentication is deleted
+	inUseReason string
+}
+
+// ensureAuthenticationFinalizer adds the finalizer keeping referenced authentications from being deleted
+func ensureAuthenticationFinalizer(ctx context.Context, c client.Client, logger logr.Logger, auth authentication) error {
+	if util.Contains(auth.object.GetFinalizers(), triggerAuthenticationFinalizer) {
+		return nil
+	}
+	logger.Info("Adding Finalizer for the " + auth.kind)
+	auth.object.SetFinalizers(append(auth.object.GetFinalizers(), triggerAuthenticationFinalizer))
+	if err := c.Update(ctx, auth.object); err != nil {
+		logger.Error(err, "Failed to update "+auth.kind+" with a finalizer", "finalizer", triggerAuthenticationFinalizer)
+		return err
+	}
+	return nil
+}
+
+// finalizeAuthentication removes the finalizer of a deleted authentication once no ScaledObject or ScaledJob references it,
+// it returns whether the finalizer was removed
+func finalizeAuthentication(ctx context.Context, c client.Client, recorder record.EventRecorder, logger logr.Logger, auth authentication) (bool, error) {
+	if !util.Contains(auth.object.GetFinalizers(), triggerAuthenticationFinalizer) {
+		return true, nil
+	}
+
+	scaledObjects, scaledJobs, err := authenticationReferences(ctx, c, auth)
+	if err != nil {
+		return false, err
+	}
+	if len(scaledObjects) > 0 || len(scaledJobs) > 0 {
+		status := auth.status.DeepCopy()
+		status.ScaledObjects, status.ScaledJobs = scaledObjects, scaledJobs
+		if err := patchAuthenticationStatus(ctx, c, logger, auth, status); err != nil {
+			return false, err
+		}
+		recorder.Eventf(auth.object, corev1.EventTypeWarning, auth.inUseReason, "%s is deleted once it isn't referenced anymore, referenced by %s",
+			auth.kind, describeReferences(scaledObjects, scaledJobs))
+		return false, nil
+	}
+
+	auth.object.SetFinalizers(util.Remove(auth.object.GetFinalizers(), triggerAuthenticationFinalizer))
+	if err := c.Update(ctx, auth.object); err != nil {
+		logger.Error(err, "Failed to update "+auth.kind+" after removing a finalizer", "finalizer", triggerAuthenticationFinalizer)
+		return false, err
+	}
+	return true, nil
+}
+
+// updateAuthenticationStatus checks that the authentication can be resolved and lists the ScaledObjects and ScaledJobs referencing it
+func updateAuthenticationStatus(ctx context.Context, c client.Client, logger logr.Logger, auth authentication) (ctrl.Result, error) {
+	scaledObjects, scaledJobs, err := authenticationReferences(ctx, c, auth)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	status := &kedav1alpha1.TriggerAuthenticationStatus{
+		Conditions:    kedav1alpha1.Conditions{{Type: kedav1alpha1.ConditionReady, Status: metav1.ConditionUnknown}},
+		ScaledObjects: scaledObjects,
+		ScaledJobs:    scaledJobs,
+	}
+	result := ctrl.Result{}
+	problems := resolver.CheckTriggerAuthSpec(ctx, c, logger, auth.spec, auth.secretNamespace)
+	if len(problems) == 0 {
+		status.Conditions.SetReadyCondition(metav1.ConditionTrue, kedav1alpha1.TriggerAuthenticationConditionReadySuccessReason,
+			kedav1alpha1.TriggerAuthenticationConditionReadySuccessMessage)
+	} else {
+		messages := make([]string, 0, len(problems))
+		for _, problem := range problems {
+			messages = append(messages, problem.Message)
+		}
+		status.Conditions.SetReadyCondition(metav1.ConditionFalse, problems[0].Reason, strings.Join(messages, "; "))
+		result.RequeueAfter = authenticationRecheckInterval
+	}
+
+	return result, patchAuthenticationStatus(ctx, c, logger, auth, status)
+}
+
+func patchAuthenticationStatus(ctx context.Context, c client.Client, logger logr.Logger, auth authentication, status *kedav1alpha1.TriggerAuthenticationStatus) error {
+	if equality.Semantic.DeepEqual(auth.status, status) {
+		return nil
+	}
+	patch := client.MergeFrom(auth.object.DeepCopyObject().(client.Object))
+	*auth.status = *status
+	if err := c.Status().Patch(ctx, auth.object, patch); err != nil {
+		logger.Error(err, "Failed to patch "+auth.kind+" status")
+		return err
+	}
+	return nil
+}
+
+// authenticationReferences returns the sorted names of the ScaledObjects and ScaledJobs with a trigger referencing the authentication,
+// the names of ClusterTriggerAuthentication references are <namespace>/<name>
+func authenticationReferences(ctx context.Context, c client.Client, auth authentication) ([]string, []string, error) {
+	var opts []client.ListOption
+	if auth.referenceNamespace != "" {
+		opts = append(opts, client.InNamespace(auth.referenceNamespace))
+	}
+	referenceName := func(meta metav1.ObjectMeta) string {
+		if auth.referenceNamespace != "" {
+			return meta.Name
+		}
+		return meta.Namespace + "/" + meta.Name
+	}
+
+	scaledObjectList := &kedav1alpha1.ScaledObjectList{}
+	if err := c.List(ctx, scaledObjectList, opts...); err != nil {
+		return nil, nil, err
+	}
+	var scaledObjects []string
+	for _, scaledObject := range scaledObjectList.Items {
+		if referencesAuthentication(scaledObject.Spec.Triggers, auth.kind, auth.object.GetName()) {
+			scaledObjects = append(scaledObjects, referenceName(scaledObject.ObjectMeta))
+		}
+	}
+
+	scaledJobList := &kedav1alpha1.ScaledJobList{}
+	if err := c.List(ctx, scaledJobList, opts...); err != nil {
+		return nil, nil, err
+	}
+	var scaledJobs []string
+	for _, scaledJob := range scaledJobList.Items {
+		if referencesAuthentication(scaledJob.Spec.Triggers, auth.kind, auth.object.GetName()) {
+			scaledJobs = append(scaledJobs, referenceName(scaledJob.ObjectMeta))
+		}
+	}
+
+	sort.Strings(scaledObjects)
+	sort.Strings(scaledJobs)
+	return scaledObjects, scaledJobs, nil
+}
+
+func referencesAuthentication(triggers []kedav1alpha1.ScaleTriggers, kind, name string) bool {
+	for _, refName := range authenticationRefs(triggers, kind) {
+		if refName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticationRefs returns the names of the authentications of the passed kind referenced by the triggers
+func authenticationRefs(triggers []kedav1alpha1.ScaleTriggers, kind string) []string {
+	var names []string
+	for _, trigger := range triggers {
+		ref := trigger.AuthenticationRef
+		if ref == nil || ref.Name == "" {
+			continue
+		}
+		refKind := ref.Kind
+		if refKind == "" {
+			refKind = "TriggerAuthentication"
+		}
+		if refKind == kind {
+			names = append(names, ref.Name)
+		}
+	}
+	return names
+}
+
+// authenticationRequestsForScalableObject maps a ScaledObject or ScaledJob to the authentications of the passed kind its triggers reference
+func authenticationRequestsForScalableObject(obj client.Object, kind string) []reconcile.Request {
+	var triggers []kedav1alpha1.ScaleTriggers
+	switch o := obj.(type) {
+	case *kedav1alpha1.ScaledObject:
+		triggers = o.Spec.Triggers
+	case *kedav1alpha1.ScaledJob:
+		triggers = o.Spec.Triggers
+	default:
+		return nil
+	}
+
+	namespace := obj.GetNamespace()
+	if kind == "ClusterTriggerAuthentication" {
+		namespace = ""
+	}
+	var requests []reconcile.Request
+	for _, name := range authenticationRefs(triggers, kind) {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}})
+	}
+	return requests
+}
+
+// referencesSecret reports whether the spec reads the named Secret
+func referencesSecret(spec *kedav1alpha1.TriggerAuthenticationSpec, name string) bool {
+	for _, ref := range spec.SecretTargetRef {
+		if ref.Name == name {
+			return true
+		}
+	}
+	if vault := spec.AzureKeyVault; vault != nil && vault.Credentials != nil && vault.Credentials.ClientSecret != nil &&
+		vault.Credentials.ClientSecret.ValueFrom.SecretKeyRef.Name == name {
+		return true
+	}
+	if spec.AwsSecretManager != nil && referencesAwsCredentials(spec.AwsSecretManager.Credentials, name) {
+		return true
+	}
+	if spec.AwsParameterStore != nil && referencesAwsCredentials(spec.AwsParameterStore.Credentials, name) {
+		return true
+	}
+	return spec.GcpSecretManager != nil && spec.GcpSecretManager.Credentials != nil &&
+		spec.GcpSecretManager.Credentials.ClientSecret.SecretKeyRef.Name == name
+}
+
+func referencesConfigMap(spec *kedav1alpha1.TriggerAuthenticationSpec, name string) bool {
+	for _, ref := range spec.ConfigMapTargetRef {
+		if ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func referencesAwsCredentials(creds *kedav1alpha1.AwsCredentials, name string) bool {
+	if creds == nil {
+		return false
+	}
+	return creds.AccessKeyID.SecretKeyRef.Name == name || creds.SecretAccessKey.SecretKeyRef.Name == name ||
+		(creds.SessionToken != nil && creds.SessionToken.SecretKeyRef.Name == name)
+}
+
+func describeReferences(scaledObjects, scaledJobs []string) string {
+	var parts []string
+	if len(scaledObjects) > 0 {
+		parts = append(parts, fmt.Sprintf("ScaledObjects %s", strings.Join(scaledObjects, ", ")))
+	}
+	if len(scaledJobs) > 0 {
+		parts = append(parts, fmt.Sprintf("ScaledJobs %s", strings.Join(scaledJobs, ", ")))
+	}
+	return strings.Join(parts, " and ")
+}
+
+// checkFailedReasons returns the reasons of the Ready condition and of the event and the message recorded when the check of a
+// ScaledObject or ScaledJob failed, errors resolving a TriggerAuthentication get their own reason and name the failed parameters
+func checkFailedReasons(err error, conditionReason, eventReason, msg string) (string, string, string) {
+	if resolver.IsAuthResolutionError(err) {
+		return kedav1alpha1.ConditionReadyAuthResolutionFailedReason, eventreason.TriggerAuthenticationResolutionFailed, fmt.Sprintf("%s: %s", msg, err)
+	}
+	return conditionReason, eventReason, msg
+}
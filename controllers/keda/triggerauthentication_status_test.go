@@ -0,0 +1,139 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
+)
+
+var _ = Describe("TriggerAuthentication status", func() {
+	var (
+		kubeClient            client.Client
+		recorder              *record.FakeRecorder
+		triggerAuthentication *kedav1alpha1.TriggerAuthentication
+		auth                  authentication
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(kedav1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		triggerAuthentication = &kedav1alpha1.TriggerAuthentication{
+			ObjectMeta: metav1.ObjectMeta{Name: "auth", Namespace: "test", Finalizers: []string{triggerAuthenticationFinalizer}},
+			Spec: kedav1alpha1.TriggerAuthenticationSpec{
+				SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+					{Parameter: "password", Name: "credentials", Key: "password"},
+					{Parameter: "username", Name: "credentials", Key: "user"},
+				},
+			},
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "test"},
+			Data:       map[string][]byte{"password": []byte("secret")},
+		}
+		scaledObject := &kedav1alpha1.ScaledObject{
+			ObjectMeta: metav1.ObjectMeta{Name: "referencing", Namespace: "test"},
+			Spec: kedav1alpha1.ScaledObjectSpec{
+				ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "app"},
+				Triggers: []kedav1alpha1.ScaleTriggers{
+					{Type: "rabbitmq", AuthenticationRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "auth"}},
+				},
+			},
+		}
+		otherKind := &kedav1alpha1.ScaledObject{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-auth", Namespace: "test"},
+			Spec: kedav1alpha1.ScaledObjectSpec{
+				ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "app"},
+				Triggers: []kedav1alpha1.ScaleTriggers{
+					{Type: "rabbitmq", AuthenticationRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "auth", Kind: "ClusterTriggerAuthentication"}},
+				},
+			},
+		}
+		kubeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(triggerAuthentication, secret, scaledObject, otherKind).Build()
+		recorder = record.NewFakeRecorder(1)
+		auth = authentication{
+			object:             triggerAuthentication,
+			kind:               "TriggerAuthentication",
+			spec:               &triggerAuthentication.Spec,
+			status:             &triggerAuthentication.Status,
+			secretNamespace:    "test",
+			referenceNamespace: "test",
+			inUseReason:        eventreason.TriggerAuthenticationInUse,
+		}
+	})
+
+	It("reports unresolvable secrets and the referencing objects", func() {
+		result, err := updateAuthenticationStatus(context.Background(), kubeClient, logr.Discard(), auth)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(authenticationRecheckInterval))
+
+		stored := &kedav1alpha1.TriggerAuthentication{}
+		Expect(kubeClient.Get(context.Background(), client.ObjectKeyFromObject(triggerAuthentication), stored)).To(Succeed())
+		Expect(stored.Status.ScaledObjects).To(Equal([]string{"referencing"}))
+		Expect(stored.Status.ScaledJobs).To(BeEmpty())
+		ready := stored.Status.Conditions.GetReadyCondition()
+		Expect(ready.IsFalse()).To(BeTrue())
+		Expect(ready.Reason).To(Equal(resolver.AuthReasonSecretNotResolvable))
+		Expect(ready.Message).To(Equal("parameter username: key user not found in secret test/credentials"))
+	})
+
+	It("keeps a referenced TriggerAuthentication from being deleted", func() {
+		finalized, err := finalizeAuthentication(context.Background(), kubeClient, recorder, logr.Discard(), auth)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(finalized).To(BeFalse())
+		Expect(<-recorder.Events).To(ContainSubstring("referenced by ScaledObjects referencing"))
+
+		Expect(kubeClient.Delete(context.Background(), &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "referencing", Namespace: "test"}})).To(Succeed())
+		Expect(kubeClient.Get(context.Background(), client.ObjectKeyFromObject(triggerAuthentication), triggerAuthentication)).To(Succeed())
+		finalized, err = finalizeAuthentication(context.Background(), kubeClient, recorder, logr.Discard(), auth)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(finalized).To(BeTrue())
+		Expect(triggerAuthentication.Finalizers).To(BeEmpty())
+	})
+
+	It("reports TriggerAuthentication resolution errors with their own reason", func() {
+		authErr := &resolver.AuthResolutionError{Kind: "TriggerAuthentication", Name: "auth", Parameters: []*resolver.ParameterResolutionError{
+			{Parameter: "password", Source: resolver.AuthSourceSecret, Err: fmt.Errorf("key password not found in secret test/credentials")},
+		}}
+		conditionReason, eventReason, message := checkFailedReasons(fmt.Errorf("error building scalers: %w", authErr), "ScaledObjectCheckFailed", eventreason.ScaledObjectCheckFailed, "Failed to ensure HPA is correctly created for ScaledObject")
+		Expect(conditionReason).To(Equal(kedav1alpha1.ConditionReadyAuthResolutionFailedReason))
+		Expect(eventReason).To(Equal(eventreason.TriggerAuthenticationResolutionFailed))
+		Expect(message).To(ContainSubstring("parameter password: key password not found in secret test/credentials"))
+
+		conditionReason, eventReason, message = checkFailedReasons(fmt.Errorf("boom"), "ScaledObjectCheckFailed", eventreason.ScaledObjectCheckFailed, "Failed")
+		Expect(conditionReason).To(Equal("ScaledObjectCheckFailed"))
+		Expect(eventReason).To(Equal(eventreason.ScaledObjectCheckFailed))
+		Expect(message).To(Equal("Failed"))
+	})
+})
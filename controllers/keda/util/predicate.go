@@ -5,7 +5,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
-const PausedReplicasAnnotation = "autoscaling.keda.sh/paused-replicas"
+const (
+	PausedReplicasAnnotation = "autoscaling.keda.sh/paused-replicas"
+	// AdoptHPAAnnotation set to "true" lets a ScaledObject take over an existing HPA of its scale target
+	AdoptHPAAnnotation = "autoscaling.keda.sh/adopt-hpa"
+)
 
 type PausedReplicasPredicate struct {
 	predicate.Funcs
@@ -28,3 +32,16 @@ func (PausedReplicasPredicate) Update(e event.UpdateEvent) bool {
 	}
 	return false
 }
+
+// AdoptHPAPredicate triggers a reconcile when the annotation adopting an existing HPA is added or changed
+type AdoptHPAPredicate struct {
+	predicate.Funcs
+}
+
+func (AdoptHPAPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+	newVal, ok := e.ObjectNew.GetAnnotations()[AdoptHPAAnnotation]
+	return ok && newVal != e.ObjectOld.GetAnnotations()[AdoptHPAAnnotation]
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -28,12 +29,19 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollers "github.com/kedacore/keda/v2/controllers/keda"
+	"github.com/kedacore/keda/v2/pkg/cloudevents"
+	"github.com/kedacore/keda/v2/pkg/config"
+	"github.com/kedacore/keda/v2/pkg/debug"
+	"github.com/kedacore/keda/v2/pkg/scaling/executor"
+	"github.com/kedacore/keda/v2/pkg/scaling/scheduler"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 	"github.com/kedacore/keda/v2/version"
 	//nolint:gci
@@ -66,11 +74,37 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var scaleLoopStartJitter float64
+	var scaleLoopMaxConcurrency int
+	var scaleLoopTriggerRateLimits string
+	var tracingConfig tracing.Config
+	var debugConfig debug.Config
+	var configFile string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.Float64Var(&scaleLoopStartJitter, "scale-loop-start-jitter", 0,
+		"Fraction (0-1) of the polling interval used as the upper bound for a random delay of the first check of each scale loop.")
+	flag.IntVar(&scaleLoopMaxConcurrency, "scale-loop-max-concurrency", 0,
+		"The maximum number of scale loop checks executed concurrently, 0 means unlimited.")
+	flag.StringVar(&scaleLoopTriggerRateLimits, "scale-loop-trigger-rate-limits", "",
+		"Comma separated list of type=limit pairs, limiting the number of scale loop checks per second for ScaledObjects and ScaledJobs with a trigger of the given type.")
+	flag.StringVar(&tracingConfig.Endpoint, "tracing-otlp-endpoint", "",
+		"The host:port of the OTLP gRPC collector traces are exported to, tracing is disabled when empty.")
+	flag.BoolVar(&tracingConfig.Insecure, "tracing-otlp-insecure", false,
+		"Disable TLS for the connection to the OTLP collector.")
+	flag.Float64Var(&tracingConfig.SampleRatio, "tracing-sample-ratio", 0.1,
+		"Fraction (0-1) of traces that are sampled.")
+	flag.StringVar(&debugConfig.BindAddress, "debug-bind-address", "",
+		"The address the debug endpoint binds to, the endpoint is disabled when empty.")
+	flag.StringVar(&debugConfig.CertFile, "debug-tls-cert-file", "",
+		"The TLS certificate file of the debug endpoint, TLS is enabled when both the certificate and the key file are set.")
+	flag.StringVar(&debugConfig.KeyFile, "debug-tls-key-file", "",
+		"The TLS key file of the debug endpoint.")
+	flag.StringVar(&configFile, "config-file", "",
+		"The OperatorConfig file, its settings override the flags and environment variables and are reloaded when it changes.")
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
 
@@ -84,57 +118,141 @@ func main() {
 		os.Exit(1)
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	// default to 3 seconds if they don't pass the env var
+	globalHTTPTimeoutMS, err := kedautil.ResolveOsEnvInt("KEDA_HTTP_DEFAULT_TIMEOUT", 3000)
+	if err != nil {
+		setupLog.Error(err, "Invalid KEDA_HTTP_DEFAULT_TIMEOUT")
+		os.Exit(1)
+	}
+
+	scaledObjectMaxReconciles, err := kedautil.ResolveOsEnvInt("KEDA_SCALEDOBJECT_CTRL_MAX_RECONCILES", 5)
+	if err != nil {
+		setupLog.Error(err, "Invalid KEDA_SCALEDOBJECT_CTRL_MAX_RECONCILES")
+		os.Exit(1)
+	}
+
+	scaledJobMaxReconciles, err := kedautil.ResolveOsEnvInt("KEDA_SCALEDJOB_CTRL_MAX_RECONCILES", 1)
+	if err != nil {
+		setupLog.Error(err, "Invalid KEDA_SCALEDJOB_CTRL_MAX_RECONCILES")
+		os.Exit(1)
+	}
+
+	triggerRateLimits, err := scheduler.ParseTriggerRateLimits(scaleLoopTriggerRateLimits)
+	if err != nil {
+		setupLog.Error(err, "Invalid scale-loop-trigger-rate-limits")
+		os.Exit(1)
+	}
+
+	baseConfig := config.Default()
+	baseConfig.HTTP.Timeout.Duration = time.Duration(globalHTTPTimeoutMS) * time.Millisecond
+	baseConfig.Controllers.ScaledObjectMaxReconciles = scaledObjectMaxReconciles
+	baseConfig.Controllers.ScaledJobMaxReconciles = scaledJobMaxReconciles
+	baseConfig.Scaling.ScaleLoop = config.ScaleLoopConfig{
+		StartJitter:       scaleLoopStartJitter,
+		MaxConcurrency:    scaleLoopMaxConcurrency,
+		TriggerRateLimits: triggerRateLimits,
+	}
+	if namespace != "" {
+		baseConfig.WatchNamespaces = []string{namespace}
+	}
+	configWatcher, err := config.NewWatcher(configFile, baseConfig, ctrl.Log.WithName("config"))
+	if err != nil {
+		setupLog.Error(err, "Invalid configuration", "path", configFile)
+		os.Exit(1)
+	}
+	operatorConfig := configWatcher.Current()
+
+	managerOptions := ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
 		Port:                   9443,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "operator.keda.sh",
-		Namespace:              namespace,
-	})
+	}
+	switch len(operatorConfig.WatchNamespaces) {
+	case 0:
+	case 1:
+		managerOptions.Namespace = operatorConfig.WatchNamespaces[0]
+	default:
+		managerOptions.NewCache = cache.MultiNamespacedCacheBuilder(operatorConfig.WatchNamespaces)
+	}
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), managerOptions)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
-	// default to 3 seconds if they don't pass the env var
-	globalHTTPTimeoutMS, err := kedautil.ResolveOsEnvInt("KEDA_HTTP_DEFAULT_TIMEOUT", 3000)
+	scaleLoopScheduler, err := scheduler.NewScheduler(scheduler.Config{
+		StartJitter:         operatorConfig.Scaling.ScaleLoop.StartJitter,
+		MaxConcurrentChecks: operatorConfig.Scaling.ScaleLoop.MaxConcurrency,
+		TriggerRateLimits:   operatorConfig.Scaling.ScaleLoop.TriggerRateLimits,
+	})
 	if err != nil {
-		setupLog.Error(err, "Invalid KEDA_HTTP_DEFAULT_TIMEOUT")
+		setupLog.Error(err, "unable to create scale loop scheduler")
 		os.Exit(1)
 	}
 
-	scaledObjectMaxReconciles, err := kedautil.ResolveOsEnvInt("KEDA_SCALEDOBJECT_CTRL_MAX_RECONCILES", 5)
-	if err != nil {
-		setupLog.Error(err, "Invalid KEDA_SCALEDOBJECT_CTRL_MAX_RECONCILES")
+	// the proxy, CA bundle and minimum TLS version have to be set before the first HTTP client is created
+	if err := operatorConfig.ApplyHTTP(); err != nil {
+		setupLog.Error(err, "Invalid HTTP configuration")
 		os.Exit(1)
 	}
+	globalHTTPTimeout := operatorConfig.HTTP.Timeout.Duration
+	cloudEventEmitter := cloudevents.NewEmitter(kedautil.CreateHTTPClient(globalHTTPTimeout, false), ctrl.Log.WithName("cloudevents"))
+	eventRecorder := cloudevents.NewEventRecorder(mgr.GetEventRecorderFor("keda-operator"), cloudEventEmitter)
 
-	scaledJobMaxReconciles, err := kedautil.ResolveOsEnvInt("KEDA_SCALEDJOB_CTRL_MAX_RECONCILES", 1)
-	if err != nil {
-		setupLog.Error(err, "Invalid KEDA_SCALEDJOB_CTRL_MAX_RECONCILES")
+	applyConfig := func(operatorConfig *config.OperatorConfig) {
+		kedav1alpha1.SetDefaultPollingInterval(operatorConfig.Scaling.DefaultPollingInterval.Duration)
+		executor.SetDefaultCooldownPeriod(operatorConfig.Scaling.DefaultCooldownPeriod.Duration)
+		if err := operatorConfig.ApplyHTTP(); err != nil {
+			setupLog.Error(err, "unable to apply HTTP configuration")
+		}
+		cloudEventEmitter.SetEnabled(operatorConfig.FeatureEnabled(config.CloudEventsFeature))
+		if err := scaleLoopScheduler.Update(scheduler.Config{
+			StartJitter:         operatorConfig.Scaling.ScaleLoop.StartJitter,
+			MaxConcurrentChecks: operatorConfig.Scaling.ScaleLoop.MaxConcurrency,
+			TriggerRateLimits:   operatorConfig.Scaling.ScaleLoop.TriggerRateLimits,
+		}); err != nil {
+			setupLog.Error(err, "unable to update scale loop scheduler")
+		}
+	}
+	applyConfig(operatorConfig)
+	configWatcher.OnReload(applyConfig)
+	if err := mgr.Add(configWatcher); err != nil {
+		setupLog.Error(err, "unable to set up configuration watcher")
 		os.Exit(1)
 	}
 
-	globalHTTPTimeout := time.Duration(globalHTTPTimeoutMS) * time.Millisecond
-	eventRecorder := mgr.GetEventRecorderFor("keda-operator")
+	var debugServer *debug.Server
+	if debugConfig.BindAddress != "" {
+		debugServer = debug.NewServer(debugConfig, mgr.GetClient())
+		debugServer.RegisterConfig(func() interface{} { return configWatcher.Status() })
+		if err := mgr.Add(debugServer); err != nil {
+			setupLog.Error(err, "unable to set up debug server")
+			os.Exit(1)
+		}
+	}
 
 	if err = (&kedacontrollers.ScaledObjectReconciler{
-		Client:            mgr.GetClient(),
-		Scheme:            mgr.GetScheme(),
-		GlobalHTTPTimeout: globalHTTPTimeout,
-		Recorder:          eventRecorder,
-	}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: scaledObjectMaxReconciles}); err != nil {
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		GlobalHTTPTimeout:  globalHTTPTimeout,
+		Recorder:           eventRecorder,
+		ScaleLoopScheduler: scaleLoopScheduler,
+		DebugServer:        debugServer,
+	}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: operatorConfig.Controllers.ScaledObjectMaxReconciles}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledObject")
 		os.Exit(1)
 	}
 	if err = (&kedacontrollers.ScaledJobReconciler{
-		Client:            mgr.GetClient(),
-		Scheme:            mgr.GetScheme(),
-		GlobalHTTPTimeout: globalHTTPTimeout,
-		Recorder:          eventRecorder,
-	}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: scaledJobMaxReconciles}); err != nil {
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		GlobalHTTPTimeout:  globalHTTPTimeout,
+		Recorder:           eventRecorder,
+		ScaleLoopScheduler: scaleLoopScheduler,
+		DebugServer:        debugServer,
+	}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: operatorConfig.Controllers.ScaledJobMaxReconciles}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledJob")
 		os.Exit(1)
 	}
@@ -154,6 +272,15 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterTriggerAuthentication")
 		os.Exit(1)
 	}
+	if err = (&kedacontrollers.ClusterCloudEventSinkReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: eventRecorder,
+		Emitter:  cloudEventEmitter,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterCloudEventSink")
+		os.Exit(1)
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -165,14 +292,26 @@ func main() {
 		os.Exit(1)
 	}
 
+	ctx := ctrl.SetupSignalHandler()
+
+	tracingConfig.ServiceName = "keda-operator"
+	shutdownTracing, err := tracing.Init(ctx, tracingConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
 	setupLog.Info("Starting manager")
 	setupLog.Info(fmt.Sprintf("KEDA Version: %s", version.Version))
 	setupLog.Info(fmt.Sprintf("Git Commit: %s", version.GitCommit))
 	setupLog.Info(fmt.Sprintf("Go Version: %s", runtime.Version()))
 	setupLog.Info(fmt.Sprintf("Go OS/Arch: %s/%s", runtime.GOOS, runtime.GOARCH))
 
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	if err := shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "error shutting down tracing")
+	}
 }
@@ -0,0 +1,369 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metrics"
+)
+
+// CloudEvent types emitted by KEDA
+const (
+	ScaleTargetActivatedType       = "keda.scaletarget.activated.v1"
+	ScaleTargetDeactivatedType     = "keda.scaletarget.deactivated.v1"
+	ScaleTargetPausedType          = "keda.scaletarget.paused.v1"
+	ScaleTargetFallbackEnteredType = "keda.scaletarget.fallback.entered.v1"
+	ScaleTargetFallbackExitedType  = "keda.scaletarget.fallback.exited.v1"
+	TriggerFailingType             = "keda.trigger.failing.v1"
+	TriggerRecoveredType           = "keda.trigger.recovered.v1"
+	JobsCreatedType                = "keda.scaledjob.jobs.created.v1"
+)
+
+// EventTypes lists all CloudEvent types emitted by KEDA
+var EventTypes = []string{
+	ScaleTargetActivatedType,
+	ScaleTargetDeactivatedType,
+	ScaleTargetPausedType,
+	ScaleTargetFallbackEnteredType,
+	ScaleTargetFallbackExitedType,
+	TriggerFailingType,
+	TriggerRecoveredType,
+	JobsCreatedType,
+}
+
+const (
+	specVersion       = "1.0"
+	defaultMaxRetries = 3
+	defaultBufferSize = 100
+	initialBackoff    = time.Second
+	maxBackoff        = 30 * time.Second
+)
+
+// Event is a scaling lifecycle moment of a ScaledObject or ScaledJob
+type Event struct {
+	Type      string
+	Time      time.Time
+	Kind      string
+	Namespace string
+	Name      string
+	Reason    string
+	Message   string
+}
+
+// eventData is the JSON payload of the emitted CloudEvents
+type eventData struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message"`
+}
+
+// Emitter publishes Events to the sinks configured by ClusterCloudEventSinks.
+// Every sink has its own bounded buffer and delivery goroutine, so a slow or unavailable sink
+// neither blocks the scale loops nor delays the other sinks.
+type Emitter struct {
+	client   *http.Client
+	logger   logr.Logger
+	disabled int32
+
+	lock  sync.RWMutex
+	sinks map[string]*sink
+}
+
+type sink struct {
+	name       string
+	spec       kedav1alpha1.ClusterCloudEventSinkSpec
+	uri        string
+	source     string
+	included   map[string]bool
+	excluded   map[string]bool
+	namespaces map[string]bool
+	maxRetries int
+	queue      chan Event
+	cancel     context.CancelFunc
+}
+
+// NewEmitter creates an Emitter without sinks, the passed client is used for the delivery of the CloudEvents
+func NewEmitter(client *http.Client, logger logr.Logger) *Emitter {
+	return &Emitter{
+		client: client,
+		logger: logger,
+		sinks:  map[string]*sink{},
+	}
+}
+
+// SetEnabled turns the delivery of events on or off, events emitted while it is off are dropped
+func (e *Emitter) SetEnabled(enabled bool) {
+	var disabled int32
+	if !enabled {
+		disabled = 1
+	}
+	atomic.StoreInt32(&e.disabled, disabled)
+}
+
+// Emit queues the event for delivery to every sink subscribed to it, it never blocks.
+// The event is dropped for sinks whose buffer is full.
+func (e *Emitter) Emit(event Event) {
+	if e == nil || atomic.LoadInt32(&e.disabled) == 1 {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	for _, s := range e.sinks {
+		if !s.accepts(event) {
+			continue
+		}
+		select {
+		case s.queue <- event:
+		default:
+			e.logger.V(1).Info("CloudEvent buffer is full, dropping event", "sink", s.name, "type", event.Type)
+			metrics.RecordCloudEvent(s.name, metrics.CloudEventDropped)
+		}
+	}
+}
+
+// UpdateSink validates the spec and (re)starts delivery to the sink with the passed name,
+// an unchanged spec keeps the running sink and its buffered events
+func (e *Emitter) UpdateSink(name string, spec kedav1alpha1.ClusterCloudEventSinkSpec) error {
+	s, err := newSink(name, spec)
+	if err != nil {
+		return err
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if current, ok := e.sinks[name]; ok {
+		if equality.Semantic.DeepEqual(current.spec, spec) {
+			return nil
+		}
+		current.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	e.sinks[name] = s
+	go e.deliver(ctx, s)
+	return nil
+}
+
+// RemoveSink stops delivery to the sink with the passed name, its buffered events are dropped
+func (e *Emitter) RemoveSink(name string) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if s, ok := e.sinks[name]; ok {
+		s.cancel()
+		delete(e.sinks, name)
+	}
+}
+
+func newSink(name string, spec kedav1alpha1.ClusterCloudEventSinkSpec) (*sink, error) {
+	uri, err := url.Parse(spec.Destination.HTTP.URI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination uri: %s", err)
+	}
+	if uri.Scheme != "http" && uri.Scheme != "https" || uri.Host == "" {
+		return nil, fmt.Errorf("destination uri must be an absolute http or https URL, got %q", spec.Destination.HTTP.URI)
+	}
+
+	included, err := eventTypeSet(spec.EventSubscription.IncludedEventTypes)
+	if err != nil {
+		return nil, err
+	}
+	excluded, err := eventTypeSet(spec.EventSubscription.ExcludedEventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	var namespaces map[string]bool
+	if len(spec.Namespaces) > 0 {
+		namespaces = make(map[string]bool, len(spec.Namespaces))
+		for _, namespace := range spec.Namespaces {
+			namespaces[namespace] = true
+		}
+	}
+
+	maxRetries := defaultMaxRetries
+	bufferSize := defaultBufferSize
+	if spec.Delivery != nil {
+		if spec.Delivery.MaxRetries != nil {
+			if *spec.Delivery.MaxRetries < 0 {
+				return nil, fmt.Errorf("delivery.maxRetries must not be negative")
+			}
+			maxRetries = int(*spec.Delivery.MaxRetries)
+		}
+		if spec.Delivery.BufferSize != nil {
+			if *spec.Delivery.BufferSize < 1 {
+				return nil, fmt.Errorf("delivery.bufferSize must be at least 1")
+			}
+			bufferSize = int(*spec.Delivery.BufferSize)
+		}
+	}
+
+	clusterName := spec.ClusterName
+	if clusterName == "" {
+		clusterName = "kubernetes-default"
+	}
+
+	return &sink{
+		name:       name,
+		spec:       *spec.DeepCopy(),
+		uri:        uri.String(),
+		source:     "/keda/" + clusterName,
+		included:   included,
+		excluded:   excluded,
+		namespaces: namespaces,
+		maxRetries: maxRetries,
+		queue:      make(chan Event, bufferSize),
+	}, nil
+}
+
+func eventTypeSet(eventTypes []string) (map[string]bool, error) {
+	if len(eventTypes) == 0 {
+		return nil, nil
+	}
+	known := make(map[string]bool, len(EventTypes))
+	for _, eventType := range EventTypes {
+		known[eventType] = true
+	}
+	result := make(map[string]bool, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if !known[eventType] {
+			return nil, fmt.Errorf("unknown event type %q, supported types are: %s", eventType, strings.Join(EventTypes, ", "))
+		}
+		result[eventType] = true
+	}
+	return result, nil
+}
+
+func (s *sink) accepts(event Event) bool {
+	if s.included != nil && !s.included[event.Type] {
+		return false
+	}
+	if s.excluded[event.Type] {
+		return false
+	}
+	return s.namespaces == nil || s.namespaces[event.Namespace]
+}
+
+func (e *Emitter) deliver(ctx context.Context, s *sink) {
+	logger := e.logger.WithValues("sink", s.name)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-s.queue:
+			err := e.send(ctx, s, event)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				logger.Error(err, "Failed to deliver CloudEvent", "type", event.Type, "namespace", event.Namespace, "name", event.Name)
+				metrics.RecordCloudEvent(s.name, metrics.CloudEventFailed)
+				continue
+			}
+			metrics.RecordCloudEvent(s.name, metrics.CloudEventDelivered)
+		}
+	}
+}
+
+// send delivers the event, retrying network errors and retryable status codes with an exponential backoff
+func (e *Emitter) send(ctx context.Context, s *sink, event Event) error {
+	body, err := json.Marshal(eventData{
+		Kind:      event.Kind,
+		Namespace: event.Namespace,
+		Name:      event.Name,
+		Reason:    event.Reason,
+		Message:   event.Message,
+	})
+	if err != nil {
+		return err
+	}
+	id := uuid.NewString()
+
+	backoff := initialBackoff
+	for attempt := 0; ; attempt++ {
+		retryable, err := e.post(ctx, s, event, id, body)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt >= s.maxRetries {
+			return err
+		}
+
+		tmr := time.NewTimer(backoff)
+		select {
+		case <-tmr.C:
+		case <-ctx.Done():
+			tmr.Stop()
+			return ctx.Err()
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// post sends a single request in HTTP binary content mode, the attributes are passed as ce- headers
+func (e *Emitter) post(ctx context.Context, s *sink, event Event, id string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.uri, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("ce-specversion", specVersion)
+	req.Header.Set("ce-id", id)
+	req.Header.Set("ce-type", event.Type)
+	req.Header.Set("ce-source", s.source)
+	req.Header.Set("ce-subject", fmt.Sprintf("%s/%s/%s", event.Namespace, strings.ToLower(event.Kind), event.Name))
+	req.Header.Set("ce-time", event.Time.UTC().Format(time.RFC3339Nano))
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retryable := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout
+	return retryable, fmt.Errorf("sink responded with status %s", resp.Status)
+}
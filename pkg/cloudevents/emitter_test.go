@@ -0,0 +1,135 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+func newTestEmitter() *Emitter {
+	return NewEmitter(http.DefaultClient, logf.Log.WithName("cloudevents"))
+}
+
+func TestUpdateSinkValidation(t *testing.T) {
+	emitter := newTestEmitter()
+	negative := int32(-1)
+
+	tests := []struct {
+		name string
+		spec kedav1alpha1.ClusterCloudEventSinkSpec
+	}{
+		{"relative uri", kedav1alpha1.ClusterCloudEventSinkSpec{
+			Destination: kedav1alpha1.CloudEventDestination{HTTP: kedav1alpha1.CloudEventHTTPDestination{URI: "/events"}},
+		}},
+		{"unsupported scheme", kedav1alpha1.ClusterCloudEventSinkSpec{
+			Destination: kedav1alpha1.CloudEventDestination{HTTP: kedav1alpha1.CloudEventHTTPDestination{URI: "ftp://example.com"}},
+		}},
+		{"unknown event type", kedav1alpha1.ClusterCloudEventSinkSpec{
+			Destination:       kedav1alpha1.CloudEventDestination{HTTP: kedav1alpha1.CloudEventHTTPDestination{URI: "http://example.com"}},
+			EventSubscription: kedav1alpha1.CloudEventSubscription{IncludedEventTypes: []string{"keda.unknown.v1"}},
+		}},
+		{"negative retries", kedav1alpha1.ClusterCloudEventSinkSpec{
+			Destination: kedav1alpha1.CloudEventDestination{HTTP: kedav1alpha1.CloudEventHTTPDestination{URI: "http://example.com"}},
+			Delivery:    &kedav1alpha1.CloudEventDelivery{MaxRetries: &negative},
+		}},
+	}
+	for _, test := range tests {
+		assert.NotNil(t, emitter.UpdateSink("test", test.spec), test.name)
+	}
+	assert.Empty(t, emitter.sinks)
+}
+
+func TestSinkFilters(t *testing.T) {
+	s, err := newSink("test", kedav1alpha1.ClusterCloudEventSinkSpec{
+		Destination: kedav1alpha1.CloudEventDestination{HTTP: kedav1alpha1.CloudEventHTTPDestination{URI: "http://example.com"}},
+		EventSubscription: kedav1alpha1.CloudEventSubscription{
+			IncludedEventTypes: []string{ScaleTargetActivatedType, ScaleTargetDeactivatedType},
+			ExcludedEventTypes: []string{ScaleTargetDeactivatedType},
+		},
+		Namespaces: []string{"default"},
+	})
+	assert.Nil(t, err)
+
+	assert.True(t, s.accepts(Event{Type: ScaleTargetActivatedType, Namespace: "default"}))
+	assert.False(t, s.accepts(Event{Type: ScaleTargetActivatedType, Namespace: "other"}))
+	assert.False(t, s.accepts(Event{Type: ScaleTargetDeactivatedType, Namespace: "default"}))
+	assert.False(t, s.accepts(Event{Type: JobsCreatedType, Namespace: "default"}))
+}
+
+func TestEmitDeliversBinaryCloudEventWithRetry(t *testing.T) {
+	var requests int32
+	received := make(chan *http.Request, 1)
+	payloads := make(chan eventData, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var data eventData
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&data))
+		received <- r
+		payloads <- data
+	}))
+	defer server.Close()
+
+	emitter := newTestEmitter()
+	err := emitter.UpdateSink("test", kedav1alpha1.ClusterCloudEventSinkSpec{
+		Destination: kedav1alpha1.CloudEventDestination{HTTP: kedav1alpha1.CloudEventHTTPDestination{URI: server.URL}},
+		ClusterName: "test-cluster",
+	})
+	assert.Nil(t, err)
+	defer emitter.RemoveSink("test")
+
+	recorder := NewEventRecorder(record.NewFakeRecorder(1), emitter)
+	scaledObject := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "Scaled %s from %d to %d", "test", 0, 1)
+
+	select {
+	case r := <-received:
+		assert.Equal(t, "1.0", r.Header.Get("ce-specversion"))
+		assert.Equal(t, ScaleTargetActivatedType, r.Header.Get("ce-type"))
+		assert.Equal(t, "/keda/test-cluster", r.Header.Get("ce-source"))
+		assert.Equal(t, "default/scaledobject/test", r.Header.Get("ce-subject"))
+		assert.NotEmpty(t, r.Header.Get("ce-id"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		data := <-payloads
+		assert.Equal(t, "ScaledObject", data.Kind)
+		assert.Equal(t, "Scaled test from 0 to 1", data.Message)
+	case <-time.After(10 * time.Second):
+		t.Fatal("CloudEvent was not delivered")
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestEventRecorderIgnoresOtherReasons(t *testing.T) {
+	// the sink is registered without a delivery goroutine, so the queued events can be inspected
+	s, err := newSink("test", kedav1alpha1.ClusterCloudEventSinkSpec{
+		Destination: kedav1alpha1.CloudEventDestination{HTTP: kedav1alpha1.CloudEventHTTPDestination{URI: "http://example.com"}},
+	})
+	assert.Nil(t, err)
+	emitter := newTestEmitter()
+	emitter.sinks["test"] = s
+
+	recorder := NewEventRecorder(record.NewFakeRecorder(3), emitter)
+	scaledJob := &kedav1alpha1.ScaledJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	recorder.Event(scaledJob, corev1.EventTypeNormal, eventreason.KEDAScalersStarted, "Started scalers watch")
+	recorder.Event(&corev1.Pod{}, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "not a scalable object")
+	recorder.Event(scaledJob, corev1.EventTypeNormal, eventreason.KEDAJobsCreated, "Created 1 jobs")
+
+	assert.Len(t, s.queue, 1)
+	event := <-s.queue
+	assert.Equal(t, JobsCreatedType, event.Type)
+	assert.Equal(t, "ScaledJob", event.Kind)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+// eventTypes maps the reasons of the Kubernetes Events recorded for the scaling lifecycle to CloudEvent types
+var eventTypes = map[string]string{
+	eventreason.KEDAScaleTargetActivated:       ScaleTargetActivatedType,
+	eventreason.KEDAScaleTargetDeactivated:     ScaleTargetDeactivatedType,
+	eventreason.KEDAScaleTargetPaused:          ScaleTargetPausedType,
+	eventreason.KEDAScaleTargetFallbackEntered: ScaleTargetFallbackEnteredType,
+	eventreason.KEDAScaleTargetFallbackExited:  ScaleTargetFallbackExitedType,
+	eventreason.KEDATriggerFailing:             TriggerFailingType,
+	eventreason.KEDATriggerRecovered:           TriggerRecoveredType,
+	eventreason.KEDAJobsCreated:                JobsCreatedType,
+}
+
+type eventRecorder struct {
+	record.EventRecorder
+	emitter *Emitter
+}
+
+// NewEventRecorder wraps the recorder, so the Kubernetes Events of the scaling lifecycle
+// of ScaledObjects and ScaledJobs are emitted as CloudEvents as well
+func NewEventRecorder(recorder record.EventRecorder, emitter *Emitter) record.EventRecorder {
+	return &eventRecorder{
+		EventRecorder: recorder,
+		emitter:       emitter,
+	}
+}
+
+func (r *eventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.EventRecorder.Event(object, eventtype, reason, message)
+	r.emit(object, reason, message)
+}
+
+func (r *eventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.EventRecorder.Eventf(object, eventtype, reason, messageFmt, args...)
+	r.emit(object, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *eventRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
+	r.emit(object, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *eventRecorder) emit(object runtime.Object, reason, message string) {
+	eventType, ok := eventTypes[reason]
+	if !ok {
+		return
+	}
+
+	var kind string
+	switch object.(type) {
+	case *kedav1alpha1.ScaledObject:
+		kind = "ScaledObject"
+	case *kedav1alpha1.ScaledJob:
+		kind = "ScaledJob"
+	default:
+		return
+	}
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return
+	}
+
+	r.emitter.Emit(Event{
+		Type:      eventType,
+		Kind:      kind,
+		Namespace: accessor.GetNamespace(),
+		Name:      accessor.GetName(),
+		Reason:    reason,
+		Message:   message,
+	})
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config loads the versioned configuration file of the operator.
+// The file is optional, its values override the ones set by flags and environment variables.
+package config
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// APIVersion is the only supported apiVersion of the configuration file
+	APIVersion = "config.keda.sh/v1alpha1"
+	// Kind is the kind of the configuration file
+	Kind = "OperatorConfig"
+)
+
+// OperatorConfig holds the global settings of the operator
+type OperatorConfig struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+
+	HTTP        HTTPConfig        `json:"http"`
+	Scaling     ScalingConfig     `json:"scaling"`
+	Controllers ControllersConfig `json:"controllers"`
+	// WatchNamespaces lists the namespaces the operator watches, all namespaces are watched when empty
+	WatchNamespaces []string `json:"watchNamespaces,omitempty"`
+	// FeatureGates enables or disables features by name, see KnownFeatureGates
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// caBundle is the content of http.tls.caBundleFile
+	caBundle []byte
+}
+
+// HTTPConfig holds the settings of the HTTP clients used by the scalers
+type HTTPConfig struct {
+	// Timeout is the timeout of the HTTP requests of the scalers
+	Timeout metav1.Duration `json:"timeout"`
+	Proxy   ProxyConfig     `json:"proxy"`
+	TLS     TLSConfig       `json:"tls"`
+}
+
+// ProxyConfig holds the proxy of the HTTP clients, the proxy environment variables are used when no proxy is set
+type ProxyConfig struct {
+	HTTPProxy  string `json:"httpProxy,omitempty"`
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// NoProxy is a comma separated list of hosts, domains, IPs and CIDRs requested without proxy
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// TLSConfig holds the TLS defaults of the outgoing connections
+type TLSConfig struct {
+	// MinVersion is the minimum TLS version, one of 1.0, 1.1, 1.2 and 1.3
+	MinVersion string `json:"minVersion"`
+	// CABundleFile is a PEM file of CAs trusted in addition to the system CAs,
+	// usually a mounted ConfigMap or Secret
+	CABundleFile string `json:"caBundleFile,omitempty"`
+}
+
+// ScalingConfig holds the defaults of ScaledObjects and ScaledJobs and the limits of their scale loops
+type ScalingConfig struct {
+	// DefaultPollingInterval is the polling interval of objects without a pollingInterval
+	DefaultPollingInterval metav1.Duration `json:"defaultPollingInterval"`
+	// DefaultCooldownPeriod is the cooldown period of ScaledObjects without a cooldownPeriod
+	DefaultCooldownPeriod metav1.Duration `json:"defaultCooldownPeriod"`
+	ScaleLoop             ScaleLoopConfig `json:"scaleLoop"`
+}
+
+// ScaleLoopConfig holds the settings of the scale loop scheduler
+type ScaleLoopConfig struct {
+	// StartJitter is the fraction (0-1) of the polling interval used as the upper bound for a random delay of the first check
+	StartJitter float64 `json:"startJitter"`
+	// MaxConcurrency is the maximum number of scale loop checks executed concurrently, 0 means unlimited
+	MaxConcurrency int `json:"maxConcurrency"`
+	// TriggerRateLimits holds the maximum number of checks per second for each trigger type
+	TriggerRateLimits map[string]float64 `json:"triggerRateLimits,omitempty"`
+}
+
+// ControllersConfig holds the settings of the controllers
+type ControllersConfig struct {
+	ScaledObjectMaxReconciles int `json:"scaledObjectMaxReconciles"`
+	ScaledJobMaxReconciles    int `json:"scaledJobMaxReconciles"`
+}
+
+// Default returns the configuration used without flags, environment variables and configuration file
+func Default() *OperatorConfig {
+	return &OperatorConfig{
+		APIVersion: APIVersion,
+		Kind:       Kind,
+		HTTP: HTTPConfig{
+			Timeout: metav1.Duration{Duration: 3 * time.Second},
+			TLS:     TLSConfig{MinVersion: "1.2"},
+		},
+		Scaling: ScalingConfig{
+			DefaultPollingInterval: metav1.Duration{Duration: 30 * time.Second},
+			DefaultCooldownPeriod:  metav1.Duration{Duration: 5 * time.Minute},
+		},
+		Controllers: ControllersConfig{
+			ScaledObjectMaxReconciles: 5,
+			ScaledJobMaxReconciles:    1,
+		},
+		FeatureGates: map[string]bool{},
+	}
+}
+
+// Load reads the configuration file at path, the values of the file override the ones of base
+func Load(path string, base *OperatorConfig) (*OperatorConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading configuration file: %s", err)
+	}
+	return Parse(data, base)
+}
+
+// Parse parses and validates the content of a configuration file, the values of the file override the ones of base
+func Parse(data []byte, base *OperatorConfig) (*OperatorConfig, error) {
+	config := base.DeepCopy()
+	// the version is required in the file, it is not inherited
+	config.APIVersion, config.Kind = "", ""
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("error parsing configuration file: %s", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	config.caBundle = nil
+	if config.HTTP.TLS.CABundleFile != "" {
+		bundle, err := os.ReadFile(config.HTTP.TLS.CABundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA bundle: %s", err)
+		}
+		if !x509.NewCertPool().AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("invalid configuration: the CA bundle %s contains no PEM certificates", config.HTTP.TLS.CABundleFile)
+		}
+		config.caBundle = bundle
+	}
+	return config, nil
+}
+
+// Validate checks the configuration and returns all problems found
+func (c *OperatorConfig) Validate() error {
+	var problems []string
+	if c.APIVersion != APIVersion || c.Kind != Kind {
+		problems = append(problems, fmt.Sprintf("apiVersion and kind must be %s and %s, got %q and %q", APIVersion, Kind, c.APIVersion, c.Kind))
+	}
+	if c.HTTP.Timeout.Duration <= 0 {
+		problems = append(problems, "http.timeout must be positive")
+	}
+	if _, err := kedautil.ParseTLSVersion(c.HTTP.TLS.MinVersion); err != nil {
+		problems = append(problems, fmt.Sprintf("http.tls.minVersion must be one of 1.0, 1.1, 1.2 and 1.3, got %q", c.HTTP.TLS.MinVersion))
+	}
+	for field, proxy := range map[string]string{"httpProxy": c.HTTP.Proxy.HTTPProxy, "httpsProxy": c.HTTP.Proxy.HTTPSProxy} {
+		if proxy == "" {
+			continue
+		}
+		if proxyURL, err := url.Parse(proxy); err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			problems = append(problems, fmt.Sprintf("http.proxy.%s must be an absolute URL, got %q", field, proxy))
+		}
+	}
+	if c.Scaling.DefaultPollingInterval.Duration < time.Second {
+		problems = append(problems, "scaling.defaultPollingInterval must be at least 1s")
+	}
+	if c.Scaling.DefaultCooldownPeriod.Duration < 0 {
+		problems = append(problems, "scaling.defaultCooldownPeriod must not be negative")
+	}
+	if c.Scaling.ScaleLoop.StartJitter < 0 || c.Scaling.ScaleLoop.StartJitter > 1 {
+		problems = append(problems, "scaling.scaleLoop.startJitter must be between 0 and 1")
+	}
+	if c.Scaling.ScaleLoop.MaxConcurrency < 0 {
+		problems = append(problems, "scaling.scaleLoop.maxConcurrency must not be negative")
+	}
+	for triggerType, limit := range c.Scaling.ScaleLoop.TriggerRateLimits {
+		if limit <= 0 {
+			problems = append(problems, fmt.Sprintf("scaling.scaleLoop.triggerRateLimits.%s must be positive", triggerType))
+		}
+	}
+	if c.Controllers.ScaledObjectMaxReconciles < 1 {
+		problems = append(problems, "controllers.scaledObjectMaxReconciles must be at least 1")
+	}
+	if c.Controllers.ScaledJobMaxReconciles < 1 {
+		problems = append(problems, "controllers.scaledJobMaxReconciles must be at least 1")
+	}
+	for _, namespace := range c.WatchNamespaces {
+		if namespace == "" {
+			problems = append(problems, "watchNamespaces must not contain empty names")
+		}
+	}
+	for name := range c.FeatureGates {
+		if _, ok := KnownFeatureGates[name]; !ok {
+			problems = append(problems, fmt.Sprintf("unknown feature gate %s", name))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("invalid configuration: %s", strings.Join(problems, "; "))
+}
+
+// MinTLSVersion returns the TLS version of http.tls.minVersion
+func (c *OperatorConfig) MinTLSVersion() uint16 {
+	version, _ := kedautil.ParseTLSVersion(c.HTTP.TLS.MinVersion)
+	return version
+}
+
+// CABundle returns the content of http.tls.caBundleFile as read when the configuration was loaded
+func (c *OperatorConfig) CABundle() []byte {
+	return c.caBundle
+}
+
+// ApplyHTTP applies the proxy, the CA bundle and the minimum TLS version to the HTTP clients created afterwards
+func (c *OperatorConfig) ApplyHTTP() error {
+	if err := kedautil.SetCABundle(c.caBundle); err != nil {
+		return fmt.Errorf("invalid CA bundle %s: %s", c.HTTP.TLS.CABundleFile, err)
+	}
+	if err := kedautil.SetProxyConfig(kedautil.ProxyConfig{
+		HTTPProxy:  c.HTTP.Proxy.HTTPProxy,
+		HTTPSProxy: c.HTTP.Proxy.HTTPSProxy,
+		NoProxy:    c.HTTP.Proxy.NoProxy,
+	}); err != nil {
+		return err
+	}
+	kedautil.SetMinTLSVersion(c.MinTLSVersion())
+	return nil
+}
+
+// FeatureEnabled returns whether the feature gate is enabled, unset gates have their default value
+func (c *OperatorConfig) FeatureEnabled(name string) bool {
+	if enabled, ok := c.FeatureGates[name]; ok {
+		return enabled
+	}
+	return KnownFeatureGates[name]
+}
+
+// DeepCopy returns a copy of the configuration that shares no maps or slices with it
+func (c *OperatorConfig) DeepCopy() *OperatorConfig {
+	out := *c
+	if c.WatchNamespaces != nil {
+		out.WatchNamespaces = append([]string{}, c.WatchNamespaces...)
+	}
+	out.FeatureGates = make(map[string]bool, len(c.FeatureGates))
+	for name, enabled := range c.FeatureGates {
+		out.FeatureGates[name] = enabled
+	}
+	if c.Scaling.ScaleLoop.TriggerRateLimits != nil {
+		out.Scaling.ScaleLoop.TriggerRateLimits = make(map[string]float64, len(c.Scaling.ScaleLoop.TriggerRateLimits))
+		for triggerType, limit := range c.Scaling.ScaleLoop.TriggerRateLimits {
+			out.Scaling.ScaleLoop.TriggerRateLimits[triggerType] = limit
+		}
+	}
+	return &out
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+const testConfig = `
+apiVersion: config.keda.sh/v1alpha1
+kind: OperatorConfig
+http:
+  tls:
+    minVersion: "1.3"
+scaling:
+  defaultPollingInterval: 15s
+  scaleLoop:
+    triggerRateLimits:
+      kafka: 5
+watchNamespaces: [team-a, team-b]
+featureGates:
+  CloudEvents: false
+`
+
+func TestParse(t *testing.T) {
+	base := Default()
+	base.Scaling.ScaleLoop.MaxConcurrency = 10
+
+	config, err := Parse([]byte(testConfig), base)
+	assert.NoError(t, err)
+	assert.Equal(t, 15*time.Second, config.Scaling.DefaultPollingInterval.Duration)
+	assert.Equal(t, map[string]float64{"kafka": 5}, config.Scaling.ScaleLoop.TriggerRateLimits)
+	assert.Equal(t, []string{"team-a", "team-b"}, config.WatchNamespaces)
+	assert.False(t, config.FeatureEnabled(CloudEventsFeature))
+	assert.Equal(t, uint16(0x0304), config.MinTLSVersion())
+
+	// settings missing in the file keep the value of the base
+	assert.Equal(t, 10, config.Scaling.ScaleLoop.MaxConcurrency)
+	assert.Equal(t, 3*time.Second, config.HTTP.Timeout.Duration)
+	assert.Equal(t, 5*time.Minute, config.Scaling.DefaultCooldownPeriod.Duration)
+	assert.Nil(t, base.WatchNamespaces)
+	assert.True(t, base.FeatureEnabled(CloudEventsFeature))
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "missing version",
+			config: "scaling:\n  defaultPollingInterval: 15s\n",
+			err:    `invalid configuration: apiVersion and kind must be config.keda.sh/v1alpha1 and OperatorConfig, got "" and ""`,
+		},
+		{
+			name:   "unknown field",
+			config: "apiVersion: config.keda.sh/v1alpha1\nkind: OperatorConfig\nscaling:\n  pollingInterval: 15s\n",
+			err:    `error parsing configuration file: error unmarshaling JSON: while decoding JSON: json: unknown field "pollingInterval"`,
+		},
+		{
+			name: "invalid values",
+			config: "apiVersion: config.keda.sh/v1alpha1\nkind: OperatorConfig\nhttp:\n  timeout: 0s\n  tls:\n    minVersion: \"1.4\"\n" +
+				"scaling:\n  defaultPollingInterval: 100ms\n  scaleLoop:\n    startJitter: 2\ncontrollers:\n  scaledJobMaxReconciles: 0\nfeatureGates:\n  Unknown: true\n",
+			err: `invalid configuration: controllers.scaledJobMaxReconciles must be at least 1; http.timeout must be positive; ` +
+				`http.tls.minVersion must be one of 1.0, 1.1, 1.2 and 1.3, got "1.4"; scaling.defaultPollingInterval must be at least 1s; ` +
+				`scaling.scaleLoop.startJitter must be between 0 and 1; unknown feature gate Unknown`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse([]byte(test.config), Default())
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestWatcherReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(testConfig), 0600))
+
+	watcher, err := NewWatcher(path, Default(), logr.Discard())
+	assert.NoError(t, err)
+	var reloaded []*OperatorConfig
+	watcher.OnReload(func(config *OperatorConfig) {
+		reloaded = append(reloaded, config)
+	})
+
+	// unchanged content is not reloaded
+	watcher.reload()
+	assert.Empty(t, reloaded)
+
+	// an invalid file keeps the previous configuration
+	assert.NoError(t, os.WriteFile(path, []byte("apiVersion: config.keda.sh/v1alpha1\nkind: OperatorConfig\nscaling:\n  defaultPollingInterval: 0s\n"), 0600))
+	watcher.reload()
+	assert.Empty(t, reloaded)
+	status := watcher.Status()
+	assert.Equal(t, "invalid configuration: scaling.defaultPollingInterval must be at least 1s", status.LastError)
+	assert.Equal(t, 15*time.Second, status.Config.Scaling.DefaultPollingInterval.Duration)
+
+	// settings that require a restart keep their value
+	assert.NoError(t, os.WriteFile(path, []byte(`
+apiVersion: config.keda.sh/v1alpha1
+kind: OperatorConfig
+http:
+  timeout: 10s
+scaling:
+  defaultCooldownPeriod: 1m
+watchNamespaces: [team-a, team-b]
+`), 0600))
+	watcher.reload()
+	assert.Len(t, reloaded, 1)
+	assert.Equal(t, time.Minute, reloaded[0].Scaling.DefaultCooldownPeriod.Duration)
+	assert.Equal(t, 30*time.Second, reloaded[0].Scaling.DefaultPollingInterval.Duration)
+	assert.True(t, reloaded[0].FeatureEnabled(CloudEventsFeature))
+	assert.Equal(t, 3*time.Second, reloaded[0].HTTP.Timeout.Duration)
+
+	status = watcher.Status()
+	assert.Empty(t, status.LastError)
+	assert.Equal(t, []string{"http.timeout"}, status.RestartRequired)
+	assert.Equal(t, reloaded[0], watcher.Current())
+}
+
+func TestWatcherWithoutFile(t *testing.T) {
+	watcher, err := NewWatcher("", Default(), logr.Discard())
+	assert.NoError(t, err)
+	assert.Equal(t, Default(), watcher.Current())
+
+	_, err = NewWatcher(filepath.Join(t.TempDir(), "missing.yaml"), Default(), logr.Discard())
+	assert.Error(t, err)
+}
+
+func TestCABundle(t *testing.T) {
+	dir := t.TempDir()
+	bundlePath := filepath.Join(dir, "ca.crt")
+	configPath := filepath.Join(dir, "config.yaml")
+	content := "apiVersion: config.keda.sh/v1alpha1\nkind: OperatorConfig\nhttp:\n  proxy:\n    httpsProxy: http://proxy:3128\n  tls:\n    caBundleFile: " + bundlePath + "\n"
+	assert.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	assert.NoError(t, os.WriteFile(bundlePath, []byte("not a certificate"), 0600))
+	_, err := NewWatcher(configPath, Default(), logr.Discard())
+	assert.EqualError(t, err, "invalid configuration: the CA bundle "+bundlePath+" contains no PEM certificates")
+
+	assert.NoError(t, os.WriteFile(bundlePath, []byte(testCertificate), 0600))
+	watcher, err := NewWatcher(configPath, Default(), logr.Discard())
+	assert.NoError(t, err)
+	assert.Equal(t, testCertificate, string(watcher.Current().CABundle()))
+	assert.Equal(t, "http://proxy:3128", watcher.Current().HTTP.Proxy.HTTPSProxy)
+
+	// a change of the bundle reloads the configuration
+	reloads := 0
+	watcher.OnReload(func(*OperatorConfig) { reloads++ })
+	watcher.reload()
+	assert.Equal(t, 0, reloads)
+	assert.NoError(t, os.WriteFile(bundlePath, []byte(testCertificate+testCertificate), 0600))
+	watcher.reload()
+	assert.Equal(t, 1, reloads)
+	assert.Equal(t, testCertificate+testCertificate, string(watcher.Current().CABundle()))
+
+	_, err = Parse([]byte("apiVersion: config.keda.sh/v1alpha1\nkind: OperatorConfig\nhttp:\n  proxy:\n    httpProxy: proxy:3128\n"), Default())
+	assert.EqualError(t, err, `invalid configuration: http.proxy.httpProxy must be an absolute URL, got "proxy:3128"`)
+}
+
+const testCertificate = `-----BEGIN CERTIFICATE-----
+MIIBhTCCASugAwIBAgIQIRi6zePL6mKjOipn+dNuaTAKBggqhkjOPQQDAjASMRAw
+DgYDVQQKEwdBY21lIENvMB4XDTE3MTAyMDE5NDMwNloXDTE4MTAyMDE5NDMwNlow
+EjEQMA4GA1UEChMHQWNtZSBDbzBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABD0d
+7VNhbWvZLWPuj/RtHFjvtJBEwOkhbN/BnnE8rnZR8+sbwnc/KhCk3FhnpHZnQz7B
+5aETbbIgmuvewdjvSBSjYzBhMA4GA1UdDwEB/wQEAwICpDATBgNVHSUEDDAKBggr
+BgEFBQcDATAPBgNVHRMBAf8EBTADAQH/MCkGA1UdEQQiMCCCDmxvY2FsaG9zdDo1
+NDUzgg4xMjcuMC4wLjE6NTQ1MzAKBggqhkjOPQQDAgNIADBFAiEA2zpJEPQyz6/l
+Wf86aX6PepsntZv2GYlA5UpabfT2EZICICpJ5h/iI+i341gBmLiAFQOyTDT+/wQc
+6MF9+Yw1Yy0t
+-----END CERTIFICATE-----
+`
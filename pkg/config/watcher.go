@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const defaultWatchPeriod = 10 * time.Second
+
+// Status describes the configuration the operator runs with
+type Status struct {
+	Path     string          `json:"path,omitempty"`
+	LoadedAt time.Time       `json:"loadedAt"`
+	Config   *OperatorConfig `json:"effective"`
+	// RestartRequired lists the changed settings of the file that are only applied when the operator restarts
+	RestartRequired []string `json:"restartRequired,omitempty"`
+	// LastError is the error of the last reload, the previous configuration stays in effect
+	LastError string `json:"lastError,omitempty"`
+}
+
+// Watcher reloads the configuration file when its content or the content of the CA bundle changes.
+// The file is polled instead of watched, the symlinks of a mounted ConfigMap are replaced on every update
+// which makes file system notifications unreliable. Changes of settings that can't be applied
+// while the operator runs are ignored and reported in the Status.
+type Watcher struct {
+	path   string
+	base   *OperatorConfig
+	period time.Duration
+	logger logr.Logger
+
+	lock     sync.RWMutex
+	data     []byte
+	status   Status
+	handlers []func(*OperatorConfig)
+}
+
+// NewWatcher loads the configuration file at path on top of base, without a path the base configuration is used
+func NewWatcher(path string, base *OperatorConfig, logger logr.Logger) (*Watcher, error) {
+	if err := base.Validate(); err != nil {
+		return nil, err
+	}
+	w := &Watcher{
+		path:   path,
+		base:   base.DeepCopy(),
+		period: defaultWatchPeriod,
+		logger: logger,
+		status: Status{Path: path, LoadedAt: time.Now(), Config: base.DeepCopy()},
+	}
+	if path == "" {
+		return w, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config, err := Parse(data, base)
+	if err != nil {
+		return nil, err
+	}
+	w.data = data
+	w.status.Config = config
+	return w, nil
+}
+
+// Current returns the configuration in effect
+func (w *Watcher) Current() *OperatorConfig {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return w.status.Config.DeepCopy()
+}
+
+// Status returns the configuration in effect and the result of the last reload
+func (w *Watcher) Status() Status {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	status := w.status
+	status.Config = w.status.Config.DeepCopy()
+	status.RestartRequired = append([]string(nil), w.status.RestartRequired...)
+	return status
+}
+
+// OnReload registers a function called with the new configuration after every successful reload
+func (w *Watcher) OnReload(handler func(*OperatorConfig)) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.handlers = append(w.handlers, handler)
+}
+
+// Start polls the configuration file until the context is done
+func (w *Watcher) Start(ctx context.Context) error {
+	if w.path == "" {
+		return nil
+	}
+	ticker := time.NewTicker(w.period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			w.reload()
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, every replica applies its configuration
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// reload applies the configuration file if its content changed since the last reload
+func (w *Watcher) reload() {
+	data, err := os.ReadFile(w.path)
+	w.lock.Lock()
+	if err == nil && bytes.Equal(data, w.data) && !w.caBundleChanged() {
+		w.lock.Unlock()
+		return
+	}
+	if err == nil {
+		w.data = data
+		err = w.apply(data)
+	}
+	if err != nil {
+		if w.status.LastError != err.Error() {
+			w.logger.Error(err, "Failed to reload the configuration file, keeping the previous configuration", "path", w.path)
+		}
+		w.status.LastError = err.Error()
+		w.lock.Unlock()
+		return
+	}
+	config := w.status.Config.DeepCopy()
+	handlers := append([]func(*OperatorConfig){}, w.handlers...)
+	w.logger.Info("Reloaded the configuration file", "path", w.path, "restartRequired", w.status.RestartRequired)
+	w.lock.Unlock()
+
+	for _, handler := range handlers {
+		handler(config)
+	}
+}
+
+// caBundleChanged returns whether the CA bundle file differs from the bundle in effect
+func (w *Watcher) caBundleChanged() bool {
+	path := w.status.Config.HTTP.TLS.CABundleFile
+	if path == "" {
+		return false
+	}
+	bundle, err := os.ReadFile(path)
+	return err != nil || !bytes.Equal(bundle, w.status.Config.caBundle)
+}
+
+// apply replaces the configuration in effect, settings that require a restart keep their current value
+func (w *Watcher) apply(data []byte) error {
+	config, err := Parse(data, w.base)
+	if err != nil {
+		return err
+	}
+
+	current := w.status.Config
+	var restartRequired []string
+	if config.HTTP.Timeout != current.HTTP.Timeout {
+		restartRequired = append(restartRequired, "http.timeout")
+		config.HTTP.Timeout = current.HTTP.Timeout
+	}
+	if config.Controllers != current.Controllers {
+		restartRequired = append(restartRequired, "controllers")
+		config.Controllers = current.Controllers
+	}
+	if !reflect.DeepEqual(config.WatchNamespaces, current.WatchNamespaces) {
+		restartRequired = append(restartRequired, "watchNamespaces")
+		config.WatchNamespaces = current.WatchNamespaces
+	}
+
+	w.status.Config = config
+	w.status.LoadedAt = time.Now()
+	w.status.RestartRequired = restartRequired
+	w.status.LastError = ""
+	return nil
+}
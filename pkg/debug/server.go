@@ -0,0 +1,329 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling"
+)
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+const (
+	// ScalersPath is the prefix of the endpoint evaluating the scalers of a ScaledObject or ScaledJob,
+	// the full path is /debug/scalers/{namespace}/{kind}/{name}
+	ScalersPath = "/debug/scalers/"
+	// ConfigPath is the endpoint returning the effective configuration of the operator,
+	// callers need the get verb on this non-resource URL
+	ConfigPath = "/debug/config"
+
+	// Subresource is the subresource of scaledobjects and scaledjobs callers need the get verb on
+	Subresource = "debug"
+
+	defaultEvaluationTimeout = 30 * time.Second
+	shutdownTimeout          = 5 * time.Second
+)
+
+// Config configures the debug Server
+type Config struct {
+	// BindAddress is the address the server listens on
+	BindAddress string
+	// CertFile and KeyFile enable TLS when both are set
+	CertFile string
+	KeyFile  string
+	// EvaluationTimeout bounds the evaluation of the scalers of a single object
+	EvaluationTimeout time.Duration
+}
+
+type scalableKind struct {
+	kind     string
+	resource string
+	newObj   func() client.Object
+}
+
+var scalableKinds = map[string]scalableKind{
+	"scaledobject": {kind: "ScaledObject", resource: "scaledobjects", newObj: func() client.Object { return &kedav1alpha1.ScaledObject{} }},
+	"scaledjob":    {kind: "ScaledJob", resource: "scaledjobs", newObj: func() client.Object { return &kedav1alpha1.ScaledJob{} }},
+}
+
+// Server serves the debug endpoints of the operator.
+// Every request has to carry a bearer token, which is authenticated with a TokenReview, and the user has to be allowed
+// to get the debug subresource of the requested object, which is checked with a SubjectAccessReview.
+type Server struct {
+	config Config
+	client client.Client
+	logger logr.Logger
+
+	lock          sync.RWMutex
+	scaleHandlers map[string]scaling.ScaleHandler
+	configStatus  func() interface{}
+}
+
+// NewServer creates a debug Server, the client is used to read the requested objects and to review the callers
+func NewServer(config Config, client client.Client) *Server {
+	if config.EvaluationTimeout <= 0 {
+		config.EvaluationTimeout = defaultEvaluationTimeout
+	}
+	return &Server{
+		config:        config,
+		client:        client,
+		logger:        logf.Log.WithName("debugserver"),
+		scaleHandlers: map[string]scaling.ScaleHandler{},
+	}
+}
+
+// RegisterScaleHandler registers the ScaleHandler running the scale loops of the passed kind (ScaledObject or ScaledJob)
+func (s *Server) RegisterScaleHandler(kind string, handler scaling.ScaleHandler) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.scaleHandlers[strings.ToLower(kind)] = handler
+}
+
+// RegisterConfig registers the function returning the effective configuration of the operator served on ConfigPath
+func (s *Server) RegisterConfig(status func() interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.configStatus = status
+}
+
+// Handler returns the http.Handler serving the debug endpoints
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ScalersPath, s.serveScalers)
+	mux.HandleFunc(ConfigPath, s.serveConfig)
+	return mux
+}
+
+// Start runs the server until the context is done, it implements manager.Runnable
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              s.config.BindAddress,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		s.logger.Info("Starting debug server", "address", s.config.BindAddress)
+		var err error
+		if s.config.CertFile != "" && s.config.KeyFile != "" {
+			err = server.ListenAndServeTLS(s.config.CertFile, s.config.KeyFile)
+		} else {
+			err = server.ListenAndServe()
+		}
+		if !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// NeedLeaderElection makes sure the server runs only in the leader, the scale loops are not started in the other replicas
+func (s *Server) NeedLeaderElection() bool {
+	return true
+}
+
+func (s *Server) serveScalers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+
+	user, err := s.authenticate(r.Context(), r)
+	if err != nil {
+		s.logger.V(1).Info("Unauthenticated debug request", "error", err.Error())
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, ScalersPath), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("expected path %s{namespace}/{kind}/{name}", ScalersPath))
+		return
+	}
+	namespace, name := parts[0], parts[2]
+	kind, ok := scalableKinds[strings.TrimSuffix(strings.ToLower(parts[1]), "s")]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unsupported kind %q, expected scaledobject or scaledjob", parts[1]))
+		return
+	}
+
+	allowed, err := s.authorize(r.Context(), user, authorizationv1.SubjectAccessReviewSpec{
+		ResourceAttributes: &authorizationv1.ResourceAttributes{
+			Namespace:   namespace,
+			Verb:        "get",
+			Group:       kedav1alpha1.GroupVersion.Group,
+			Resource:    kind.resource,
+			Subresource: Subresource,
+			Name:        name,
+		},
+	})
+	if err != nil {
+		s.logger.Error(err, "Failed to authorize debug request")
+		writeError(w, http.StatusInternalServerError, "failed to authorize the request")
+		return
+	}
+	if !allowed {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("user %q cannot get %s/%s in namespace %q", user.Username, kind.resource, Subresource, namespace))
+		return
+	}
+
+	s.lock.RLock()
+	handler, ok := s.scaleHandlers[strings.ToLower(kind.kind)]
+	s.lock.RUnlock()
+	if !ok {
+		writeError(w, http.StatusServiceUnavailable, fmt.Sprintf("%s scale loops are not running", kind.kind))
+		return
+	}
+
+	obj := kind.newObj()
+	if err := s.client.Get(r.Context(), client.ObjectKey{Namespace: namespace, Name: name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s/%s not found", kind.kind, namespace, name))
+			return
+		}
+		s.logger.Error(err, "Failed to get scalable object", "kind", kind.kind, "namespace", namespace, "name", name)
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.config.EvaluationTimeout)
+	defer cancel()
+	report, err := handler.DebugScalableObject(ctx, obj)
+	if err != nil {
+		if errors.Is(err, scaling.ErrScalersCacheNotFound) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+func (s *Server) serveConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+
+	user, err := s.authenticate(r.Context(), r)
+	if err != nil {
+		s.logger.V(1).Info("Unauthenticated debug request", "error", err.Error())
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	allowed, err := s.authorize(r.Context(), user, authorizationv1.SubjectAccessReviewSpec{
+		NonResourceAttributes: &authorizationv1.NonResourceAttributes{Path: ConfigPath, Verb: "get"},
+	})
+	if err != nil {
+		s.logger.Error(err, "Failed to authorize debug request")
+		writeError(w, http.StatusInternalServerError, "failed to authorize the request")
+		return
+	}
+	if !allowed {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("user %q cannot get %s", user.Username, ConfigPath))
+		return
+	}
+
+	s.lock.RLock()
+	configStatus := s.configStatus
+	s.lock.RUnlock()
+	if configStatus == nil {
+		writeError(w, http.StatusServiceUnavailable, "the configuration is not available")
+		return
+	}
+	writeJSON(w, http.StatusOK, configStatus())
+}
+
+// authenticate reviews the bearer token of the request and returns the user it belongs to
+func (s *Server) authenticate(ctx context.Context, r *http.Request) (authenticationv1.UserInfo, error) {
+	header := r.Header.Get("Authorization")
+	token := strings.TrimPrefix(header, "Bearer ")
+	if header == "" || token == header || token == "" {
+		return authenticationv1.UserInfo{}, fmt.Errorf("missing bearer token")
+	}
+
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}
+	if err := s.client.Create(ctx, review); err != nil {
+		return authenticationv1.UserInfo{}, fmt.Errorf("error reviewing token: %s", err)
+	}
+	if !review.Status.Authenticated {
+		return authenticationv1.UserInfo{}, fmt.Errorf("token is not authenticated: %s", review.Status.Error)
+	}
+	return review.Status.User, nil
+}
+
+// authorize checks whether the user may access the resource or non-resource URL of the passed spec
+func (s *Server) authorize(ctx context.Context, user authenticationv1.UserInfo, spec authorizationv1.SubjectAccessReviewSpec) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+
+	spec.User = user.Username
+	spec.Groups = user.Groups
+	spec.UID = user.UID
+	spec.Extra = extra
+	review := &authorizationv1.SubjectAccessReview{Spec: spec}
+	if err := s.client.Create(ctx, review); err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
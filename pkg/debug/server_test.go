@@ -0,0 +1,154 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling"
+)
+
+const validToken = "valid-token"
+
+// reviewClient answers TokenReviews and SubjectAccessReviews like the API server would
+type reviewClient struct {
+	client.Client
+	allowed         bool
+	lastResource    *authorizationv1.ResourceAttributes
+	lastNonResource *authorizationv1.NonResourceAttributes
+}
+
+func (c *reviewClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	switch review := obj.(type) {
+	case *authenticationv1.TokenReview:
+		if review.Spec.Token == validToken {
+			review.Status.Authenticated = true
+			review.Status.User = authenticationv1.UserInfo{Username: "jane", Groups: []string{"developers"}}
+		}
+		return nil
+	case *authorizationv1.SubjectAccessReview:
+		c.lastResource = review.Spec.ResourceAttributes
+		c.lastNonResource = review.Spec.NonResourceAttributes
+		review.Status.Allowed = c.allowed && review.Spec.User == "jane"
+		return nil
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func newTestServer(t *testing.T, allowed bool) (*Server, *reviewClient, *mock_scaling.MockScaleHandler) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	scaledObject := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+	c := &reviewClient{
+		Client:  fake.NewClientBuilder().WithScheme(scheme).WithObjects(scaledObject).Build(),
+		allowed: allowed,
+	}
+
+	handler := mock_scaling.NewMockScaleHandler(gomock.NewController(t))
+	server := NewServer(Config{}, c)
+	server.RegisterScaleHandler("ScaledObject", handler)
+	return server, c, handler
+}
+
+func doRequest(server *Server, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServeScalers(t *testing.T) {
+	server, c, handler := newTestServer(t, true)
+	handler.EXPECT().DebugScalableObject(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, obj interface{}) (*scaling.DebugReport, error) {
+		scaledObject := obj.(*kedav1alpha1.ScaledObject)
+		return &scaling.DebugReport{Kind: "ScaledObject", Namespace: scaledObject.Namespace, Name: scaledObject.Name, CacheGeneration: 3}, nil
+	})
+
+	rec := doRequest(server, "/debug/scalers/default/scaledobject/app", validToken)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var report scaling.DebugReport
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	assert.Equal(t, "app", report.Name)
+	assert.Equal(t, int64(3), report.CacheGeneration)
+
+	assert.Equal(t, &authorizationv1.ResourceAttributes{
+		Namespace:   "default",
+		Verb:        "get",
+		Group:       "keda.sh",
+		Resource:    "scaledobjects",
+		Subresource: "debug",
+		Name:        "app",
+	}, c.lastResource)
+}
+
+func TestServeScalersErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		token   string
+		allowed bool
+		status  int
+	}{
+		{name: "missing token", path: "/debug/scalers/default/scaledobject/app", allowed: true, status: http.StatusUnauthorized},
+		{name: "invalid token", path: "/debug/scalers/default/scaledobject/app", token: "invalid", allowed: true, status: http.StatusUnauthorized},
+		{name: "forbidden", path: "/debug/scalers/default/scaledobject/app", token: validToken, allowed: false, status: http.StatusForbidden},
+		{name: "malformed path", path: "/debug/scalers/default/app", token: validToken, allowed: true, status: http.StatusNotFound},
+		{name: "unknown kind", path: "/debug/scalers/default/deployment/app", token: validToken, allowed: true, status: http.StatusNotFound},
+		{name: "object not found", path: "/debug/scalers/default/scaledobjects/other", token: validToken, allowed: true, status: http.StatusNotFound},
+		{name: "handler not registered", path: "/debug/scalers/default/scaledjob/app", token: validToken, allowed: true, status: http.StatusServiceUnavailable},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, _, _ := newTestServer(t, test.allowed)
+			rec := doRequest(server, test.path, test.token)
+			assert.Equal(t, test.status, rec.Code)
+		})
+	}
+}
+
+func TestServeScalersWithoutCache(t *testing.T) {
+	server, _, handler := newTestServer(t, true)
+	handler.EXPECT().DebugScalableObject(gomock.Any(), gomock.Any()).Return(nil, scaling.ErrScalersCacheNotFound)
+
+	rec := doRequest(server, "/debug/scalers/default/scaledobject/app", validToken)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "scalers cache not found")
+}
+
+func TestServeConfig(t *testing.T) {
+	server, c, _ := newTestServer(t, true)
+	rec := doRequest(server, ConfigPath, validToken)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	server.RegisterConfig(func() interface{} {
+		return map[string]string{"path": "/etc/keda/config.yaml"}
+	})
+	rec = doRequest(server, ConfigPath, validToken)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"path": "/etc/keda/config.yaml"}`, rec.Body.String())
+	assert.Nil(t, c.lastResource)
+	assert.Equal(t, &authorizationv1.NonResourceAttributes{Path: "/debug/config", Verb: "get"}, c.lastNonResource)
+
+	assert.Equal(t, http.StatusUnauthorized, doRequest(server, ConfigPath, "").Code)
+	server, _, _ = newTestServer(t, false)
+	server.RegisterConfig(func() interface{} { return nil })
+	assert.Equal(t, http.StatusForbidden, doRequest(server, ConfigPath, validToken).Code)
+}
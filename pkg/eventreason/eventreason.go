@@ -56,6 +56,30 @@ const (
 	// KEDAScaleTargetDeactivationFailed is for event when the deactivation of the scale target for ScaledObject fails
 	KEDAScaleTargetDeactivationFailed = "KEDAScaleTargetDeactivationFailed"
 
+	// KEDAScaleTargetScaled is for event when KEDA scaled the scale target of ScaledObject through its replicasPath
+	KEDAScaleTargetScaled = "KEDAScaleTargetScaled"
+
+	// KEDAScaleTargetScaleFailed is for event when KEDA fails to scale the scale target of ScaledObject through its replicasPath
+	KEDAScaleTargetScaleFailed = "KEDAScaleTargetScaleFailed"
+
+	// KEDAScaleTargetPaused is for event when the scale target of ScaledObject was scaled to the paused replica count
+	KEDAScaleTargetPaused = "KEDAScaleTargetPaused"
+
+	// KEDAScaleTargetFallbackEntered is for event when at least one trigger of ScaledObject started falling back
+	KEDAScaleTargetFallbackEntered = "KEDAScaleTargetFallbackEntered"
+
+	// KEDAScaleTargetFallbackExited is for event when no trigger of ScaledObject is falling back anymore
+	KEDAScaleTargetFallbackExited = "KEDAScaleTargetFallbackExited"
+
+	// KEDATriggerFailing is for event when a trigger of ScaledObject or ScaledJob started failing
+	KEDATriggerFailing = "KEDATriggerFailing"
+
+	// KEDATriggerRecovered is for event when a failing trigger of ScaledObject or ScaledJob recovered
+	KEDATriggerRecovered = "KEDATriggerRecovered"
+
+	// KEDAScalersRefreshed is for event when scalers of ScaledObject or ScaledJob were rebuilt because a referenced Secret, ConfigMap or TriggerAuthentication changed
+	KEDAScalersRefreshed = "KEDAScalersRefreshed"
+
 	// KEDAJobsCreated is for event when jobs for ScaledJob are created
 	KEDAJobsCreated = "KEDAJobsCreated"
 
@@ -70,4 +94,16 @@ const (
 
 	// ClusterTriggerAuthenticationAdded is for event when a ClusterTriggerAuthentication is added
 	ClusterTriggerAuthenticationAdded = "ClusterTriggerAuthenticationAdded"
+
+	// TriggerAuthenticationInUse is for event when the deletion of a TriggerAuthentication waits for the ScaledObjects and ScaledJobs referencing it
+	TriggerAuthenticationInUse = "TriggerAuthenticationInUse"
+
+	// ClusterTriggerAuthenticationInUse is for event when the deletion of a ClusterTriggerAuthentication waits for the ScaledObjects and ScaledJobs referencing it
+	ClusterTriggerAuthenticationInUse = "ClusterTriggerAuthenticationInUse"
+
+	// TriggerAuthenticationResolutionFailed is for event when the TriggerAuthentication or ClusterTriggerAuthentication referenced by a trigger can't be resolved
+	TriggerAuthenticationResolutionFailed = "TriggerAuthenticationResolutionFailed"
+
+	// ClusterCloudEventSinkInvalid is for event when a ClusterCloudEventSink can't be used
+	ClusterCloudEventSinkInvalid = "ClusterCloudEventSinkInvalid"
 )
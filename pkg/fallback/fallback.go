@@ -14,27 +14,30 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package provider
+package fallback
 
 import (
 	"context"
 	"fmt"
 
-	"k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
 
-func isFallbackEnabled(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2beta2.MetricSpec) bool {
+var logger = logf.Log.WithName("fallback")
+
+func isFallbackEnabled(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpec) bool {
 	if scaledObject.Spec.Fallback == nil {
 		return false
 	}
 
-	if metricSpec.External.Target.Type != v2beta2.AverageValueMetricType {
+	if metricSpec.External.Target.Type != v2.AverageValueMetricType {
 		logger.V(0).Info("Fallback can only be enabled for triggers with metric of type AverageValue")
 		return false
 	}
@@ -42,7 +45,9 @@ func isFallbackEnabled(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2bet
 	return true
 }
 
-func (p *KedaProvider) getMetricsWithFallback(ctx context.Context, metrics []external_metrics.ExternalMetricValue, suppressedError error, metricName string, scaledObject *kedav1alpha1.ScaledObject, metricSpec v2beta2.MetricSpec) ([]external_metrics.ExternalMetricValue, error) {
+// GetMetricsWithFallback records the health of the metric in the ScaledObject status and returns the fallback metric
+// instead of the suppressed error once the metric failed more often than the fallback failureThreshold allows
+func GetMetricsWithFallback(ctx context.Context, client runtimeclient.Client, metrics []external_metrics.ExternalMetricValue, suppressedError error, metricName string, scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpec) ([]external_metrics.ExternalMetricValue, error) {
 	status := scaledObject.Status.DeepCopy()
 
 	initHealthStatus(status)
@@ -54,7 +59,7 @@ func (p *KedaProvider) getMetricsWithFallback(ctx context.Context, metrics []ext
 		healthStatus.Status = kedav1alpha1.HealthStatusHappy
 		status.Health[metricName] = *healthStatus
 
-		p.updateStatus(ctx, scaledObject, status, metricSpec)
+		updateStatus(ctx, client, scaledObject, status, metricSpec)
 		return metrics, nil
 	}
 
@@ -62,7 +67,7 @@ func (p *KedaProvider) getMetricsWithFallback(ctx context.Context, metrics []ext
 	*healthStatus.NumberOfFailures++
 	status.Health[metricName] = *healthStatus
 
-	p.updateStatus(ctx, scaledObject, status, metricSpec)
+	updateStatus(ctx, client, scaledObject, status, metricSpec)
 
 	switch {
 	case !isFallbackEnabled(scaledObject, metricSpec):
@@ -77,7 +82,7 @@ func (p *KedaProvider) getMetricsWithFallback(ctx context.Context, metrics []ext
 	}
 }
 
-func fallbackExistsInScaledObject(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2beta2.MetricSpec) bool {
+func fallbackExistsInScaledObject(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpec) bool {
 	if !isFallbackEnabled(scaledObject, metricSpec) || !validateFallback(scaledObject) {
 		return false
 	}
@@ -96,7 +101,7 @@ func validateFallback(scaledObject *kedav1alpha1.ScaledObject) bool {
 		scaledObject.Spec.Fallback.Replicas >= 0
 }
 
-func doFallback(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2beta2.MetricSpec, metricName string, suppressedError error) []external_metrics.ExternalMetricValue {
+func doFallback(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpec, metricName string, suppressedError error) []external_metrics.ExternalMetricValue {
 	replicas := int64(scaledObject.Spec.Fallback.Replicas)
 	normalisationValue, _ := metricSpec.External.Target.AverageValue.AsInt64()
 	metric := external_metrics.ExternalMetricValue{
@@ -110,7 +115,7 @@ func doFallback(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2beta2.Metr
 	return fallbackMetrics
 }
 
-func (p *KedaProvider) updateStatus(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, status *kedav1alpha1.ScaledObjectStatus, metricSpec v2beta2.MetricSpec) {
+func updateStatus(ctx context.Context, client runtimeclient.Client, scaledObject *kedav1alpha1.ScaledObject, status *kedav1alpha1.ScaledObjectStatus, metricSpec v2.MetricSpec) {
 	patch := runtimeclient.MergeFrom(scaledObject.DeepCopy())
 
 	if fallbackExistsInScaledObject(scaledObject, metricSpec) {
@@ -120,7 +125,7 @@ func (p *KedaProvider) updateStatus(ctx context.Context, scaledObject *kedav1alp
 	}
 
 	scaledObject.Status = *status
-	err := p.client.Status().Patch(ctx, scaledObject, patch)
+	err := client.Status().Patch(ctx, scaledObject, patch)
 	if err != nil {
 		logger.Error(err, "Failed to patch ScaledObjects Status")
 	}
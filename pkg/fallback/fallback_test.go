@@ -14,7 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package provider
+package fallback
 
 import (
 	"context"
@@ -27,7 +27,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
-	"k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/metrics/pkg/apis/external_metrics"
@@ -36,7 +36,6 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
 	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
-	"github.com/kedacore/keda/v2/pkg/mock/mock_scaling"
 )
 
 const metricName = "some_metric_name"
@@ -51,22 +50,14 @@ func TestFallback(t *testing.T) {
 
 var _ = Describe("fallback", func() {
 	var (
-		scaleHandler      *mock_scaling.MockScaleHandler
-		client            *mock_client.MockClient
-		providerUnderTest *KedaProvider
-		scaler            *mock_scalers.MockScaler
-		ctrl              *gomock.Controller
+		client *mock_client.MockClient
+		scaler *mock_scalers.MockScaler
+		ctrl   *gomock.Controller
 	)
 
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
-		scaleHandler = mock_scaling.NewMockScaleHandler(ctrl)
 		client = mock_client.NewMockClient(ctrl)
-		providerUnderTest = &KedaProvider{
-			client:           client,
-			scaleHandler:     scaleHandler,
-			watchedNamespace: "",
-		}
 		scaler = mock_scalers.NewMockScaler(ctrl)
 
 		logger = logr.Discard()
@@ -85,7 +76,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		metrics, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		metrics, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
 
 		Expect(err).ToNot(HaveOccurred())
 		value, _ := metrics[0].Value.AsInt64()
@@ -116,7 +107,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		metrics, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		metrics, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
 
 		Expect(err).ToNot(HaveOccurred())
 		value, _ := metrics[0].Value.AsInt64()
@@ -132,7 +123,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		_, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		_, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
 
 		Expect(err).ShouldNot(BeNil())
 		Expect(err.Error()).Should(Equal("Some error"))
@@ -161,7 +152,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		_, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		_, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
 
 		Expect(err).ShouldNot(BeNil())
 		Expect(err.Error()).Should(Equal("Some error"))
@@ -191,7 +182,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		metrics, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		metrics, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
 
 		Expect(err).ToNot(HaveOccurred())
 		value, _ := metrics[0].Value.AsInt64()
@@ -208,10 +199,10 @@ var _ = Describe("fallback", func() {
 		)
 
 		qty := resource.NewQuantity(int64(3), resource.DecimalSI)
-		metricsSpec := v2beta2.MetricSpec{
-			External: &v2beta2.ExternalMetricSource{
-				Target: v2beta2.MetricTarget{
-					Type:  v2beta2.UtilizationMetricType,
+		metricsSpec := v2.MetricSpec{
+			External: &v2.ExternalMetricSource{
+				Target: v2.MetricTarget{
+					Type:  v2.UtilizationMetricType,
 					Value: qty,
 				},
 			},
@@ -247,7 +238,7 @@ var _ = Describe("fallback", func() {
 		client.EXPECT().Status().Return(statusWriter)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		metrics, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		metrics, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
 
 		Expect(err).ToNot(HaveOccurred())
 		value, _ := metrics[0].Value.AsInt64()
@@ -277,7 +268,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		_, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		_, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
 
 		Expect(err).ShouldNot(BeNil())
 		Expect(err.Error()).Should(Equal("Some error"))
@@ -311,7 +302,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		_, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		_, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
 		Expect(err).ToNot(HaveOccurred())
 		condition := so.Status.Conditions.GetFallbackCondition()
 		Expect(condition.IsTrue()).Should(BeTrue())
@@ -345,7 +336,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		_, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		_, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
 		Expect(err).ShouldNot(BeNil())
 		Expect(err.Error()).Should(Equal("Some error"))
 		condition := so.Status.Conditions.GetFallbackCondition()
@@ -436,12 +427,12 @@ func primeGetMetrics(scaler *mock_scalers.MockScaler, value int64) {
 	scaler.EXPECT().GetMetrics(gomock.Any(), gomock.Eq(metricName), gomock.Any()).Return([]external_metrics.ExternalMetricValue{expectedMetric}, nil)
 }
 
-func createMetricSpec(averageValue int) v2beta2.MetricSpec {
+func createMetricSpec(averageValue int) v2.MetricSpec {
 	qty := resource.NewQuantity(int64(averageValue), resource.DecimalSI)
-	return v2beta2.MetricSpec{
-		External: &v2beta2.ExternalMetricSource{
-			Target: v2beta2.MetricTarget{
-				Type:         v2beta2.AverageValueMetricType,
+	return v2.MetricSpec{
+		External: &v2.ExternalMetricSource{
+			Target: v2.MetricTarget{
+				Type:         v2.AverageValueMetricType,
 				AverageValue: qty,
 			},
 		},
@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// circuitBreakerStateValues maps the circuit breaker states to the values of the state gauge
+var circuitBreakerStateValues = map[string]float64{
+	"Closed":   0,
+	"HalfOpen": 1,
+	"Open":     2,
+}
+
+// Circuit breaker metrics are used by both the operator and the metrics adapter,
+// they are registered in the registries of both processes
+var (
+	circuitBreakerState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "keda",
+			Subsystem: "scaler_circuit_breaker",
+			Name:      "state",
+			Help:      "State of the trigger circuit breaker: 0 closed, 1 half-open, 2 open",
+		},
+		[]string{"namespace", "name", "trigger"},
+	)
+	circuitBreakerTransitionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "keda",
+			Subsystem: "scaler_circuit_breaker",
+			Name:      "transitions_total",
+			Help:      "Number of trigger circuit breaker state transitions by target state",
+		},
+		[]string{"namespace", "name", "trigger", "state"},
+	)
+)
+
+func circuitBreakerCollectors() []prometheus.Collector {
+	return []prometheus.Collector{circuitBreakerState, circuitBreakerTransitionsTotal}
+}
+
+// RecordCircuitBreakerState records the transition of a trigger circuit breaker to the passed state
+func RecordCircuitBreakerState(namespace, name, trigger, state string) {
+	circuitBreakerState.With(prometheus.Labels{"namespace": namespace, "name": name, "trigger": trigger}).Set(circuitBreakerStateValues[state])
+	circuitBreakerTransitionsTotal.With(prometheus.Labels{"namespace": namespace, "name": name, "trigger": trigger, "state": state}).Inc()
+}
+
+// DeleteCircuitBreakerState removes the state of a trigger circuit breaker that is not used anymore
+func DeleteCircuitBreakerState(namespace, name, trigger string) {
+	circuitBreakerState.Delete(prometheus.Labels{"namespace": namespace, "name": name, "trigger": trigger})
+}
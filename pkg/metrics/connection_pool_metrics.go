@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Connection pool metrics are used by both the operator and the metrics adapter,
+// they are registered in the registries of both processes
+var (
+	connectionPoolConnections = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "keda",
+			Subsystem: "scaler_connection_pool",
+			Name:      "connections",
+			Help:      "Number of upstream connections held in the shared scaler connection pool",
+		},
+		[]string{"type"},
+	)
+	connectionPoolReferences = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "keda",
+			Subsystem: "scaler_connection_pool",
+			Name:      "references",
+			Help:      "Number of scalers using a connection from the shared scaler connection pool",
+		},
+		[]string{"type"},
+	)
+	connectionPoolAcquiredTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "keda",
+			Subsystem: "scaler_connection_pool",
+			Name:      "acquired_total",
+			Help:      "Number of connections acquired from the shared scaler connection pool",
+		},
+		[]string{"type", "reused"},
+	)
+)
+
+func connectionPoolCollectors() []prometheus.Collector {
+	return []prometheus.Collector{connectionPoolConnections, connectionPoolReferences, connectionPoolAcquiredTotal}
+}
+
+// RecordConnectionPoolSize sets the number of pooled connections of a type and the number of scalers using them
+func RecordConnectionPoolSize(connectionType string, connections, references int) {
+	connectionPoolConnections.With(prometheus.Labels{"type": connectionType}).Set(float64(connections))
+	connectionPoolReferences.With(prometheus.Labels{"type": connectionType}).Set(float64(references))
+}
+
+// RecordConnectionPoolAcquired counts a connection acquired from the pool, reused tells whether it already existed
+func RecordConnectionPoolAcquired(connectionType string, reused bool) {
+	connectionPoolAcquiredTotal.With(prometheus.Labels{"type": connectionType, "reused": strconv.FormatBool(reused)}).Inc()
+}
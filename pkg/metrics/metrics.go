@@ -16,10 +16,29 @@ limitations under the License.
 
 package metrics
 
+import "time"
+
 // Server an HTTP serving instance to track metrics
 type Server interface {
 	NewServer(address string, pattern string)
-	RecordScalerError(namespace string, scaledObject string, scaler string, scalerIndex int, metric string, err error)
-	RecordScalerMetric(namespace string, scaledObject string, scaler string, scalerIndex int, metric string, value int64)
+
+	// metrics recorded by the metrics adapter
+	RecordHPAScalerMetric(namespace string, scaledObject string, scaler string, scalerIndex int, metric string, value int64)
+	RecordHPAScalerError(namespace string, scaledObject string, scaler string, scalerIndex int, metric string, err error)
 	RecordScalerObjectError(namespace string, scaledObject string, err error)
+
+	// metrics recorded by the operator
+	RecordScaleLoopCheck(namespace string, objectType string, name string, duration time.Duration, lag time.Duration)
+	DeleteScaleLoop(namespace string, objectType string, name string)
+	RecordTriggerActive(namespace string, objectType string, name string, trigger string, triggerType string, active bool)
+	DeleteTriggerActive(namespace string, objectType string, name string, trigger string, triggerType string)
+	RecordScalingAction(namespace string, scaledObject string, reason string)
+	RecordScaledJobJobs(namespace string, scaledJob string, running int64, pending int64)
+	RecordScaledJobJobsCreated(namespace string, scaledJob string, count int64)
+	DeleteScaledJobJobs(namespace string, scaledJob string)
+	RecordScalersCacheRebuild(namespace string, objectType string, name string, scope string)
+	RecordResource(namespace string, resourceType string, name string)
+	DeleteResource(namespace string, resourceType string, name string)
 }
+
+var _ Server = PrometheusMetricServer{}
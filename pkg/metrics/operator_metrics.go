@@ -0,0 +1,309 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Operator metrics are registered in the controller-runtime registry,
+// so they are served on the operator's existing metrics endpoint
+var (
+	scaleLoopQueueDepth = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scale_loop",
+			Name:      "queue_depth",
+			Help:      "Number of scale loop checks waiting for a free concurrency slot",
+		},
+	)
+	scaleLoopInFlight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scale_loop",
+			Name:      "in_flight",
+			Help:      "Number of scale loop checks currently being executed",
+		},
+	)
+	scaleLoopWaitSeconds = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scale_loop",
+			Name:      "wait_seconds",
+			Help:      "Time a scale loop check spent waiting for the scheduler",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		},
+	)
+	scaleLoopRateLimitedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scale_loop",
+			Name:      "rate_limited_total",
+			Help:      "Number of scale loop checks delayed by a trigger type rate limit",
+		},
+		[]string{"type"},
+	)
+	scaleLoopDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scale_loop",
+			Name:      "duration_seconds",
+			Help:      "Time a scale loop check took to query the triggers and scale the target",
+			Buckets:   prometheus.ExponentialBuckets(0.005, 2, 14),
+		},
+		[]string{"namespace", "type"},
+	)
+	scaleLoopLagSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scale_loop",
+			Name:      "lag_seconds",
+			Help:      "Delay between the time the last scale loop check was due and the time it started",
+		},
+		[]string{"namespace", "type", "name"},
+	)
+	triggerActive = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "keda_operator",
+			Subsystem: "trigger",
+			Name:      "active",
+			Help:      "Whether the trigger was active in the last scale loop check (1) or not (0)",
+		},
+		[]string{"namespace", "type", "name", "trigger", "triggerType"},
+	)
+	scalingActionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scaled_object",
+			Name:      "scaling_actions_total",
+			Help:      "Number of times the operator scaled the target of a ScaledObject, by reason",
+		},
+		[]string{"namespace", "scaledObject", "reason"},
+	)
+	scaledJobJobs = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scaled_job",
+			Name:      "jobs",
+			Help:      "Number of Jobs of a ScaledJob, by state",
+		},
+		[]string{"namespace", "scaledJob", "state"},
+	)
+	scaledJobJobsCreatedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scaled_job",
+			Name:      "jobs_created_total",
+			Help:      "Number of Jobs created for a ScaledJob",
+		},
+		[]string{"namespace", "scaledJob"},
+	)
+	scalersCacheRebuildsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "keda_operator",
+			Subsystem: "scalers_cache",
+			Name:      "rebuilds_total",
+			Help:      "Number of times the scalers of an object (scope=object) or of a single trigger (scope=trigger) were built again",
+		},
+		[]string{"namespace", "type", "name", "scope"},
+	)
+	cloudEventsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "keda_operator",
+			Subsystem: "cloudevents",
+			Name:      "total",
+			Help:      "Number of CloudEvents handled per ClusterCloudEventSink, by result",
+		},
+		[]string{"sink", "result"},
+	)
+	resources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "keda_operator",
+			Name:      "resources",
+			Help:      "Number of KEDA resources watched by the operator, by namespace and type",
+		},
+		[]string{"namespace", "type"},
+	)
+)
+
+// Values of the reason label of keda_operator_scaled_object_scaling_actions_total
+const (
+	ScalingActionActivated   = "activated"
+	ScalingActionDeactivated = "deactivated"
+	ScalingActionFallback    = "fallback"
+	ScalingActionPaused      = "paused"
+	ScalingActionScaled      = "scaled"
+)
+
+// Values of the state label of keda_operator_scaled_job_jobs
+const (
+	ScaledJobJobsRunning = "running"
+	ScaledJobJobsPending = "pending"
+)
+
+// Values of the scope label of keda_operator_scalers_cache_rebuilds_total
+const (
+	ScalersCacheRebuildObject  = "object"
+	ScalersCacheRebuildTrigger = "trigger"
+)
+
+// Values of the result label of keda_operator_cloudevents_total
+const (
+	CloudEventDelivered = "delivered"
+	CloudEventFailed    = "failed"
+	CloudEventDropped   = "dropped"
+)
+
+// trackedResources remembers the reconciled resources, so repeated reconciles of the same resource are counted once
+var trackedResources = struct {
+	sync.Mutex
+	items map[string]map[string]struct{}
+}{items: map[string]map[string]struct{}{}}
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(scaleLoopQueueDepth)
+	ctrlmetrics.Registry.MustRegister(scaleLoopInFlight)
+	ctrlmetrics.Registry.MustRegister(scaleLoopWaitSeconds)
+	ctrlmetrics.Registry.MustRegister(scaleLoopRateLimitedTotal)
+	ctrlmetrics.Registry.MustRegister(scaleLoopDurationSeconds)
+	ctrlmetrics.Registry.MustRegister(scaleLoopLagSeconds)
+	ctrlmetrics.Registry.MustRegister(triggerActive)
+	ctrlmetrics.Registry.MustRegister(scalingActionsTotal)
+	ctrlmetrics.Registry.MustRegister(scaledJobJobs)
+	ctrlmetrics.Registry.MustRegister(scaledJobJobsCreatedTotal)
+	ctrlmetrics.Registry.MustRegister(scalersCacheRebuildsTotal)
+	ctrlmetrics.Registry.MustRegister(cloudEventsTotal)
+	ctrlmetrics.Registry.MustRegister(resources)
+	ctrlmetrics.Registry.MustRegister(connectionPoolCollectors()...)
+	ctrlmetrics.Registry.MustRegister(circuitBreakerCollectors()...)
+}
+
+// RecordScaleLoopQueueDepth sets the number of scale loop checks waiting for the scheduler
+func RecordScaleLoopQueueDepth(depth int) {
+	scaleLoopQueueDepth.Set(float64(depth))
+}
+
+// RecordScaleLoopInFlight sets the number of scale loop checks being executed
+func RecordScaleLoopInFlight(count int) {
+	scaleLoopInFlight.Set(float64(count))
+}
+
+// RecordScaleLoopWait observes the time a scale loop check waited for the scheduler
+func RecordScaleLoopWait(seconds float64) {
+	scaleLoopWaitSeconds.Observe(seconds)
+}
+
+// RecordScaleLoopRateLimited counts a scale loop check delayed by the rate limit of a trigger type
+func RecordScaleLoopRateLimited(triggerType string) {
+	scaleLoopRateLimitedTotal.With(prometheus.Labels{"type": triggerType}).Inc()
+}
+
+// RecordCloudEvent counts a CloudEvent delivered to, failed for or dropped by a sink
+func RecordCloudEvent(sink string, result string) {
+	cloudEventsTotal.With(prometheus.Labels{"sink": sink, "result": result}).Inc()
+}
+
+// RecordScaleLoopCheck observes the duration of a scale loop check and how late it started
+func (metricsServer PrometheusMetricServer) RecordScaleLoopCheck(namespace string, objectType string, name string, duration time.Duration, lag time.Duration) {
+	scaleLoopDurationSeconds.With(prometheus.Labels{"namespace": namespace, "type": objectType}).Observe(duration.Seconds())
+	scaleLoopLagSeconds.With(prometheus.Labels{"namespace": namespace, "type": objectType, "name": name}).Set(lag.Seconds())
+}
+
+// DeleteScaleLoop drops the per object scale loop metrics once the loop of the object is stopped
+func (metricsServer PrometheusMetricServer) DeleteScaleLoop(namespace string, objectType string, name string) {
+	scaleLoopLagSeconds.Delete(prometheus.Labels{"namespace": namespace, "type": objectType, "name": name})
+}
+
+// RecordTriggerActive sets the activity state of a trigger of a ScaledObject or ScaledJob
+func (metricsServer PrometheusMetricServer) RecordTriggerActive(namespace string, objectType string, name string, trigger string, triggerType string, active bool) {
+	value := 0.0
+	if active {
+		value = 1
+	}
+	triggerActive.With(getTriggerLabels(namespace, objectType, name, trigger, triggerType)).Set(value)
+}
+
+// DeleteTriggerActive drops the activity state of a trigger that is not used anymore
+func (metricsServer PrometheusMetricServer) DeleteTriggerActive(namespace string, objectType string, name string, trigger string, triggerType string) {
+	triggerActive.Delete(getTriggerLabels(namespace, objectType, name, trigger, triggerType))
+}
+
+// RecordScalingAction counts a scaling of the target of a ScaledObject, reason is one of the ScalingAction* values
+func (metricsServer PrometheusMetricServer) RecordScalingAction(namespace string, scaledObject string, reason string) {
+	scalingActionsTotal.With(prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject, "reason": reason}).Inc()
+}
+
+// RecordScaledJobJobs sets the number of running and pending Jobs of a ScaledJob
+func (metricsServer PrometheusMetricServer) RecordScaledJobJobs(namespace string, scaledJob string, running int64, pending int64) {
+	scaledJobJobs.With(prometheus.Labels{"namespace": namespace, "scaledJob": scaledJob, "state": ScaledJobJobsRunning}).Set(float64(running))
+	scaledJobJobs.With(prometheus.Labels{"namespace": namespace, "scaledJob": scaledJob, "state": ScaledJobJobsPending}).Set(float64(pending))
+}
+
+// RecordScaledJobJobsCreated counts the Jobs created for a ScaledJob
+func (metricsServer PrometheusMetricServer) RecordScaledJobJobsCreated(namespace string, scaledJob string, count int64) {
+	scaledJobJobsCreatedTotal.With(prometheus.Labels{"namespace": namespace, "scaledJob": scaledJob}).Add(float64(count))
+}
+
+// DeleteScaledJobJobs drops the Job gauges of a ScaledJob that is not scaled anymore
+func (metricsServer PrometheusMetricServer) DeleteScaledJobJobs(namespace string, scaledJob string) {
+	scaledJobJobs.Delete(prometheus.Labels{"namespace": namespace, "scaledJob": scaledJob, "state": ScaledJobJobsRunning})
+	scaledJobJobs.Delete(prometheus.Labels{"namespace": namespace, "scaledJob": scaledJob, "state": ScaledJobJobsPending})
+}
+
+// RecordScalersCacheRebuild counts a rebuild of the scalers of an object or of a single trigger, scope is one of the ScalersCacheRebuild* values
+func (metricsServer PrometheusMetricServer) RecordScalersCacheRebuild(namespace string, objectType string, name string, scope string) {
+	scalersCacheRebuildsTotal.With(prometheus.Labels{"namespace": namespace, "type": objectType, "name": name, "scope": scope}).Inc()
+}
+
+// RecordResource counts a reconciled resource of the passed type, recording the same resource again is a no-op
+func (metricsServer PrometheusMetricServer) RecordResource(namespace string, resourceType string, name string) {
+	trackedResources.Lock()
+	defer trackedResources.Unlock()
+
+	key := namespace + "/" + name
+	items, ok := trackedResources.items[resourceType]
+	if !ok {
+		items = map[string]struct{}{}
+		trackedResources.items[resourceType] = items
+	}
+	if _, ok := items[key]; ok {
+		return
+	}
+	items[key] = struct{}{}
+	resources.With(prometheus.Labels{"namespace": namespace, "type": resourceType}).Inc()
+}
+
+// DeleteResource stops counting a deleted resource of the passed type
+func (metricsServer PrometheusMetricServer) DeleteResource(namespace string, resourceType string, name string) {
+	trackedResources.Lock()
+	defer trackedResources.Unlock()
+
+	key := namespace + "/" + name
+	if _, ok := trackedResources.items[resourceType][key]; !ok {
+		return
+	}
+	delete(trackedResources.items[resourceType], key)
+	resources.With(prometheus.Labels{"namespace": namespace, "type": resourceType}).Dec()
+}
+
+func getTriggerLabels(namespace string, objectType string, name string, trigger string, triggerType string) prometheus.Labels {
+	return prometheus.Labels{"namespace": namespace, "type": objectType, "name": name, "trigger": trigger, "triggerType": triggerType}
+}
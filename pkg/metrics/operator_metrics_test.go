@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordResourceCountsOnce(t *testing.T) {
+	server := PrometheusMetricServer{}
+	labels := prometheus.Labels{"namespace": "test-resources", "type": "ScaledObject"}
+
+	server.RecordResource("test-resources", "ScaledObject", "first")
+	server.RecordResource("test-resources", "ScaledObject", "first")
+	server.RecordResource("test-resources", "ScaledObject", "second")
+	server.RecordResource("test-resources", "ScaledJob", "first")
+	assert.Equal(t, 2.0, testutil.ToFloat64(resources.With(labels)))
+
+	server.DeleteResource("test-resources", "ScaledObject", "first")
+	server.DeleteResource("test-resources", "ScaledObject", "first")
+	server.DeleteResource("test-resources", "ScaledObject", "unknown")
+	assert.Equal(t, 1.0, testutil.ToFloat64(resources.With(labels)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(resources.With(prometheus.Labels{"namespace": "test-resources", "type": "ScaledJob"})))
+}
+
+func TestRecordScaleLoopCheck(t *testing.T) {
+	server := PrometheusMetricServer{}
+	labels := prometheus.Labels{"namespace": "test-loop", "type": "ScaledObject", "name": "test"}
+
+	server.RecordScaleLoopCheck("test-loop", "ScaledObject", "test", 100*time.Millisecond, 2*time.Second)
+	assert.Equal(t, 2.0, testutil.ToFloat64(scaleLoopLagSeconds.With(labels)))
+
+	server.DeleteScaleLoop("test-loop", "ScaledObject", "test")
+	assert.False(t, scaleLoopLagSeconds.Delete(labels))
+}
+
+func TestRecordTriggerActive(t *testing.T) {
+	server := PrometheusMetricServer{}
+	labels := getTriggerLabels("test-trigger", "ScaledObject", "test", "s0-kafka", "kafka")
+
+	server.RecordTriggerActive("test-trigger", "ScaledObject", "test", "s0-kafka", "kafka", true)
+	assert.Equal(t, 1.0, testutil.ToFloat64(triggerActive.With(labels)))
+	server.RecordTriggerActive("test-trigger", "ScaledObject", "test", "s0-kafka", "kafka", false)
+	assert.Equal(t, 0.0, testutil.ToFloat64(triggerActive.With(labels)))
+
+	server.DeleteTriggerActive("test-trigger", "ScaledObject", "test", "s0-kafka", "kafka")
+	assert.False(t, triggerActive.Delete(labels))
+}
@@ -76,6 +76,8 @@ func init() {
 	registry.MustRegister(scalerMetricsValue)
 	registry.MustRegister(scalerErrors)
 	registry.MustRegister(scaledObjectErrors)
+	registry.MustRegister(connectionPoolCollectors()...)
+	registry.MustRegister(circuitBreakerCollectors()...)
 }
 
 // NewServer creates a new http serving instance of prometheus metrics
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	scaling "github.com/kedacore/keda/v2/pkg/scaling"
 	cache "github.com/kedacore/keda/v2/pkg/scaling/cache"
 )
 
@@ -49,6 +50,21 @@ func (mr *MockScaleHandlerMockRecorder) ClearScalersCache(ctx, scalableObject in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearScalersCache", reflect.TypeOf((*MockScaleHandler)(nil).ClearScalersCache), ctx, scalableObject)
 }
 
+// DebugScalableObject mocks base method.
+func (m *MockScaleHandler) DebugScalableObject(ctx context.Context, scalableObject interface{}) (*scaling.DebugReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DebugScalableObject", ctx, scalableObject)
+	ret0, _ := ret[0].(*scaling.DebugReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DebugScalableObject indicates an expected call of DebugScalableObject.
+func (mr *MockScaleHandlerMockRecorder) DebugScalableObject(ctx, scalableObject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DebugScalableObject", reflect.TypeOf((*MockScaleHandler)(nil).DebugScalableObject), ctx, scalableObject)
+}
+
 // DeleteScalableObject mocks base method.
 func (m *MockScaleHandler) DeleteScalableObject(ctx context.Context, scalableObject interface{}) error {
 	m.ctrl.T.Helper()
@@ -91,3 +107,15 @@ func (mr *MockScaleHandlerMockRecorder) HandleScalableObject(ctx, scalableObject
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleScalableObject", reflect.TypeOf((*MockScaleHandler)(nil).HandleScalableObject), ctx, scalableObject)
 }
+
+// RefreshDependentScalers mocks base method.
+func (m *MockScaleHandler) RefreshDependentScalers(ctx context.Context, dependency cache.Dependency) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RefreshDependentScalers", ctx, dependency)
+}
+
+// RefreshDependentScalers indicates an expected call of RefreshDependentScalers.
+func (mr *MockScaleHandlerMockRecorder) RefreshDependentScalers(ctx, dependency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshDependentScalers", reflect.TypeOf((*MockScaleHandler)(nil).RefreshDependentScalers), ctx, dependency)
+}
@@ -23,17 +23,22 @@ import (
 	"sync"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/metrics/pkg/apis/custom_metrics"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/custom-metrics-apiserver/pkg/provider"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/fallback"
 	prommetrics "github.com/kedacore/keda/v2/pkg/metrics"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 )
 
 // KedaProvider implements External Metrics Provider
@@ -47,7 +52,7 @@ type KedaProvider struct {
 }
 
 var (
-	logger        logr.Logger
+	logger        = logf.Log.WithName("provider")
 	metricsServer prommetrics.PrometheusMetricServer
 )
 
@@ -71,6 +76,16 @@ func NewProvider(ctx context.Context, adapterLogger logr.Logger, scaleHandler sc
 // implementation how to translate metricSelector to a filter for metric values.
 // Namespace can be used by the implementation for metric identification, access control or ignored.
 func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string, metricSelector labels.Selector, info provider.ExternalMetricInfo) (*external_metrics.ExternalMetricValueList, error) {
+	ctx, span := tracing.StartSpan(ctx, "Provider.GetExternalMetric", trace.WithAttributes(
+		attribute.String("keda.metric.namespace", namespace),
+		attribute.String("keda.metric.name", info.Metric),
+	))
+	metrics, err := p.getExternalMetric(ctx, namespace, metricSelector, info)
+	tracing.EndSpan(span, err)
+	return metrics, err
+}
+
+func (p *KedaProvider) getExternalMetric(ctx context.Context, namespace string, metricSelector labels.Selector, info provider.ExternalMetricInfo) (*external_metrics.ExternalMetricValueList, error) {
 	// Note:
 	//		metric name and namespace is used to lookup for the CRD which contains configuration
 	// 		if not found then ignored and label selector is parsed for all the metrics
@@ -95,6 +110,7 @@ func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string,
 	}
 
 	scaledObject := &scaledObjects.Items[0]
+	trace.SpanFromContext(ctx).SetAttributes(tracing.ScalableObjectAttributes("ScaledObject", scaledObject.Namespace, scaledObject.Name)...)
 	var matchingMetrics []external_metrics.ExternalMetricValue
 
 	cache, err := p.scaleHandler.GetScalersCache(ctx, scaledObject)
@@ -117,7 +133,7 @@ func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string,
 			// Filter only the desired metric
 			if strings.EqualFold(metricSpec.External.Metric.Name, info.Metric) {
 				metrics, err := cache.GetMetricsForScaler(ctx, scalerIndex, info.Metric, metricSelector)
-				metrics, err = p.getMetricsWithFallback(ctx, metrics, err, info.Metric, scaledObject, metricSpec)
+				metrics, err = fallback.GetMetricsWithFallback(ctx, p.client, metrics, err, info.Metric, scaledObject, metricSpec)
 
 				if err != nil {
 					scalerError = true
@@ -134,14 +150,10 @@ func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string,
 		}
 	}
 
-	// invalidate the cache for the ScaledObject, if we hit an error in any scaler
-	// in this case we try to build all scalers (and resolve all secrets/creds) again in the next call
+	// failing scalers are rebuilt (and their secrets/creds resolved again) by the scalers cache itself,
+	// guarded by a per trigger circuit breaker, so the healthy scalers of the ScaledObject keep their connections
 	if scalerError {
-		err := p.scaleHandler.ClearScalersCache(ctx, scaledObject)
-		if err != nil {
-			logger.Error(err, "error clearing scalers cache")
-		}
-		logger.V(1).Info("scaler error encountered, clearing scaler cache")
+		logger.V(1).Info("scaler error encountered", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
 	}
 
 	if len(matchingMetrics) == 0 {
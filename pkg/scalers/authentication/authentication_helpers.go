@@ -130,28 +130,23 @@ func CreateHTTPRoundTripper(roundTripperType TransportType, auth *AuthMeta, conf
 			return nil, fmt.Errorf("error creating fast http round tripper: %s", err)
 		}
 
-		if auth != nil {
-			if auth.EnableBasicAuth {
-				rt = pConfig.NewBasicAuthRoundTripper(
-					auth.Username,
-					pConfig.Secret(auth.Password),
-					"", roundTripper,
-				)
-			}
-
-			if auth.EnableBearerAuth {
-				rt = pConfig.NewAuthorizationCredentialsRoundTripper(
-					"Bearer",
-					pConfig.Secret(auth.BearerToken),
-					roundTripper,
-				)
-			}
-		} else {
-			rt = roundTripper
-		}
-
-		return rt, nil
+		return NewAuthRoundTripper(auth, roundTripper), nil
 	}
 
 	return rt, nil
 }
+
+// NewAuthRoundTripper adds the basic or bearer authentication of the auth to the requests of the round tripper,
+// the bearer token is used when both are enabled
+func NewAuthRoundTripper(auth *AuthMeta, rt http.RoundTripper) http.RoundTripper {
+	switch {
+	case auth == nil:
+		return rt
+	case auth.EnableBearerAuth:
+		return pConfig.NewAuthorizationCredentialsRoundTripper("Bearer", pConfig.Secret(auth.BearerToken), rt)
+	case auth.EnableBasicAuth:
+		return pConfig.NewBasicAuthRoundTripper(auth.Username, pConfig.Secret(auth.Password), "", rt)
+	default:
+		return rt
+	}
+}
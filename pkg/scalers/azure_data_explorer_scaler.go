@@ -22,7 +22,7 @@ import (
 	"strconv"
 
 	"github.com/Azure/azure-kusto-go/kusto"
-	"k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -35,7 +35,7 @@ import (
 )
 
 type azureDataExplorerScaler struct {
-	metricType v2beta2.MetricTargetType
+	metricType v2.MetricTargetType
 	metadata   *azure.DataExplorerMetadata
 	client     *kusto.Client
 	name       string
@@ -174,15 +174,15 @@ func (s azureDataExplorerScaler) GetMetrics(ctx context.Context, metricName stri
 	return append([]external_metrics.ExternalMetricValue{}, metric), nil
 }
 
-func (s azureDataExplorerScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
-	externalMetric := &v2beta2.ExternalMetricSource{
-		Metric: v2beta2.MetricIdentifier{
+func (s azureDataExplorerScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
 			Name: s.metadata.MetricName,
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.Threshold),
 	}
-	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: externalMetricType}
-	return []v2beta2.MetricSpec{metricSpec}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
 }
 
 func (s azureDataExplorerScaler) IsActive(ctx context.Context) (bool, error) {
@@ -0,0 +1,179 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"sync"
+
+	"github.com/kedacore/keda/v2/pkg/metrics"
+)
+
+// sharedConnections holds the upstream connections shared by all scalers of this process,
+// so ScaledObjects pointing to the same endpoint with the same credentials reuse one connection
+var sharedConnections = newSharedConnectionPool()
+
+// sharedConnectionPool is a reference counted pool of upstream connections keyed by endpoint and credentials hash
+type sharedConnectionPool struct {
+	lock    sync.Mutex
+	entries map[string]*pooledConnection
+}
+
+type pooledConnection struct {
+	kind  string
+	key   string
+	refs  int
+	ready chan struct{}
+
+	connection interface{}
+	closeFn    func() error
+	err        error
+}
+
+func newSharedConnectionPool() *sharedConnectionPool {
+	return &sharedConnectionPool{
+		entries: make(map[string]*pooledConnection),
+	}
+}
+
+// connectionPoolKey builds the pool key from the endpoint and the credentials used to connect.
+// The values are hashed so credentials are never kept in clear text as map keys.
+func connectionPoolKey(kind string, parts ...string) string {
+	h := sha256.New()
+	h.Write([]byte(kind))
+	for _, part := range parts {
+		h.Write([]byte{0})
+		h.Write([]byte(part))
+	}
+	return kind + "/" + hex.EncodeToString(h.Sum(nil))
+}
+
+// acquire returns the connection stored under key, creating it with create when there is none yet
+// or when healthy reports the existing one as broken. The returned release function must be called
+// once the connection is not used anymore, the connection is closed when the last reference is released.
+func (p *sharedConnectionPool) acquire(kind, key string, healthy func(interface{}) bool, create func() (interface{}, func() error, error)) (interface{}, func() error, error) {
+	for {
+		p.lock.Lock()
+		entry, found := p.entries[key]
+		if !found {
+			entry = &pooledConnection{kind: kind, key: key, refs: 1, ready: make(chan struct{})}
+			p.entries[key] = entry
+			p.updateMetrics(kind)
+			p.lock.Unlock()
+
+			entry.connection, entry.closeFn, entry.err = create()
+			close(entry.ready)
+			if entry.err != nil {
+				p.evict(entry)
+				p.release(entry)
+				return nil, nil, entry.err
+			}
+			metrics.RecordConnectionPoolAcquired(kind, false)
+			return entry.connection, p.releaseFunc(entry), nil
+		}
+		entry.refs++
+		p.updateMetrics(kind)
+		p.lock.Unlock()
+
+		<-entry.ready
+		if entry.err != nil {
+			p.release(entry)
+			return nil, nil, entry.err
+		}
+		if healthy != nil && !healthy(entry.connection) {
+			// drop the broken connection from the pool, scalers still holding it close it on release
+			p.evict(entry)
+			p.release(entry)
+			continue
+		}
+		metrics.RecordConnectionPoolAcquired(kind, true)
+		return entry.connection, p.releaseFunc(entry), nil
+	}
+}
+
+func (p *sharedConnectionPool) releaseFunc(entry *pooledConnection) func() error {
+	var once sync.Once
+	return func() error {
+		var err error
+		once.Do(func() {
+			err = p.release(entry)
+		})
+		return err
+	}
+}
+
+func (p *sharedConnectionPool) release(entry *pooledConnection) error {
+	p.lock.Lock()
+	entry.refs--
+	last := entry.refs == 0
+	if last && p.entries[entry.key] == entry {
+		delete(p.entries, entry.key)
+	}
+	p.updateMetrics(entry.kind)
+	p.lock.Unlock()
+
+	if !last {
+		return nil
+	}
+
+	if entry.err != nil || entry.closeFn == nil {
+		return nil
+	}
+	return entry.closeFn()
+}
+
+func (p *sharedConnectionPool) evict(entry *pooledConnection) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.entries[entry.key] == entry {
+		delete(p.entries, entry.key)
+		p.updateMetrics(entry.kind)
+	}
+}
+
+// updateMetrics must be called with the lock held
+func (p *sharedConnectionPool) updateMetrics(kind string) {
+	connections, references := 0, 0
+	for _, entry := range p.entries {
+		if entry.kind == kind {
+			connections++
+			references += entry.refs
+		}
+	}
+	metrics.RecordConnectionPoolSize(kind, connections, references)
+}
+
+// getSharedSQLConnection returns the sql.DB shared by all scalers using the same driver and connection string,
+// open is only called when there is no healthy connection in the pool yet
+func getSharedSQLConnection(driver, connectionString string, open func() (*sql.DB, error)) (*sql.DB, func() error, error) {
+	healthy := func(connection interface{}) bool {
+		return connection.(*sql.DB).Ping() == nil
+	}
+	connection, release, err := sharedConnections.acquire(driver, connectionPoolKey(driver, connectionString), healthy, func() (interface{}, func() error, error) {
+		db, err := open()
+		if err != nil {
+			return nil, nil, err
+		}
+		return db, db.Close, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return connection.(*sql.DB), release, nil
+}
@@ -0,0 +1,100 @@
+package scalers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPooledConnection struct {
+	id     int
+	closed bool
+}
+
+func testConnectionFactory(created *int) func() (interface{}, func() error, error) {
+	return func() (interface{}, func() error, error) {
+		*created++
+		conn := &testPooledConnection{id: *created}
+		return conn, func() error {
+			conn.closed = true
+			return nil
+		}, nil
+	}
+}
+
+func TestConnectionPoolKey(t *testing.T) {
+	assert.Equal(t, connectionPoolKey("redis", "host:6379", "secret"), connectionPoolKey("redis", "host:6379", "secret"))
+	assert.NotEqual(t, connectionPoolKey("redis", "host:6379", "secret"), connectionPoolKey("redis", "host:6379", "other"))
+	assert.NotEqual(t, connectionPoolKey("redis", "ab", "c"), connectionPoolKey("redis", "a", "bc"))
+	assert.NotContains(t, connectionPoolKey("redis", "host:6379", "secret"), "secret")
+}
+
+func TestConnectionPoolSharesConnections(t *testing.T) {
+	pool := newSharedConnectionPool()
+	created := 0
+
+	first, releaseFirst, err := pool.acquire("test", "key", nil, testConnectionFactory(&created))
+	assert.Nil(t, err)
+	second, releaseSecond, err := pool.acquire("test", "key", nil, testConnectionFactory(&created))
+	assert.Nil(t, err)
+	other, releaseOther, err := pool.acquire("test", "other", nil, testConnectionFactory(&created))
+	assert.Nil(t, err)
+
+	assert.Same(t, first, second)
+	assert.NotSame(t, first, other)
+	assert.Equal(t, 2, created)
+
+	assert.Nil(t, releaseFirst())
+	assert.False(t, first.(*testPooledConnection).closed)
+	// releasing twice must not drop the reference held by the second scaler
+	assert.Nil(t, releaseFirst())
+	assert.False(t, first.(*testPooledConnection).closed)
+
+	assert.Nil(t, releaseSecond())
+	assert.True(t, first.(*testPooledConnection).closed)
+	assert.Nil(t, releaseOther())
+	assert.True(t, other.(*testPooledConnection).closed)
+	assert.Empty(t, pool.entries)
+}
+
+func TestConnectionPoolReplacesUnhealthyConnections(t *testing.T) {
+	pool := newSharedConnectionPool()
+	created := 0
+	healthy := func(connection interface{}) bool {
+		return connection.(*testPooledConnection).id != 1
+	}
+
+	first, releaseFirst, err := pool.acquire("test", "key", healthy, testConnectionFactory(&created))
+	assert.Nil(t, err)
+	second, releaseSecond, err := pool.acquire("test", "key", healthy, testConnectionFactory(&created))
+	assert.Nil(t, err)
+
+	assert.NotSame(t, first, second)
+	assert.Equal(t, 2, created)
+
+	// the broken connection is still owned by the first scaler until it releases it
+	assert.False(t, first.(*testPooledConnection).closed)
+	assert.Nil(t, releaseFirst())
+	assert.True(t, first.(*testPooledConnection).closed)
+
+	assert.Nil(t, releaseSecond())
+	assert.True(t, second.(*testPooledConnection).closed)
+}
+
+func TestConnectionPoolCreateError(t *testing.T) {
+	pool := newSharedConnectionPool()
+	failing := func() (interface{}, func() error, error) {
+		return nil, nil, errors.New("connection refused")
+	}
+
+	_, _, err := pool.acquire("test", "key", nil, failing)
+	assert.NotNil(t, err)
+	assert.Empty(t, pool.entries)
+
+	created := 0
+	_, release, err := pool.acquire("test", "key", nil, testConnectionFactory(&created))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, created)
+	assert.Nil(t, release())
+}
@@ -5,12 +5,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	"k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 )
 
 type parseCPUMemoryMetadataTestData struct {
-	metricType v2beta2.MetricTargetType
+	metricType v2.MetricTargetType
 	metadata   map[string]string
 	isError    bool
 }
@@ -25,11 +25,11 @@ var testCPUMemoryMetadata = []parseCPUMemoryMetadataTestData{
 	{"", map[string]string{}, true},
 	{"", validCPUMemoryMetadata, false},
 	{"", map[string]string{"type": "Utilization", "value": "50"}, false},
-	{v2beta2.UtilizationMetricType, map[string]string{"value": "50"}, false},
+	{v2.UtilizationMetricType, map[string]string{"value": "50"}, false},
 	{"", map[string]string{"type": "AverageValue", "value": "50"}, false},
-	{v2beta2.AverageValueMetricType, map[string]string{"value": "50"}, false},
+	{v2.AverageValueMetricType, map[string]string{"value": "50"}, false},
 	{"", map[string]string{"type": "Value", "value": "50"}, true},
-	{v2beta2.ValueMetricType, map[string]string{"value": "50"}, true},
+	{v2.ValueMetricType, map[string]string{"value": "50"}, true},
 	{"", map[string]string{"type": "AverageValue"}, true},
 	{"", map[string]string{"type": "xxx", "value": "50"}, true},
 }
@@ -58,19 +58,19 @@ func TestGetMetricSpecForScaling(t *testing.T) {
 	scaler, _ := NewCPUMemoryScaler(v1.ResourceCPU, config)
 	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
 
-	assert.Equal(t, metricSpec[0].Type, v2beta2.ResourceMetricSourceType)
+	assert.Equal(t, metricSpec[0].Type, v2.ResourceMetricSourceType)
 	assert.Equal(t, metricSpec[0].Resource.Name, v1.ResourceCPU)
-	assert.Equal(t, metricSpec[0].Resource.Target.Type, v2beta2.UtilizationMetricType)
+	assert.Equal(t, metricSpec[0].Resource.Target.Type, v2.UtilizationMetricType)
 
 	// Using trigger.metricType field for type
 	config = &ScalerConfig{
 		TriggerMetadata: map[string]string{"value": "50"},
-		MetricType:      v2beta2.UtilizationMetricType,
+		MetricType:      v2.UtilizationMetricType,
 	}
 	scaler, _ = NewCPUMemoryScaler(v1.ResourceCPU, config)
 	metricSpec = scaler.GetMetricSpecForScaling(context.Background())
 
-	assert.Equal(t, metricSpec[0].Type, v2beta2.ResourceMetricSourceType)
+	assert.Equal(t, metricSpec[0].Type, v2.ResourceMetricSourceType)
 	assert.Equal(t, metricSpec[0].Resource.Name, v1.ResourceCPU)
-	assert.Equal(t, metricSpec[0].Resource.Target.Type, v2beta2.UtilizationMetricType)
+	assert.Equal(t, metricSpec[0].Resource.Target.Type, v2.UtilizationMetricType)
 }
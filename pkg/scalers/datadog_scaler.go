@@ -9,7 +9,7 @@ import (
 	"time"
 
 	datadog "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
-	"k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -30,7 +30,7 @@ type datadogMetadata struct {
 	datadogSite string
 	query       string
 	queryValue  int64
-	vType       v2beta2.MetricTargetType
+	vType       v2.MetricTargetType
 	metricName  string
 	age         int
 	useFiller   bool
@@ -127,9 +127,9 @@ func parseDatadogMetadata(config *ScalerConfig) (*datadogMetadata, error) {
 		val = strings.ToLower(val)
 		switch val {
 		case "average":
-			meta.vType = v2beta2.AverageValueMetricType
+			meta.vType = v2.AverageValueMetricType
 		case "global":
-			meta.vType = v2beta2.ValueMetricType
+			meta.vType = v2.ValueMetricType
 		default:
 			return nil, fmt.Errorf("type has to be global or average")
 		}
@@ -189,10 +189,14 @@ func newDatadogConnection(ctx context.Context, meta *datadogMetadata, config *Sc
 		})
 
 	configuration := datadog.NewConfiguration()
-	configuration.HTTPClient = kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	httpClient, err := newHTTPClient(config, config.GlobalHTTPTimeout, false)
+	if err != nil {
+		return nil, fmt.Errorf("error creating http client: %s", err)
+	}
+	configuration.HTTPClient = httpClient
 	apiClient := datadog.NewAPIClient(configuration)
 
-	_, _, err := apiClient.AuthenticationApi.Validate(ctx) //nolint:bodyclose
+	_, _, err = apiClient.AuthenticationApi.Validate(ctx) //nolint:bodyclose
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to Datadog API endpoint: %v", err)
 	}
@@ -288,17 +292,17 @@ func (s *datadogScaler) getQueryResult(ctx context.Context) (float64, error) {
 }
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
-func (s *datadogScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
-	externalMetric := &v2beta2.ExternalMetricSource{
-		Metric: v2beta2.MetricIdentifier{
+func (s *datadogScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
 			Name: s.metadata.metricName,
 		},
 		Target: GetMetricTarget(s.metadata.vType, s.metadata.queryValue),
 	}
-	metricSpec := v2beta2.MetricSpec{
+	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
 	}
-	return []v2beta2.MetricSpec{metricSpec}
+	return []v2.MetricSpec{metricSpec}
 }
 
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
@@ -3,17 +3,15 @@ package scalers
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/elastic/go-elasticsearch/v7"
 	"github.com/tidwall/gjson"
-	"k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -24,7 +22,7 @@ import (
 )
 
 type elasticsearchScaler struct {
-	metricType v2beta2.MetricTargetType
+	metricType v2.MetricTargetType
 	metadata   *elasticsearchMetadata
 	esClient   *elasticsearch.Client
 }
@@ -56,7 +54,7 @@ func NewElasticsearchScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing elasticsearch metadata: %s", err)
 	}
 
-	esClient, err := newElasticsearchClient(meta)
+	esClient, err := newElasticsearchClient(meta, config)
 	if err != nil {
 		return nil, fmt.Errorf("error getting elasticsearch client: %s", err)
 	}
@@ -134,7 +132,7 @@ func parseElasticsearchMetadata(config *ScalerConfig) (*elasticsearchMetadata, e
 }
 
 // newElasticsearchClient creates elasticsearch db connection
-func newElasticsearchClient(meta *elasticsearchMetadata) (*elasticsearch.Client, error) {
+func newElasticsearchClient(meta *elasticsearchMetadata, scalerConfig *ScalerConfig) (*elasticsearch.Client, error) {
 	config := elasticsearch.Config{Addresses: meta.addresses}
 	if meta.username != "" {
 		config.Username = meta.username
@@ -143,8 +141,15 @@ func newElasticsearchClient(meta *elasticsearchMetadata) (*elasticsearch.Client,
 		config.Password = meta.password
 	}
 
-	transport := http.DefaultTransport.(*http.Transport)
-	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: meta.unsafeSsl}
+	// the elasticsearch client applies its own timeouts, the transport only carries the proxy and TLS settings
+	options, err := getHTTPClientOptions(scalerConfig, 0, meta.unsafeSsl)
+	if err != nil {
+		return nil, err
+	}
+	transport, err := kedautil.NewHTTPTransport(options)
+	if err != nil {
+		return nil, err
+	}
 	config.Transport = transport
 
 	esClient, err := elasticsearch.NewClient(config)
@@ -240,17 +245,17 @@ func getValueFromSearch(body []byte, valueLocation string) (int64, error) {
 }
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
-func (s *elasticsearchScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
-	externalMetric := &v2beta2.ExternalMetricSource{
-		Metric: v2beta2.MetricIdentifier{
+func (s *elasticsearchScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
 			Name: s.metadata.metricName,
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.targetValue),
 	}
-	metricSpec := v2beta2.MetricSpec{
+	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
 	}
-	return []v2beta2.MetricSpec{metricSpec}
+	return []v2.MetricSpec{metricSpec}
 }
 
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
@@ -2,7 +2,10 @@ package scalers
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
 	"strings"
 	"sync"
 	"time"
@@ -11,18 +14,20 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	pb "github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 type externalScaler struct {
-	metricType      v2beta2.MetricTargetType
+	metricType      v2.MetricTargetType
 	metadata        externalScalerMetadata
 	scaledObjectRef pb.ScaledObjectRef
 }
@@ -34,6 +39,8 @@ type externalPushScaler struct {
 type externalScalerMetadata struct {
 	scalerAddress    string
 	tlsCertFile      string
+	spiffe           bool
+	spiffeServerID   string
 	originalMetadata map[string]string
 	scalerIndex      int
 }
@@ -112,6 +119,10 @@ func parseExternalScalerMetadata(config *ScalerConfig) (externalScalerMetadata,
 	if val, ok := config.TriggerMetadata["tlsCertFile"]; ok && val != "" {
 		meta.tlsCertFile = val
 	}
+	meta.spiffe = config.PodIdentity == kedav1alpha1.PodIdentityProviderSpiffe
+	if meta.spiffe {
+		meta.spiffeServerID = getSpiffeServerID(config)
+	}
 
 	meta.originalMetadata = make(map[string]string)
 
@@ -152,8 +163,8 @@ func (s *externalScaler) Close(context.Context) error {
 }
 
 // GetMetricSpecForScaling returns the metric spec for the HPA
-func (s *externalScaler) GetMetricSpecForScaling(ctx context.Context) []v2beta2.MetricSpec {
-	var result []v2beta2.MetricSpec
+func (s *externalScaler) GetMetricSpecForScaling(ctx context.Context) []v2.MetricSpec {
+	var result []v2.MetricSpec
 
 	grpcClient, done, err := getClientForConnectionPool(s.metadata)
 	if err != nil {
@@ -169,15 +180,15 @@ func (s *externalScaler) GetMetricSpecForScaling(ctx context.Context) []v2beta2.
 	}
 
 	for _, spec := range response.MetricSpecs {
-		externalMetric := &v2beta2.ExternalMetricSource{
-			Metric: v2beta2.MetricIdentifier{
+		externalMetric := &v2.ExternalMetricSource{
+			Metric: v2.MetricIdentifier{
 				Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, spec.MetricName),
 			},
 			Target: GetMetricTarget(s.metricType, spec.TargetSize),
 		}
 
 		// Create the metric spec for the HPA
-		metricSpec := v2beta2.MetricSpec{
+		metricSpec := v2.MetricSpec{
 			External: externalMetric,
 			Type:     externalMetricType,
 		}
@@ -299,6 +310,23 @@ func getClientForConnectionPool(metadata externalScalerMetadata) (pb.ExternalSca
 	defer connectionPoolMutex.Unlock()
 
 	buildGRPCConnection := func(metadata externalScalerMetadata) (*grpc.ClientConn, error) {
+		if metadata.spiffe {
+			tlsConfig := &tls.Config{MinVersion: kedautil.GetMinTLSVersion()}
+			if metadata.tlsCertFile != "" {
+				ca, err := ioutil.ReadFile(metadata.tlsCertFile)
+				if err != nil {
+					return nil, err
+				}
+				tlsConfig.RootCAs = x509.NewCertPool()
+				if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+					return nil, fmt.Errorf("%s contains no PEM certificates", metadata.tlsCertFile)
+				}
+			}
+			if err := useSpiffeTLS(tlsConfig, metadata.spiffeServerID); err != nil {
+				return nil, err
+			}
+			return grpc.Dial(metadata.scalerAddress, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+		}
 		if metadata.tlsCertFile != "" {
 			creds, err := credentials.NewClientTLSFromFile(metadata.tlsCertFile, "")
 			if err != nil {
@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// Credentials is how KEDA authenticates with GCP, with a service account JSON key or key file,
+// or with its default credentials, like its GCP workload identity
+type Credentials struct {
+	// ServiceAccountKey is a service account JSON key
+	ServiceAccountKey string
+	// ServiceAccountKeyFile is the path of a service account JSON key file
+	ServiceAccountKeyFile string
+	// PodIdentity authenticates with the default credentials of KEDA
+	PodIdentity bool
+}
+
+// GetCredentials returns the default credentials of KEDA with the gcp pod identity, otherwise the service account key
+// returned by serviceAccountKey, which is only called without the pod identity
+func GetCredentials(podIdentity kedav1alpha1.PodIdentityProvider, serviceAccountKey func() (Credentials, error)) (Credentials, error) {
+	if podIdentity == kedav1alpha1.PodIdentityProviderGCP {
+		return Credentials{PodIdentity: true}, nil
+	}
+	return serviceAccountKey()
+}
+
+// ClientOptions returns the options of the Google API clients authenticating with the credentials
+func (c Credentials) ClientOptions() []option.ClientOption {
+	switch {
+	case c.PodIdentity:
+		return nil
+	case c.ServiceAccountKeyFile != "":
+		return []option.ClientOption{option.WithCredentialsFile(c.ServiceAccountKeyFile)}
+	default:
+		return []option.ClientOption{option.WithCredentialsJSON([]byte(c.ServiceAccountKey))}
+	}
+}
+
+// TokenSource returns a token source for the credentials, the tokens are requested with the HTTP client
+// of the oauth2.HTTPClient value of the context
+func (c Credentials) TokenSource(ctx context.Context) (oauth2.TokenSource, error) {
+	if c.PodIdentity {
+		return google.DefaultTokenSource(ctx, cloudPlatformScope)
+	}
+	key := []byte(c.ServiceAccountKey)
+	if c.ServiceAccountKeyFile != "" {
+		var err error
+		if key, err = ioutil.ReadFile(c.ServiceAccountKeyFile); err != nil {
+			return nil, fmt.Errorf("error reading the service account key file: %s", err)
+		}
+	}
+	credentials, err := google.CredentialsFromJSON(ctx, key, cloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.TokenSource, nil
+}
@@ -3,14 +3,12 @@ package scalers
 import (
 	"fmt"
 
-	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/gcp"
 )
 
 type gcpAuthorizationMetadata struct {
-	GoogleApplicationCredentials     string
-	GoogleApplicationCredentialsFile string
-	podIdentityOwner                 bool
-	podIdentityProviderEnabled       bool
+	credentials      gcp.Credentials
+	podIdentityOwner bool
 }
 
 func getGcpAuthorization(config *ScalerConfig, resolvedEnv map[string]string) (*gcpAuthorizationMetadata, error) {
@@ -21,21 +19,21 @@ func getGcpAuthorization(config *ScalerConfig, resolvedEnv map[string]string) (*
 		meta.podIdentityOwner = false
 	} else if metadata["identityOwner"] == "" || metadata["identityOwner"] == "pod" {
 		meta.podIdentityOwner = true
-		switch {
-		case config.PodIdentity == kedav1alpha1.PodIdentityProviderGCP:
-			// do nothing, rely on underneath metadata google
-			meta.podIdentityProviderEnabled = true
-		case authParams["GoogleApplicationCredentials"] != "":
-			meta.GoogleApplicationCredentials = authParams["GoogleApplicationCredentials"]
-		default:
+		var err error
+		meta.credentials, err = gcp.GetCredentials(config.PodIdentity, func() (gcp.Credentials, error) {
 			switch {
+			case authParams["GoogleApplicationCredentials"] != "":
+				return gcp.Credentials{ServiceAccountKey: authParams["GoogleApplicationCredentials"]}, nil
 			case metadata["credentialsFromEnv"] != "":
-				meta.GoogleApplicationCredentials = resolvedEnv[metadata["credentialsFromEnv"]]
+				return gcp.Credentials{ServiceAccountKey: resolvedEnv[metadata["credentialsFromEnv"]]}, nil
 			case metadata["credentialsFromEnvFile"] != "":
-				meta.GoogleApplicationCredentialsFile = resolvedEnv[metadata["credentialsFromEnvFile"]]
+				return gcp.Credentials{ServiceAccountKeyFile: resolvedEnv[metadata["credentialsFromEnvFile"]]}, nil
 			default:
-				return nil, fmt.Errorf("GoogleApplicationCredentials not found")
+				return gcp.Credentials{}, fmt.Errorf("GoogleApplicationCredentials not found")
 			}
+		})
+		if err != nil {
+			return nil, err
 		}
 	}
 	return &meta, nil
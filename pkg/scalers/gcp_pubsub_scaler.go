@@ -8,7 +8,7 @@ import (
 	"strconv"
 	"strings"
 
-	"k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -33,7 +33,7 @@ var regexpCompositeSubscriptionIDPrefix = regexp.MustCompile(compositeSubscripti
 
 type pubsubScaler struct {
 	client     *StackDriverClient
-	metricType v2beta2.MetricTargetType
+	metricType v2.MetricTargetType
 	metadata   *pubsubMetadata
 }
 
@@ -161,21 +161,21 @@ func (s *pubsubScaler) Close(context.Context) error {
 }
 
 // GetMetricSpecForScaling returns the metric spec for the HPA
-func (s *pubsubScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
-	externalMetric := &v2beta2.ExternalMetricSource{
-		Metric: v2beta2.MetricIdentifier{
+func (s *pubsubScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("gcp-ps-%s", s.metadata.subscriptionName))),
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.value),
 	}
 
 	// Create the metric spec for the HPA
-	metricSpec := v2beta2.MetricSpec{
+	metricSpec := v2.MetricSpec{
 		External: externalMetric,
 		Type:     externalMetricType,
 	}
 
-	return []v2beta2.MetricSpec{metricSpec}
+	return []v2.MetricSpec{metricSpec}
 }
 
 // GetMetrics connects to Stack Driver and finds the size of the pub sub subscription
@@ -210,10 +210,10 @@ func (s *pubsubScaler) GetMetrics(ctx context.Context, metricName string, metric
 func (s *pubsubScaler) setStackdriverClient(ctx context.Context) error {
 	var client *StackDriverClient
 	var err error
-	if s.metadata.gcpAuthorization.podIdentityProviderEnabled {
+	if s.metadata.gcpAuthorization.credentials.PodIdentity {
 		client, err = NewStackDriverClientPodIdentity(ctx)
 	} else {
-		client, err = NewStackDriverClient(ctx, s.metadata.gcpAuthorization.GoogleApplicationCredentials)
+		client, err = NewStackDriverClient(ctx, s.metadata.gcpAuthorization.credentials.ServiceAccountKey)
 	}
 
 	if err != nil {
@@ -8,8 +8,7 @@ import (
 
 	"cloud.google.com/go/storage"
 	"google.golang.org/api/iterator"
-	option "google.golang.org/api/option"
-	"k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -29,7 +28,7 @@ const (
 type gcsScaler struct {
 	client     *storage.Client
 	bucket     *storage.BucketHandle
-	metricType v2beta2.MetricTargetType
+	metricType v2.MetricTargetType
 	metadata   *gcsMetadata
 }
 
@@ -57,19 +56,7 @@ func NewGcsScaler(config *ScalerConfig) (Scaler, error) {
 
 	ctx := context.Background()
 
-	var client *storage.Client
-
-	switch {
-	case meta.gcpAuthorization.podIdentityProviderEnabled:
-		client, err = storage.NewClient(ctx)
-	case meta.gcpAuthorization.GoogleApplicationCredentialsFile != "":
-		client, err = storage.NewClient(
-			ctx, option.WithCredentialsFile(meta.gcpAuthorization.GoogleApplicationCredentialsFile))
-	default:
-		client, err = storage.NewClient(
-			ctx, option.WithCredentialsJSON([]byte(meta.gcpAuthorization.GoogleApplicationCredentials)))
-	}
-
+	client, err := storage.NewClient(ctx, meta.gcpAuthorization.credentials.ClientOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("storage.NewClient: %v", err)
 	}
@@ -156,15 +143,15 @@ func (s *gcsScaler) Close(context.Context) error {
 }
 
 // GetMetricSpecForScaling returns the metric spec for the HPA
-func (s *gcsScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
-	externalMetric := &v2beta2.ExternalMetricSource{
-		Metric: v2beta2.MetricIdentifier{
+func (s *gcsScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
 			Name: s.metadata.metricName,
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.targetObjectCount),
 	}
-	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: externalMetricType}
-	return []v2beta2.MetricSpec{metricSpec}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
 }
 
 // GetMetrics returns the number of items in the bucket (up to s.metadata.maxBucketItemsToScan)
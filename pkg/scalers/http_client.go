@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"time"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/spiffe"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	httpProxyMetadata     = "httpProxy"
+	httpNoProxyMetadata   = "httpNoProxy"
+	tlsMinVersionMetadata = "tlsMinVersion"
+)
+
+// getHTTPClientOptions reads the overrides of the cluster-wide HTTP client settings of a trigger:
+// the httpProxy, httpNoProxy and tlsMinVersion metadata and the ca, cert and key authentication parameters
+func getHTTPClientOptions(config *ScalerConfig, timeout time.Duration, unsafeSsl bool) (kedautil.HTTPClientOptions, error) {
+	options := kedautil.HTTPClientOptions{
+		Timeout:   timeout,
+		UnsafeSsl: unsafeSsl,
+		Proxy:     config.TriggerMetadata[httpProxyMetadata],
+		NoProxy:   config.TriggerMetadata[httpNoProxyMetadata],
+		CA:        config.AuthParams["ca"],
+	}
+	if version, ok := config.TriggerMetadata[tlsMinVersionMetadata]; ok && version != "" {
+		minVersion, err := kedautil.ParseTLSVersion(version)
+		if err != nil {
+			return options, fmt.Errorf("error parsing %s: %s", tlsMinVersionMetadata, err)
+		}
+		options.MinTLSVersion = minVersion
+	}
+	// a client certificate is only presented when both parts are set, some scalers use them for other connections
+	if config.AuthParams["cert"] != "" && config.AuthParams["key"] != "" {
+		options.Cert = config.AuthParams["cert"]
+		options.Key = config.AuthParams["key"]
+	}
+	return options, nil
+}
+
+// newHTTPClient creates the HTTP client of a scaler from the cluster-wide settings and the overrides of its trigger
+func newHTTPClient(config *ScalerConfig, timeout time.Duration, unsafeSsl bool) (*http.Client, error) {
+	options, err := getHTTPClientOptions(config, timeout, unsafeSsl)
+	if err != nil {
+		return nil, err
+	}
+	client, err := kedautil.NewHTTPClient(options)
+	if err != nil {
+		return nil, err
+	}
+	if err := usePodIdentityTLS(config, client.Transport.(*http.Transport)); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// newHTTPTransport creates the HTTP transport of a scaler whose client is created by a library, like newHTTPClient
+func newHTTPTransport(config *ScalerConfig, unsafeSsl bool) (*http.Transport, error) {
+	options, err := getHTTPClientOptions(config, 0, unsafeSsl)
+	if err != nil {
+		return nil, err
+	}
+	transport, err := kedautil.NewHTTPTransport(options)
+	if err != nil {
+		return nil, err
+	}
+	if err := usePodIdentityTLS(config, transport); err != nil {
+		return nil, err
+	}
+	return transport, nil
+}
+
+// usePodIdentityTLS presents the X.509 SVID of KEDA with the spiffe pod identity
+func usePodIdentityTLS(config *ScalerConfig, transport *http.Transport) error {
+	if config.PodIdentity != kedav1alpha1.PodIdentityProviderSpiffe {
+		return nil
+	}
+	return useSpiffeTLS(transport.TLSClientConfig, getSpiffeServerID(config))
+}
+
+// useSpiffeTLS makes the TLS config present the X.509 SVID of KEDA instead of the cert and key parameters, following its rotations.
+// With a serverID the server must present an X.509 SVID with that SPIFFE ID, otherwise its host name is verified.
+func useSpiffeTLS(tlsConfig *tls.Config, serverID string) error {
+	source, err := spiffe.DefaultX509Source()
+	if err != nil {
+		return fmt.Errorf("error fetching the X.509 SVID of the %s pod identity: %s", kedav1alpha1.PodIdentityProviderSpiffe, err)
+	}
+	return source.ConfigureTLS(tlsConfig, serverID)
+}
+
+// getSpiffeServerID returns the spiffeServerID auth or trigger parameter, the SPIFFE ID the server must present
+func getSpiffeServerID(config *ScalerConfig) string {
+	if serverID := config.AuthParams["spiffeServerID"]; serverID != "" {
+		return serverID
+	}
+	return config.TriggerMetadata["spiffeServerID"]
+}
@@ -0,0 +1,57 @@
+package scalers
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetHTTPClientOptions(t *testing.T) {
+	config := &ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"httpProxy":     "http://proxy:3128",
+			"httpNoProxy":   ".svc",
+			"tlsMinVersion": "1.3",
+		},
+		AuthParams: map[string]string{"ca": "ca", "cert": "cert"},
+	}
+	options, err := getHTTPClientOptions(config, time.Second, true)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second, options.Timeout)
+	assert.True(t, options.UnsafeSsl)
+	assert.Equal(t, "http://proxy:3128", options.Proxy)
+	assert.Equal(t, ".svc", options.NoProxy)
+	assert.Equal(t, uint16(tls.VersionTLS13), options.MinTLSVersion)
+	assert.Equal(t, "ca", options.CA)
+	// the client certificate requires both the cert and the key
+	assert.Empty(t, options.Cert)
+
+	config.AuthParams["key"] = "key"
+	options, err = getHTTPClientOptions(config, time.Second, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "cert", options.Cert)
+	assert.Equal(t, "key", options.Key)
+
+	config.TriggerMetadata["tlsMinVersion"] = "1.4"
+	_, err = getHTTPClientOptions(config, time.Second, false)
+	assert.Error(t, err)
+}
+
+func TestNewHTTPTransport(t *testing.T) {
+	config := &ScalerConfig{
+		TriggerMetadata: map[string]string{"httpProxy": "http://proxy:3128", "tlsMinVersion": "1.3"},
+		AuthParams:      map[string]string{},
+	}
+	transport, err := newHTTPTransport(config, false)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), transport.TLSClientConfig.MinVersion)
+
+	request, err := http.NewRequest(http.MethodGet, "https://keystone.example.com/v3/auth/tokens", nil)
+	assert.NoError(t, err)
+	proxy, err := transport.Proxy(request)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://proxy:3128", proxy.String())
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"testing"
-	"time"
 )
 
 // Test host URLs for validation
@@ -107,14 +106,11 @@ func TestParseDefaultQueueDepth(t *testing.T) {
 func TestIBMMQGetMetricSpecForScaling(t *testing.T) {
 	for _, testData := range IBMMQMetricIdentifiers {
 		metadata, err := parseIBMMQMetadata(&ScalerConfig{ResolvedEnv: sampleIBMMQResolvedEnv, TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, ScalerIndex: testData.scalerIndex})
-		httpTimeout := 100 * time.Millisecond
-
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
 		mockIBMMQScaler := IBMMQScaler{
-			metadata:           metadata,
-			defaultHTTPTimeout: httpTimeout,
+			metadata: metadata,
 		}
 		metricSpec := mockIBMMQScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
@@ -2,6 +2,7 @@ package scalers
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"strconv"
@@ -9,21 +10,28 @@ import (
 	"sync"
 
 	"github.com/Shopify/sarama"
-	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 type kafkaScaler struct {
-	metricType v2beta2.MetricTargetType
+	metricType v2.MetricTargetType
 	metadata   kafkaMetadata
 	client     sarama.Client
 	admin      sarama.ClusterAdmin
+	release    func() error
+}
+
+type kafkaClients struct {
+	client sarama.Client
+	admin  sarama.ClusterAdmin
 }
 
 type kafkaMetadata struct {
@@ -49,6 +57,10 @@ type kafkaMetadata struct {
 	cert      string
 	key       string
 	ca        string
+	// spiffe presents the X.509 SVID of KEDA as client certificate
+	spiffe bool
+	// spiffeServerID is the SPIFFE ID the brokers must present, their host names are verified without it
+	spiffeServerID string
 
 	scalerIndex int
 }
@@ -92,7 +104,7 @@ func NewKafkaScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing kafka metadata: %s", err)
 	}
 
-	client, admin, err := getKafkaClients(kafkaMetadata)
+	client, admin, release, err := getKafkaClients(kafkaMetadata)
 	if err != nil {
 		return nil, err
 	}
@@ -100,6 +112,7 @@ func NewKafkaScaler(config *ScalerConfig) (Scaler, error) {
 	return &kafkaScaler{
 		client:     client,
 		admin:      admin,
+		release:    release,
 		metricType: metricType,
 		metadata:   kafkaMetadata,
 	}, nil
@@ -149,6 +162,14 @@ func parseKafkaAuthParams(config *ScalerConfig, meta *kafkaMetadata) error {
 		}
 	}
 
+	if config.PodIdentity == kedav1alpha1.PodIdentityProviderSpiffe {
+		if !meta.enableTLS {
+			return fmt.Errorf("tls must be enabled to use the %s pod identity", kedav1alpha1.PodIdentityProviderSpiffe)
+		}
+		meta.spiffe = true
+		meta.spiffeServerID = getSpiffeServerID(config)
+	}
+
 	return nil
 }
 
@@ -267,7 +288,34 @@ func (s *kafkaScaler) IsActive(ctx context.Context) (bool, error) {
 	return false, nil
 }
 
-func getKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin, error) {
+// getKafkaClients returns the kafka client and admin shared by all scalers connecting
+// to the same brokers with the same credentials
+func getKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin, func() error, error) {
+	key := connectionPoolKey("kafka", strings.Join(metadata.bootstrapServers, ","), metadata.version.String(),
+		string(metadata.saslType), metadata.username, metadata.password,
+		strconv.FormatBool(metadata.enableTLS), metadata.cert, metadata.key, metadata.ca, strconv.FormatBool(metadata.spiffe), metadata.spiffeServerID)
+
+	healthy := func(connection interface{}) bool {
+		return !connection.(*kafkaClients).client.Closed()
+	}
+	create := func() (interface{}, func() error, error) {
+		client, admin, err := newKafkaClients(metadata)
+		if err != nil {
+			return nil, nil, err
+		}
+		// underlying client will also be closed on admin's Close() call
+		return &kafkaClients{client: client, admin: admin}, admin.Close, nil
+	}
+
+	connection, release, err := sharedConnections.acquire("kafka", key, healthy, create)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	clients := connection.(*kafkaClients)
+	return clients.client, clients.admin, release, nil
+}
+
+func newKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin, error) {
 	config := sarama.NewConfig()
 	config.Version = metadata.version
 
@@ -283,6 +331,14 @@ func getKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin
 		if err != nil {
 			return nil, nil, err
 		}
+		if metadata.spiffe {
+			if tlsConfig == nil {
+				tlsConfig = &tls.Config{MinVersion: kedautil.GetMinTLSVersion()}
+			}
+			if err := useSpiffeTLS(tlsConfig, metadata.spiffeServerID); err != nil {
+				return nil, nil, err
+			}
+		}
 		config.Net.TLS.Config = tlsConfig
 	}
 
@@ -391,18 +447,15 @@ func (s *kafkaScaler) getLagForPartition(topic string, partitionID int32, offset
 	return latestOffset - consumerOffset, nil
 }
 
-// Close closes the kafka admin and client
+// Close releases the shared kafka admin and client, they are closed once no other scaler uses them
 func (s *kafkaScaler) Close(context.Context) error {
-	// underlying client will also be closed on admin's Close() call
-	err := s.admin.Close()
-	if err != nil {
-		return err
+	if s.release == nil {
+		return nil
 	}
-
-	return nil
+	return s.release()
 }
 
-func (s *kafkaScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+func (s *kafkaScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	var metricName string
 	if s.metadata.topic != "" {
 		metricName = fmt.Sprintf("kafka-%s", s.metadata.topic)
@@ -410,14 +463,14 @@ func (s *kafkaScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricS
 		metricName = fmt.Sprintf("kafka-%s-topics", s.metadata.group)
 	}
 
-	externalMetric := &v2beta2.ExternalMetricSource{
-		Metric: v2beta2.MetricIdentifier{
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(metricName)),
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.lagThreshold),
 	}
-	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: kafkaMetricType}
-	return []v2beta2.MetricSpec{metricSpec}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: kafkaMetricType}
+	return []v2.MetricSpec{metricSpec}
 }
 
 type consumerOffsetResult struct {
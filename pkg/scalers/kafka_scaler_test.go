@@ -4,6 +4,8 @@ import (
 	"context"
 	"reflect"
 	"testing"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
 
 type parseKafkaMetadataTestData struct {
@@ -205,13 +207,32 @@ func TestKafkaAuthParams(t *testing.T) {
 	}
 }
 
+func TestKafkaSpiffePodIdentity(t *testing.T) {
+	authParams := map[string]string{"tls": "enable", "spiffeServerID": "spiffe://example.org/kafka"}
+	meta, err := parseKafkaMetadata(&ScalerConfig{TriggerMetadata: validKafkaMetadata, AuthParams: authParams, PodIdentity: kedav1alpha1.PodIdentityProviderSpiffe})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if !meta.spiffe {
+		t.Error("Expected the X.509 SVID to be presented")
+	}
+	if meta.spiffeServerID != "spiffe://example.org/kafka" {
+		t.Errorf("Expected the server SPIFFE ID spiffe://example.org/kafka, got %q", meta.spiffeServerID)
+	}
+
+	_, err = parseKafkaMetadata(&ScalerConfig{TriggerMetadata: validKafkaMetadata, AuthParams: map[string]string{}, PodIdentity: kedav1alpha1.PodIdentityProviderSpiffe})
+	if err == nil {
+		t.Error("Expected an error without tls")
+	}
+}
+
 func TestKafkaGetMetricSpecForScaling(t *testing.T) {
 	for _, testData := range kafkaMetricIdentifiers {
 		meta, err := parseKafkaMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: validWithAuthParams, ScalerIndex: testData.scalerIndex})
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockKafkaScaler := kafkaScaler{"", meta, nil, nil}
+		mockKafkaScaler := kafkaScaler{"", meta, nil, nil, nil}
 
 		metricSpec := mockKafkaScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
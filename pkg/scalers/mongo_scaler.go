@@ -12,7 +12,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/x/bsonx"
-	"k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -24,9 +24,10 @@ import (
 
 // mongoDBScaler is support for mongoDB in keda.
 type mongoDBScaler struct {
-	metricType v2beta2.MetricTargetType
+	metricType v2.MetricTargetType
 	metadata   *mongoDBMetadata
 	client     *mongo.Client
+	release    func() error
 }
 
 // mongoDBMetadata specify mongoDB scaler params.
@@ -90,23 +91,49 @@ func NewMongoDBScaler(ctx context.Context, config *ScalerConfig) (Scaler, error)
 		return nil, fmt.Errorf("failed to parsing mongoDB metadata, because of %v", err)
 	}
 
-	opt := options.Client().ApplyURI(connStr)
-	client, err := mongo.Connect(ctx, opt)
+	client, release, err := getMongoDBClient(ctx, connStr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to establish connection with mongoDB, because of %v", err)
-	}
-
-	if err = client.Ping(ctx, readpref.Primary()); err != nil {
-		return nil, fmt.Errorf("failed to ping mongoDB, because of %v", err)
+		return nil, err
 	}
 
 	return &mongoDBScaler{
 		metricType: metricType,
 		metadata:   meta,
 		client:     client,
+		release:    release,
 	}, nil
 }
 
+// getMongoDBClient returns the mongoDB client shared by all scalers using the same connection string
+func getMongoDBClient(ctx context.Context, connStr string) (*mongo.Client, func() error, error) {
+	healthy := func(connection interface{}) bool {
+		return connection.(*mongo.Client).Ping(ctx, readpref.Primary()) == nil
+	}
+	connection, release, err := sharedConnections.acquire("mongodb", connectionPoolKey("mongodb", connStr), healthy, func() (interface{}, func() error, error) {
+		opt := options.Client().ApplyURI(connStr)
+		client, err := mongo.Connect(ctx, opt)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to establish connection with mongoDB, because of %v", err)
+		}
+
+		if err = client.Ping(ctx, readpref.Primary()); err != nil {
+			_ = client.Disconnect(ctx)
+			return nil, nil, fmt.Errorf("failed to ping mongoDB, because of %v", err)
+		}
+
+		closeFn := func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), mongoDBDefaultTimeOut)
+			defer cancel()
+			return client.Disconnect(ctx)
+		}
+		return client, closeFn, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return connection.(*mongo.Client), release, nil
+}
+
 func parseMongoDBMetadata(config *ScalerConfig) (*mongoDBMetadata, string, error) {
 	var connStr string
 	var err error
@@ -202,9 +229,9 @@ func (s *mongoDBScaler) IsActive(ctx context.Context) (bool, error) {
 }
 
 // Close disposes of mongoDB connections
-func (s *mongoDBScaler) Close(ctx context.Context) error {
-	if s.client != nil {
-		err := s.client.Disconnect(ctx)
+func (s *mongoDBScaler) Close(context.Context) error {
+	if s.release != nil {
+		err := s.release()
 		if err != nil {
 			mongoDBLog.Error(err, fmt.Sprintf("failed to close mongoDB connection, because of %v", err))
 			return err
@@ -251,17 +278,17 @@ func (s *mongoDBScaler) GetMetrics(ctx context.Context, metricName string, metri
 }
 
 // GetMetricSpecForScaling get the query value for scaling
-func (s *mongoDBScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
-	externalMetric := &v2beta2.ExternalMetricSource{
-		Metric: v2beta2.MetricIdentifier{
+func (s *mongoDBScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, s.metadata.metricName),
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.queryValue),
 	}
-	metricSpec := v2beta2.MetricSpec{
+	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
 	}
-	return []v2beta2.MetricSpec{metricSpec}
+	return []v2.MetricSpec{metricSpec}
 }
 
 // json2BsonDoc convert Json to Bson.Doc
@@ -9,7 +9,7 @@ import (
 
 	// mssql driver required for this scaler
 	_ "github.com/denisenkom/go-mssqldb"
-	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -21,9 +21,10 @@ import (
 
 // mssqlScaler exposes a data pointer to mssqlMetadata and sql.DB connection
 type mssqlScaler struct {
-	metricType v2beta2.MetricTargetType
+	metricType v2.MetricTargetType
 	metadata   *mssqlMetadata
 	connection *sql.DB
+	release    func() error
 }
 
 // mssqlMetadata defines metadata used by KEDA to query a Microsoft SQL database
@@ -75,7 +76,9 @@ func NewMSSQLScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing mssql metadata: %s", err)
 	}
 
-	conn, err := newMSSQLConnection(meta)
+	conn, release, err := getSharedSQLConnection("sqlserver", getMSSQLConnectionString(meta), func() (*sql.DB, error) {
+		return newMSSQLConnection(meta)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error establishing mssql connection: %s", err)
 	}
@@ -84,6 +87,7 @@ func NewMSSQLScaler(config *ScalerConfig) (Scaler, error) {
 		metricType: metricType,
 		metadata:   meta,
 		connection: conn,
+		release:    release,
 	}, nil
 }
 
@@ -216,19 +220,19 @@ func getMSSQLConnectionString(meta *mssqlMetadata) string {
 }
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
-func (s *mssqlScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
-	externalMetric := &v2beta2.ExternalMetricSource{
-		Metric: v2beta2.MetricIdentifier{
+func (s *mssqlScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, s.metadata.metricName),
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.targetValue),
 	}
 
-	metricSpec := v2beta2.MetricSpec{
+	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
 	}
 
-	return []v2beta2.MetricSpec{metricSpec}
+	return []v2.MetricSpec{metricSpec}
 }
 
 // GetMetrics returns a value for a supported metric or an error if there is a problem getting the metric
@@ -274,7 +278,10 @@ func (s *mssqlScaler) IsActive(ctx context.Context) (bool, error) {
 
 // Close closes the mssql database connections
 func (s *mssqlScaler) Close(context.Context) error {
-	err := s.connection.Close()
+	if s.release == nil {
+		return nil
+	}
+	err := s.release()
 	if err != nil {
 		mssqlLog.Error(err, "Error closing mssql connection")
 		return err
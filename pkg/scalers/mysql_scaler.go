@@ -8,7 +8,7 @@ import (
 	"strings"
 
 	"github.com/go-sql-driver/mysql"
-	"k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -19,9 +19,10 @@ import (
 )
 
 type mySQLScaler struct {
-	metricType v2beta2.MetricTargetType
+	metricType v2.MetricTargetType
 	metadata   *mySQLMetadata
 	connection *sql.DB
+	release    func() error
 }
 
 type mySQLMetadata struct {
@@ -50,7 +51,9 @@ func NewMySQLScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing MySQL metadata: %s", err)
 	}
 
-	conn, err := newMySQLConnection(meta)
+	conn, release, err := getSharedSQLConnection("mysql", metadataToConnectionStr(meta), func() (*sql.DB, error) {
+		return newMySQLConnection(meta)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error establishing MySQL connection: %s", err)
 	}
@@ -58,6 +61,7 @@ func NewMySQLScaler(config *ScalerConfig) (Scaler, error) {
 		metricType: metricType,
 		metadata:   meta,
 		connection: conn,
+		release:    release,
 	}, nil
 }
 
@@ -175,7 +179,10 @@ func parseMySQLDbNameFromConnectionStr(connectionString string) string {
 
 // Close disposes of MySQL connections
 func (s *mySQLScaler) Close(context.Context) error {
-	err := s.connection.Close()
+	if s.release == nil {
+		return nil
+	}
+	err := s.release()
 	if err != nil {
 		mySQLLog.Error(err, "Error closing MySQL connection")
 		return err
@@ -205,17 +212,17 @@ func (s *mySQLScaler) getQueryResult(ctx context.Context) (int64, error) {
 }
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
-func (s *mySQLScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
-	externalMetric := &v2beta2.ExternalMetricSource{
-		Metric: v2beta2.MetricIdentifier{
+func (s *mySQLScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
 			Name: s.metadata.metricName,
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.queryValue),
 	}
-	metricSpec := v2beta2.MetricSpec{
+	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
 	}
-	return []v2beta2.MetricSpec{metricSpec}
+	return []v2.MetricSpec{metricSpec}
 }
 
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
@@ -9,10 +9,8 @@ import (
 	"net/http"
 	"net/url"
 	"path"
-	"time"
 
 	openstackutil "github.com/kedacore/keda/v2/pkg/scalers/openstack/utils"
-	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 const tokensEndpoint = "/v3/auth/tokens"
@@ -40,8 +38,8 @@ type KeystoneAuthRequest struct {
 	// AuthURL is the Keystone URL.
 	AuthURL string `json:"-"`
 
-	// HTTPClientTimeout is the HTTP client for querying the OpenStack service API.
-	HTTPClientTimeout time.Duration `json:"-"`
+	// HTTPClient is the HTTP client for querying Keystone and the OpenStack service API.
+	HTTPClient *http.Client `json:"-"`
 
 	// Properties contains the authentication metadata to build the body of a token request.
 	Properties *authProps `json:"auth"`
@@ -152,7 +150,7 @@ func (client *Client) RenewToken(ctx context.Context) error {
 }
 
 // NewPasswordAuth creates a struct containing metadata for authentication using the password method
-func NewPasswordAuth(authURL string, userID string, userPassword string, projectID string, httpTimeout int) (*KeystoneAuthRequest, error) {
+func NewPasswordAuth(authURL string, userID string, userPassword string, projectID string, httpClient *http.Client) (*KeystoneAuthRequest, error) {
 	passAuth := new(KeystoneAuthRequest)
 
 	passAuth.Properties = new(authProps)
@@ -174,7 +172,7 @@ func NewPasswordAuth(authURL string, userID string, userPassword string, project
 
 	passAuth.AuthURL = url.String()
 
-	passAuth.HTTPClientTimeout = time.Duration(httpTimeout) * time.Second
+	passAuth.HTTPClient = httpClient
 
 	passAuth.Properties.Identity.Methods = []string{"password"}
 
@@ -187,7 +185,7 @@ func NewPasswordAuth(authURL string, userID string, userPassword string, project
 }
 
 // NewAppCredentialsAuth creates a struct containing metadata for authentication using the application credentials method
-func NewAppCredentialsAuth(authURL string, id string, secret string, httpTimeout int) (*KeystoneAuthRequest, error) {
+func NewAppCredentialsAuth(authURL string, id string, secret string, httpClient *http.Client) (*KeystoneAuthRequest, error) {
 	appAuth := new(KeystoneAuthRequest)
 
 	appAuth.Properties = new(authProps)
@@ -209,7 +207,7 @@ func NewAppCredentialsAuth(authURL string, id string, secret string, httpTimeout
 	appAuth.Properties.Identity.AppCredential.ID = id
 	appAuth.Properties.Identity.AppCredential.Secret = secret
 
-	appAuth.HTTPClientTimeout = time.Duration(httpTimeout) * time.Second
+	appAuth.HTTPClient = httpClient
 
 	return appAuth, nil
 }
@@ -220,7 +218,7 @@ func NewAppCredentialsAuth(authURL string, id string, secret string, httpTimeout
 // Otherwise, if the service API URL was found, it retrieves the first public URL for that service.
 func (keystone *KeystoneAuthRequest) RequestClient(ctx context.Context, projectProps ...string) (Client, error) {
 	var client = Client{
-		HTTPClient:   kedautil.CreateHTTPClient(keystone.HTTPClientTimeout, false),
+		HTTPClient:   keystone.HTTPClient,
 		authMetadata: keystone,
 	}
 
@@ -253,8 +251,6 @@ func (keystone *KeystoneAuthRequest) RequestClient(ctx context.Context, projectP
 }
 
 func (keystone *KeystoneAuthRequest) getToken(ctx context.Context) (string, error) {
-	var httpClient = kedautil.CreateHTTPClient(keystone.HTTPClientTimeout, false)
-
 	jsonBody, err := json.Marshal(keystone)
 
 	if err != nil {
@@ -277,7 +273,7 @@ func (keystone *KeystoneAuthRequest) getToken(ctx context.Context) (string, erro
 		return "", err
 	}
 
-	resp, err := httpClient.Do(tokenRequest)
+	resp, err := keystone.HTTPClient.Do(tokenRequest)
 
 	if err != nil {
 		return "", err
@@ -300,8 +296,6 @@ func (keystone *KeystoneAuthRequest) getToken(ctx context.Context) (string, erro
 
 // getCatalog retrives the OpenStack catalog according to the current authorization
 func (keystone *KeystoneAuthRequest) getCatalog(ctx context.Context, token string) ([]service, error) {
-	var httpClient = kedautil.CreateHTTPClient(keystone.HTTPClientTimeout, false)
-
 	catalogURL, err := url.Parse(keystone.AuthURL)
 
 	if err != nil {
@@ -318,7 +312,7 @@ func (keystone *KeystoneAuthRequest) getCatalog(ctx context.Context, token strin
 
 	getCatalog.Header.Set("X-Auth-Token", token)
 
-	resp, err := httpClient.Do(getCatalog)
+	resp, err := keystone.HTTPClient.Do(getCatalog)
 
 	if err != nil {
 		return nil, err
@@ -349,7 +343,7 @@ func (keystone *KeystoneAuthRequest) getCatalog(ctx context.Context, token strin
 
 // getServiceURL retrieves a public URL for an OpenStack project from the OpenStack catalog
 func (keystone *KeystoneAuthRequest) getServiceURL(ctx context.Context, token string, projectName string, region string) (string, error) {
-	serviceTypes, err := openstackutil.GetServiceTypes(ctx, projectName)
+	serviceTypes, err := openstackutil.GetServiceTypes(ctx, keystone.HTTPClient, projectName)
 
 	if err != nil {
 		return "", err
@@ -5,14 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"time"
-
-	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 const (
 	serviceTypesAuthorityEndpoint = "https://service-types.openstack.org/service-types.json"
-	defaultHTTPClientTimeout      = 30
 )
 
 type serviceTypesRequest struct {
@@ -34,11 +30,9 @@ type serviceMapping struct {
 }
 
 // GetServiceTypes retrieves all historical OpenStack Service Types for a given OpenStack project
-func GetServiceTypes(ctx context.Context, projectName string) ([]string, error) {
+func GetServiceTypes(ctx context.Context, httpClient *http.Client, projectName string) ([]string, error) {
 	var serviceTypesRequest serviceTypesRequest
 
-	var httpClient = kedautil.CreateHTTPClient(defaultHTTPClientTimeout*time.Second, false)
-
 	var url = serviceTypesAuthorityEndpoint
 
 	getServiceTypes, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -9,8 +9,9 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"time"
 
-	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -53,7 +54,7 @@ type openstackSwiftAuthenticationMetadata struct {
 }
 
 type openstackSwiftScaler struct {
-	metricType  v2beta2.MetricTargetType
+	metricType  v2.MetricTargetType
 	metadata    *openstackSwiftMetadata
 	swiftClient openstack.Client
 }
@@ -200,16 +201,21 @@ func NewOpenstackSwiftScaler(ctx context.Context, config *ScalerConfig) (Scaler,
 		return nil, fmt.Errorf("error parsing swift authentication metadata: %s", err)
 	}
 
+	httpClient, err := newHTTPClient(config, time.Duration(openstackSwiftMetadata.httpClientTimeout)*time.Second, false)
+	if err != nil {
+		return nil, fmt.Errorf("error creating swift HTTP client: %s", err)
+	}
+
 	// User chose the "application_credentials" authentication method
 	if authMetadata.appCredentialID != "" {
-		authRequest, err = openstack.NewAppCredentialsAuth(authMetadata.authURL, authMetadata.appCredentialID, authMetadata.appCredentialSecret, openstackSwiftMetadata.httpClientTimeout)
+		authRequest, err = openstack.NewAppCredentialsAuth(authMetadata.authURL, authMetadata.appCredentialID, authMetadata.appCredentialSecret, httpClient)
 		if err != nil {
 			return nil, fmt.Errorf("error getting openstack credentials for application credentials method: %s", err)
 		}
 	} else {
 		// User chose the "password" authentication method
 		if authMetadata.userID != "" {
-			authRequest, err = openstack.NewPasswordAuth(authMetadata.authURL, authMetadata.userID, authMetadata.password, authMetadata.projectID, openstackSwiftMetadata.httpClientTimeout)
+			authRequest, err = openstack.NewPasswordAuth(authMetadata.authURL, authMetadata.userID, authMetadata.password, authMetadata.projectID, httpClient)
 			if err != nil {
 				return nil, fmt.Errorf("error getting openstack credentials for password method: %s", err)
 			}
@@ -388,7 +394,7 @@ func (s *openstackSwiftScaler) GetMetrics(ctx context.Context, metricName string
 	return append([]external_metrics.ExternalMetricValue{}, metric), nil
 }
 
-func (s *openstackSwiftScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+func (s *openstackSwiftScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	var metricName string
 
 	if s.metadata.objectPrefix != "" {
@@ -399,16 +405,16 @@ func (s *openstackSwiftScaler) GetMetricSpecForScaling(context.Context) []v2beta
 
 	metricName = kedautil.NormalizeString(fmt.Sprintf("openstack-swift-%s", metricName))
 
-	externalMetric := &v2beta2.ExternalMetricSource{
-		Metric: v2beta2.MetricIdentifier{
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, metricName),
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.objectCount),
 	}
 
-	metricSpec := v2beta2.MetricSpec{
+	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
 	}
 
-	return []v2beta2.MetricSpec{metricSpec}
+	return []v2.MetricSpec{metricSpec}
 }
@@ -3,25 +3,30 @@ package scalers
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"strconv"
+	"strings"
 
 	// PostreSQL drive required for this scaler
-	_ "github.com/lib/pq"
-	"k8s.io/api/autoscaling/v2beta2"
+	"github.com/lib/pq"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/spiffe"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 type postgreSQLScaler struct {
-	metricType v2beta2.MetricTargetType
+	metricType v2.MetricTargetType
 	metadata   *postgreSQLMetadata
 	connection *sql.DB
+	release    func() error
 }
 
 type postgreSQLMetadata struct {
@@ -30,6 +35,8 @@ type postgreSQLMetadata struct {
 	query            string
 	metricName       string
 	scalerIndex      int
+	// useSpiffe connects with the X.509 SVID of KEDA as client certificate
+	useSpiffe bool
 }
 
 var postgreSQLLog = logf.Log.WithName("postgreSQL_scaler")
@@ -46,7 +53,13 @@ func NewPostgreSQLScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing postgreSQL metadata: %s", err)
 	}
 
-	conn, err := getConnection(meta)
+	connectionKey := meta.connection
+	if meta.useSpiffe {
+		connectionKey += " spiffe"
+	}
+	conn, release, err := getSharedSQLConnection("postgres", connectionKey, func() (*sql.DB, error) {
+		return getConnection(meta)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error establishing postgreSQL connection: %s", err)
 	}
@@ -54,6 +67,7 @@ func NewPostgreSQLScaler(config *ScalerConfig) (Scaler, error) {
 		metricType: metricType,
 		metadata:   meta,
 		connection: conn,
+		release:    release,
 	}, nil
 }
 
@@ -131,11 +145,18 @@ func parsePostgreSQLMetadata(config *ScalerConfig) (*postgreSQLMetadata, error)
 		meta.metricName = kedautil.NormalizeString("postgresql")
 	}
 	meta.scalerIndex = config.ScalerIndex
+	meta.useSpiffe = config.PodIdentity == kedav1alpha1.PodIdentityProviderSpiffe
 	return &meta, nil
 }
 
 func getConnection(meta *postgreSQLMetadata) (*sql.DB, error) {
-	db, err := sql.Open("postgres", meta.connection)
+	var db *sql.DB
+	var err error
+	if meta.useSpiffe {
+		db, err = openSpiffePostgreSQL(meta.connection)
+	} else {
+		db, err = sql.Open("postgres", meta.connection)
+	}
 	if err != nil {
 		postgreSQLLog.Error(err, fmt.Sprintf("Found error opening postgreSQL: %s", err))
 		return nil, err
@@ -148,9 +169,48 @@ func getConnection(meta *postgreSQLMetadata) (*sql.DB, error) {
 	return db, nil
 }
 
+// openSpiffePostgreSQL opens a database presenting the X.509 SVID of KEDA as client certificate and verifying the server
+// against the SPIFFE trust bundle, each new connection uses the current SVID
+func openSpiffePostgreSQL(connection string) (*sql.DB, error) {
+	source, err := spiffe.DefaultX509Source()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching the X.509 SVID of the %s pod identity: %s", kedav1alpha1.PodIdentityProviderSpiffe, err)
+	}
+	if strings.HasPrefix(connection, "postgres://") || strings.HasPrefix(connection, "postgresql://") {
+		if connection, err = pq.ParseURL(connection); err != nil {
+			return nil, err
+		}
+	}
+	return sql.OpenDB(&spiffePostgreSQLConnector{connection: connection, source: source}), nil
+}
+
+type spiffePostgreSQLConnector struct {
+	connection string
+	source     *spiffe.X509Source
+}
+
+func (c *spiffePostgreSQLConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	cert, key, bundle, err := c.source.PEM()
+	if err != nil {
+		return nil, err
+	}
+	connector, err := pq.NewConnector(fmt.Sprintf("%s sslinline=true sslcert='%s' sslkey='%s' sslrootcert='%s'", c.connection, cert, key, bundle))
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(ctx)
+}
+
+func (c *spiffePostgreSQLConnector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
+
 // Close disposes of postgres connections
 func (s *postgreSQLScaler) Close(context.Context) error {
-	err := s.connection.Close()
+	if s.release == nil {
+		return nil
+	}
+	err := s.release()
 	if err != nil {
 		postgreSQLLog.Error(err, "Error closing postgreSQL connection")
 		return err
@@ -179,17 +239,17 @@ func (s *postgreSQLScaler) getActiveNumber(ctx context.Context) (int64, error) {
 }
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
-func (s *postgreSQLScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
-	externalMetric := &v2beta2.ExternalMetricSource{
-		Metric: v2beta2.MetricIdentifier{
+func (s *postgreSQLScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, s.metadata.metricName),
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.targetQueryValue),
 	}
-	metricSpec := v2beta2.MetricSpec{
+	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
 	}
-	return []v2beta2.MetricSpec{metricSpec}
+	return []v2.MetricSpec{metricSpec}
 }
 
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"net/http"
 	"strconv"
 	"time"
 
@@ -25,7 +24,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	health "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
-	"k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -76,7 +75,7 @@ var (
 )
 
 type PredictKubeScaler struct {
-	metricType       v2beta2.MetricTargetType
+	metricType       v2.MetricTargetType
 	metadata         *predictKubeMetadata
 	prometheusClient api.Client
 	grpcConn         *grpc.ClientConn
@@ -158,7 +157,7 @@ func NewPredictKubeScaler(ctx context.Context, config *ScalerConfig) (*PredictKu
 
 	s.metadata = meta
 
-	err = s.initPredictKubePrometheusConn(ctx)
+	err = s.initPredictKubePrometheusConn(ctx, config)
 	if err != nil {
 		predictKubeLog.Error(err, "error create Prometheus client and API objects")
 		return nil, fmt.Errorf("error create Prometheus client and API objects: %3s", err)
@@ -202,19 +201,19 @@ func (s *PredictKubeScaler) Close(_ context.Context) error {
 	return s.grpcConn.Close()
 }
 
-func (s *PredictKubeScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+func (s *PredictKubeScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	metricName := kedautil.NormalizeString(fmt.Sprintf("predictkube-%s", predictKubeMetricPrefix))
-	externalMetric := &v2beta2.ExternalMetricSource{
-		Metric: v2beta2.MetricIdentifier{
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, metricName),
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.threshold),
 	}
 
-	metricSpec := v2beta2.MetricSpec{
+	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: predictKubeMetricType,
 	}
-	return []v2beta2.MetricSpec{metricSpec}
+	return []v2.MetricSpec{metricSpec}
 }
 
 func (s *PredictKubeScaler) GetMetrics(ctx context.Context, metricName string, _ labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
@@ -462,16 +461,14 @@ func (s *PredictKubeScaler) ping(ctx context.Context) (err error) {
 }
 
 // initPredictKubePrometheusConn init prometheus client and setup connection to API
-func (s *PredictKubeScaler) initPredictKubePrometheusConn(ctx context.Context) (err error) {
-	var roundTripper http.RoundTripper
-	// create http.RoundTripper with auth settings from ScalerConfig
-	if roundTripper, err = authentication.CreateHTTPRoundTripper(
-		authentication.FastHTTP,
-		s.metadata.prometheusAuth,
-	); err != nil {
+func (s *PredictKubeScaler) initPredictKubePrometheusConn(ctx context.Context, config *ScalerConfig) (err error) {
+	// create http.RoundTripper with the HTTP client settings and auth of the trigger
+	transport, err := newHTTPTransport(config, false)
+	if err != nil {
 		predictKubeLog.V(1).Error(err, "init Prometheus client http transport")
 		return err
 	}
+	roundTripper := authentication.NewAuthRoundTripper(s.metadata.prometheusAuth, transport)
 
 	if s.prometheusClient, err = api.NewClient(api.Config{
 		Address:      s.metadata.prometheusAddress,
@@ -2,6 +2,7 @@ package scalers
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -9,16 +10,18 @@ import (
 	"net/url"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/streadway/amqp"
-	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -54,10 +57,11 @@ const (
 )
 
 type rabbitMQScaler struct {
-	metricType v2beta2.MetricTargetType
+	metricType v2.MetricTargetType
 	metadata   *rabbitMQMetadata
 	connection *amqp.Connection
 	channel    *amqp.Channel
+	release    func() error
 	httpClient *http.Client
 }
 
@@ -113,7 +117,10 @@ func NewRabbitMQScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing rabbitmq metadata: %s", err)
 	}
 	s.metadata = meta
-	s.httpClient = kedautil.CreateHTTPClient(meta.timeout, false)
+	s.httpClient, err = newHTTPClient(config, meta.timeout, false)
+	if err != nil {
+		return nil, fmt.Errorf("error creating http client: %s", err)
+	}
 
 	if meta.protocol == amqpProtocol {
 		// Override vhost if requested.
@@ -127,12 +134,13 @@ func NewRabbitMQScaler(config *ScalerConfig) (Scaler, error) {
 			host = hostURI.String()
 		}
 
-		conn, ch, err := getConnectionAndChannel(host)
+		conn, ch, release, err := getConnectionAndChannel(host, config.PodIdentity == kedav1alpha1.PodIdentityProviderSpiffe, getSpiffeServerID(config))
 		if err != nil {
 			return nil, fmt.Errorf("error establishing rabbitmq connection: %s", err)
 		}
 		s.connection = conn
 		s.channel = ch
+		s.release = release
 	}
 
 	return s, nil
@@ -320,24 +328,57 @@ func parseTrigger(meta *rabbitMQMetadata, config *ScalerConfig) (*rabbitMQMetada
 	return meta, nil
 }
 
-func getConnectionAndChannel(host string) (*amqp.Connection, *amqp.Channel, error) {
-	conn, err := amqp.Dial(host)
+// getConnectionAndChannel opens a channel on the AMQP connection shared by all scalers using the same host,
+// the returned function releases the shared connection. With useSpiffe the X.509 SVID of KEDA is presented to amqps hosts,
+// which must present an X.509 SVID with the spiffeServerID when it is set.
+func getConnectionAndChannel(host string, useSpiffe bool, spiffeServerID string) (*amqp.Connection, *amqp.Channel, func() error, error) {
+	healthy := func(connection interface{}) bool {
+		return !connection.(*amqp.Connection).IsClosed()
+	}
+	key := connectionPoolKey("rabbitmq", host, strconv.FormatBool(useSpiffe), spiffeServerID)
+	connection, release, err := sharedConnections.acquire("rabbitmq", key, healthy, func() (interface{}, func() error, error) {
+		dial := amqp.Dial
+		if useSpiffe {
+			if !strings.HasPrefix(host, "amqps://") {
+				return nil, nil, fmt.Errorf("the %s pod identity requires an amqps host", kedav1alpha1.PodIdentityProviderSpiffe)
+			}
+			tlsConfig := &tls.Config{MinVersion: kedautil.GetMinTLSVersion()}
+			if err := useSpiffeTLS(tlsConfig, spiffeServerID); err != nil {
+				return nil, nil, err
+			}
+			dial = func(url string) (*amqp.Connection, error) {
+				return amqp.DialTLS(url, tlsConfig)
+			}
+		}
+		conn, err := dial(host)
+		if err != nil {
+			return nil, nil, err
+		}
+		return conn, conn.Close, nil
+	})
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
+	conn := connection.(*amqp.Connection)
 
 	channel, err := conn.Channel()
 	if err != nil {
-		return nil, nil, err
+		release()
+		return nil, nil, nil, err
 	}
 
-	return conn, channel, nil
+	return conn, channel, release, nil
 }
 
 // Close disposes of RabbitMQ connections
 func (s *rabbitMQScaler) Close(context.Context) error {
-	if s.connection != nil {
-		err := s.connection.Close()
+	if s.channel != nil {
+		if err := s.channel.Close(); err != nil && err != amqp.ErrClosed {
+			rabbitmqLog.Error(err, "Error closing rabbitmq channel")
+		}
+	}
+	if s.release != nil {
+		err := s.release()
 		if err != nil {
 			rabbitmqLog.Error(err, "Error closing rabbitmq connection")
 			return err
@@ -460,18 +501,18 @@ func (s *rabbitMQScaler) getQueueInfoViaHTTP() (*queueInfo, error) {
 }
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
-func (s *rabbitMQScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
-	externalMetric := &v2beta2.ExternalMetricSource{
-		Metric: v2beta2.MetricIdentifier{
+func (s *rabbitMQScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, s.metadata.metricName),
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.value),
 	}
-	metricSpec := v2beta2.MetricSpec{
+	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: rabbitMetricType,
 	}
 
-	return []v2beta2.MetricSpec{metricSpec}
+	return []v2.MetricSpec{metricSpec}
 }
 
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
@@ -8,7 +8,7 @@ import (
 	"strings"
 
 	"github.com/go-redis/redis/v8"
-	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -27,7 +27,7 @@ const (
 type redisAddressParser func(metadata, resolvedEnv, authParams map[string]string) (redisConnectionInfo, error)
 
 type redisScaler struct {
-	metricType      v2beta2.MetricTargetType
+	metricType      v2.MetricTargetType
 	metadata        *redisMetadata
 	closeFn         func() error
 	getListLengthFn func(context.Context) (int64, error)
@@ -97,14 +97,14 @@ func NewRedisScaler(ctx context.Context, isClustered, isSentinel bool, config *S
 	return createRedisScaler(ctx, meta, luaScript, metricType)
 }
 
-func createClusteredRedisScaler(ctx context.Context, meta *redisMetadata, script string, metricType v2beta2.MetricTargetType) (Scaler, error) {
-	client, err := getRedisClusterClient(ctx, meta.connectionInfo)
+func createClusteredRedisScaler(ctx context.Context, meta *redisMetadata, script string, metricType v2.MetricTargetType) (Scaler, error) {
+	client, release, err := getRedisClusterClient(ctx, meta.connectionInfo)
 	if err != nil {
 		return nil, fmt.Errorf("connection to redis cluster failed: %s", err)
 	}
 
 	closeFn := func() error {
-		if err := client.Close(); err != nil {
+		if err := release(); err != nil {
 			redisLog.Error(err, "error closing redis client")
 			return err
 		}
@@ -128,27 +128,27 @@ func createClusteredRedisScaler(ctx context.Context, meta *redisMetadata, script
 	}, nil
 }
 
-func createSentinelRedisScaler(ctx context.Context, meta *redisMetadata, script string, metricType v2beta2.MetricTargetType) (Scaler, error) {
-	client, err := getRedisSentinelClient(ctx, meta.connectionInfo, meta.databaseIndex)
+func createSentinelRedisScaler(ctx context.Context, meta *redisMetadata, script string, metricType v2.MetricTargetType) (Scaler, error) {
+	client, release, err := getRedisSentinelClient(ctx, meta.connectionInfo, meta.databaseIndex)
 	if err != nil {
 		return nil, fmt.Errorf("connection to redis sentinel failed: %s", err)
 	}
 
-	return createRedisScalerWithClient(client, meta, script, metricType), nil
+	return createRedisScalerWithClient(client, release, meta, script, metricType), nil
 }
 
-func createRedisScaler(ctx context.Context, meta *redisMetadata, script string, metricType v2beta2.MetricTargetType) (Scaler, error) {
-	client, err := getRedisClient(ctx, meta.connectionInfo, meta.databaseIndex)
+func createRedisScaler(ctx context.Context, meta *redisMetadata, script string, metricType v2.MetricTargetType) (Scaler, error) {
+	client, release, err := getRedisClient(ctx, meta.connectionInfo, meta.databaseIndex)
 	if err != nil {
 		return nil, fmt.Errorf("connection to redis failed: %s", err)
 	}
 
-	return createRedisScalerWithClient(client, meta, script, metricType), nil
+	return createRedisScalerWithClient(client, release, meta, script, metricType), nil
 }
 
-func createRedisScalerWithClient(client *redis.Client, meta *redisMetadata, script string, metricType v2beta2.MetricTargetType) Scaler {
+func createRedisScalerWithClient(client *redis.Client, release func() error, meta *redisMetadata, script string, metricType v2.MetricTargetType) Scaler {
 	closeFn := func() error {
-		if err := client.Close(); err != nil {
+		if err := release(); err != nil {
 			redisLog.Error(err, "error closing redis client")
 			return err
 		}
@@ -225,18 +225,18 @@ func (s *redisScaler) Close(context.Context) error {
 }
 
 // GetMetricSpecForScaling returns the metric spec for the HPA
-func (s *redisScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+func (s *redisScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	metricName := kedautil.NormalizeString(fmt.Sprintf("redis-%s", s.metadata.listName))
-	externalMetric := &v2beta2.ExternalMetricSource{
-		Metric: v2beta2.MetricIdentifier{
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, metricName),
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.targetListLength),
 	}
-	metricSpec := v2beta2.MetricSpec{
+	metricSpec := v2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
 	}
-	return []v2beta2.MetricSpec{metricSpec}
+	return []v2.MetricSpec{metricSpec}
 }
 
 // GetMetrics connects to Redis and finds the length of the list
@@ -456,7 +456,62 @@ func parseRedisSentinelAddress(metadata, resolvedEnv, authParams map[string]stri
 	return info, nil
 }
 
-func getRedisClusterClient(ctx context.Context, info redisConnectionInfo) (*redis.ClusterClient, error) {
+// getRedisClusterClient returns the redis cluster client shared by all scalers using the same connection info
+func getRedisClusterClient(ctx context.Context, info redisConnectionInfo) (*redis.ClusterClient, func() error, error) {
+	client, release, err := getSharedRedisClient(ctx, redisConnectionKey("cluster", info, 0), func() (redis.UniversalClient, error) {
+		return newRedisClusterClient(ctx, info)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return client.(*redis.ClusterClient), release, nil
+}
+
+// getRedisSentinelClient returns the redis sentinel client shared by all scalers using the same connection info
+func getRedisSentinelClient(ctx context.Context, info redisConnectionInfo, dbIndex int) (*redis.Client, func() error, error) {
+	client, release, err := getSharedRedisClient(ctx, redisConnectionKey("sentinel", info, dbIndex), func() (redis.UniversalClient, error) {
+		return newRedisSentinelClient(ctx, info, dbIndex)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return client.(*redis.Client), release, nil
+}
+
+// getRedisClient returns the redis client shared by all scalers using the same connection info
+func getRedisClient(ctx context.Context, info redisConnectionInfo, dbIndex int) (*redis.Client, func() error, error) {
+	client, release, err := getSharedRedisClient(ctx, redisConnectionKey("standalone", info, dbIndex), func() (redis.UniversalClient, error) {
+		return newRedisClient(ctx, info, dbIndex)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return client.(*redis.Client), release, nil
+}
+
+func redisConnectionKey(mode string, info redisConnectionInfo, dbIndex int) string {
+	return connectionPoolKey("redis", mode, strings.Join(info.addresses, ","), info.username, info.password,
+		info.sentinelUsername, info.sentinelPassword, info.sentinelMaster, strconv.FormatBool(info.enableTLS), strconv.Itoa(dbIndex))
+}
+
+func getSharedRedisClient(ctx context.Context, key string, create func() (redis.UniversalClient, error)) (redis.UniversalClient, func() error, error) {
+	healthy := func(connection interface{}) bool {
+		return connection.(redis.UniversalClient).Ping(ctx).Err() == nil
+	}
+	connection, release, err := sharedConnections.acquire("redis", key, healthy, func() (interface{}, func() error, error) {
+		client, err := create()
+		if err != nil {
+			return nil, nil, err
+		}
+		return client, client.Close, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return connection.(redis.UniversalClient), release, nil
+}
+
+func newRedisClusterClient(ctx context.Context, info redisConnectionInfo) (*redis.ClusterClient, error) {
 	options := &redis.ClusterOptions{
 		Addrs:    info.addresses,
 		Username: info.username,
@@ -476,7 +531,7 @@ func getRedisClusterClient(ctx context.Context, info redisConnectionInfo) (*redi
 	return c, nil
 }
 
-func getRedisSentinelClient(ctx context.Context, info redisConnectionInfo, dbIndex int) (*redis.Client, error) {
+func newRedisSentinelClient(ctx context.Context, info redisConnectionInfo, dbIndex int) (*redis.Client, error) {
 	options := &redis.FailoverOptions{
 		Username:         info.username,
 		Password:         info.password,
@@ -500,7 +555,7 @@ func getRedisSentinelClient(ctx context.Context, info redisConnectionInfo, dbInd
 	return c, nil
 }
 
-func getRedisClient(ctx context.Context, info redisConnectionInfo, dbIndex int) (*redis.Client, error) {
+func newRedisClient(ctx context.Context, info redisConnectionInfo, dbIndex int) (*redis.Client, error) {
 	options := &redis.Options{
 		Addr:     info.addresses[0],
 		Username: info.username,
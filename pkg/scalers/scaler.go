@@ -23,7 +23,7 @@ import (
 	"time"
 
 	metrics "github.com/rcrowley/go-metrics"
-	"k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
@@ -45,7 +45,7 @@ type Scaler interface {
 
 	// Returns the metrics based on which this scaler determines that the ScaleTarget scales. This is used to construct the HPA spec that is created for
 	// this scaled object. The labels used should match the selectors used in GetMetrics
-	GetMetricSpecForScaling(ctx context.Context) []v2beta2.MetricSpec
+	GetMetricSpecForScaling(ctx context.Context) []v2.MetricSpec
 
 	IsActive(ctx context.Context) (bool, error)
 
@@ -88,7 +88,7 @@ type ScalerConfig struct {
 	ScalerIndex int
 
 	// MetricType
-	MetricType v2beta2.MetricTargetType
+	MetricType v2.MetricTargetType
 }
 
 // GetFromAuthOrMeta helps getting a field from Auth or Meta sections
@@ -127,27 +127,27 @@ func RemoveIndexFromMetricName(scalerIndex int, metricName string) (string, erro
 }
 
 // GetMetricTargetType helps getting the metric target type of the scaler
-func GetMetricTargetType(config *ScalerConfig) (v2beta2.MetricTargetType, error) {
+func GetMetricTargetType(config *ScalerConfig) (v2.MetricTargetType, error) {
 	switch config.MetricType {
-	case v2beta2.UtilizationMetricType:
+	case v2.UtilizationMetricType:
 		return "", fmt.Errorf("'Utilization' metric type is unsupported for external metrics, allowed values are 'Value' or 'AverageValue'")
 	case "":
 		// Use AverageValue if no metric type was provided
-		return v2beta2.AverageValueMetricType, nil
+		return v2.AverageValueMetricType, nil
 	default:
 		return config.MetricType, nil
 	}
 }
 
 // GetMetricTarget returns a metric target for a valid given metric target type (Value or AverageValue) and value
-func GetMetricTarget(metricType v2beta2.MetricTargetType, metricValue int64) v2beta2.MetricTarget {
-	target := v2beta2.MetricTarget{
+func GetMetricTarget(metricType v2.MetricTargetType, metricValue int64) v2.MetricTarget {
+	target := v2.MetricTarget{
 		Type: metricType,
 	}
 
 	// Construct the target size as a quantity
 	targetQty := resource.NewQuantity(metricValue, resource.DecimalSI)
-	if metricType == v2beta2.AverageValueMetricType {
+	if metricType == v2.AverageValueMetricType {
 		target.AverageValue = targetQty
 	} else {
 		target.Value = targetQty
@@ -5,7 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -13,31 +13,31 @@ func TestGetMetricTargetType(t *testing.T) {
 	cases := []struct {
 		name           string
 		config         *ScalerConfig
-		wantmetricType v2beta2.MetricTargetType
+		wantmetricType v2.MetricTargetType
 		wantErr        error
 	}{
 		{
 			name:           "utilization metric type",
-			config:         &ScalerConfig{MetricType: v2beta2.UtilizationMetricType},
+			config:         &ScalerConfig{MetricType: v2.UtilizationMetricType},
 			wantmetricType: "",
 			wantErr:        fmt.Errorf("'Utilization' metric type is unsupported for external metrics, allowed values are 'Value' or 'AverageValue'"),
 		},
 		{
 			name:           "average value metric type",
-			config:         &ScalerConfig{MetricType: v2beta2.AverageValueMetricType},
-			wantmetricType: v2beta2.AverageValueMetricType,
+			config:         &ScalerConfig{MetricType: v2.AverageValueMetricType},
+			wantmetricType: v2.AverageValueMetricType,
 			wantErr:        nil,
 		},
 		{
 			name:           "value metric type",
-			config:         &ScalerConfig{MetricType: v2beta2.ValueMetricType},
-			wantmetricType: v2beta2.ValueMetricType,
+			config:         &ScalerConfig{MetricType: v2.ValueMetricType},
+			wantmetricType: v2.ValueMetricType,
 			wantErr:        nil,
 		},
 		{
 			name:           "no metric type",
 			config:         &ScalerConfig{},
-			wantmetricType: v2beta2.AverageValueMetricType,
+			wantmetricType: v2.AverageValueMetricType,
 			wantErr:        nil,
 		},
 	}
@@ -59,21 +59,21 @@ func TestGetMetricTargetType(t *testing.T) {
 func TestGetMetricTarget(t *testing.T) {
 	cases := []struct {
 		name             string
-		metricType       v2beta2.MetricTargetType
+		metricType       v2.MetricTargetType
 		metricValue      int64
-		wantmetricTarget v2beta2.MetricTarget
+		wantmetricTarget v2.MetricTarget
 	}{
 		{
 			name:             "average value metric type",
-			metricType:       v2beta2.AverageValueMetricType,
+			metricType:       v2.AverageValueMetricType,
 			metricValue:      10,
-			wantmetricTarget: v2beta2.MetricTarget{Type: v2beta2.AverageValueMetricType, AverageValue: resource.NewQuantity(10, resource.DecimalSI)},
+			wantmetricTarget: v2.MetricTarget{Type: v2.AverageValueMetricType, AverageValue: resource.NewQuantity(10, resource.DecimalSI)},
 		},
 		{
 			name:             "value metric type",
-			metricType:       v2beta2.ValueMetricType,
+			metricType:       v2.ValueMetricType,
 			metricValue:      20,
-			wantmetricTarget: v2beta2.MetricTarget{Type: v2beta2.ValueMetricType, Value: resource.NewQuantity(20, resource.DecimalSI)},
+			wantmetricTarget: v2.MetricTarget{Type: v2.ValueMetricType, Value: resource.NewQuantity(20, resource.DecimalSI)},
 		},
 	}
 
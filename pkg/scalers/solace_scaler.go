@@ -8,7 +8,7 @@ import (
 	"strconv"
 	"strings"
 
-	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -57,7 +57,7 @@ type SolaceMetricValues struct {
 }
 
 type SolaceScaler struct {
-	metricType v2beta2.MetricTargetType
+	metricType v2.MetricTargetType
 	metadata   *SolaceMetadata
 	httpClient *http.Client
 }
@@ -107,13 +107,16 @@ type solaceSEMPMetadata struct {
 	ResponseCode int `json:"responseCode"`
 }
 
-//	Solace Logger
+// Solace Logger
 var solaceLog = logf.Log.WithName(solaceScalerID + "_scaler")
 
-//	Constructor for SolaceScaler
+// Constructor for SolaceScaler
 func NewSolaceScaler(config *ScalerConfig) (Scaler, error) {
 	// Create HTTP Client
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	httpClient, err := newHTTPClient(config, config.GlobalHTTPTimeout, false)
+	if err != nil {
+		return nil, fmt.Errorf("error creating http client: %s", err)
+	}
 
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
@@ -134,7 +137,7 @@ func NewSolaceScaler(config *ScalerConfig) (Scaler, error) {
 	}, nil
 }
 
-//	Called by constructor
+// Called by constructor
 func parseSolaceMetadata(config *ScalerConfig) (*SolaceMetadata, error) {
 	meta := SolaceMetadata{}
 	//	GET THE SEMP API ENDPOINT
@@ -238,44 +241,44 @@ func getSolaceSempCredentials(config *ScalerConfig) (u string, p string, err err
 	return u, p, nil
 }
 
-//	INTERFACE METHOD
-//	DEFINE METRIC FOR SCALING
-//	CURRENT SUPPORTED METRICS ARE:
-//	- QUEUE MESSAGE COUNT (msgCount)
-//	- QUEUE SPOOL USAGE   (msgSpoolUsage in MBytes)
-//	METRIC IDENTIFIER HAS THE SIGNATURE:
-//	- solace-[Queue_Name]-[metric_type]
-//	e.g. solace-QUEUE1-msgCount
-func (s *SolaceScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
-	var metricSpecList []v2beta2.MetricSpec
+// INTERFACE METHOD
+// DEFINE METRIC FOR SCALING
+// CURRENT SUPPORTED METRICS ARE:
+// - QUEUE MESSAGE COUNT (msgCount)
+// - QUEUE SPOOL USAGE   (msgSpoolUsage in MBytes)
+// METRIC IDENTIFIER HAS THE SIGNATURE:
+// - solace-[Queue_Name]-[metric_type]
+// e.g. solace-QUEUE1-msgCount
+func (s *SolaceScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	var metricSpecList []v2.MetricSpec
 	// Message Count Target Spec
 	if s.metadata.msgCountTarget > 0 {
 		metricName := kedautil.NormalizeString(fmt.Sprintf("solace-%s-%s", s.metadata.queueName, solaceTriggermsgcount))
-		externalMetric := &v2beta2.ExternalMetricSource{
-			Metric: v2beta2.MetricIdentifier{
+		externalMetric := &v2.ExternalMetricSource{
+			Metric: v2.MetricIdentifier{
 				Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, metricName),
 			},
 			Target: GetMetricTarget(s.metricType, s.metadata.msgCountTarget),
 		}
-		metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: solaceExtMetricType}
+		metricSpec := v2.MetricSpec{External: externalMetric, Type: solaceExtMetricType}
 		metricSpecList = append(metricSpecList, metricSpec)
 	}
 	// Message Spool Usage Target Spec
 	if s.metadata.msgSpoolUsageTarget > 0 {
 		metricName := kedautil.NormalizeString(fmt.Sprintf("solace-%s-%s", s.metadata.queueName, solaceTriggermsgspoolusage))
-		externalMetric := &v2beta2.ExternalMetricSource{
-			Metric: v2beta2.MetricIdentifier{
+		externalMetric := &v2.ExternalMetricSource{
+			Metric: v2.MetricIdentifier{
 				Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, metricName),
 			},
 			Target: GetMetricTarget(s.metricType, s.metadata.msgSpoolUsageTarget),
 		}
-		metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: solaceExtMetricType}
+		metricSpec := v2.MetricSpec{External: externalMetric, Type: solaceExtMetricType}
 		metricSpecList = append(metricSpecList, metricSpec)
 	}
 	return metricSpecList
 }
 
-//	returns SolaceMetricValues struct populated from broker  SEMP endpoint
+// returns SolaceMetricValues struct populated from broker  SEMP endpoint
 func (s *SolaceScaler) getSolaceQueueMetricsFromSEMP(ctx context.Context) (SolaceMetricValues, error) {
 	var scaledMetricEndpointURL = s.metadata.endpointURL
 	var httpClient = s.httpClient
@@ -320,9 +323,9 @@ func (s *SolaceScaler) getSolaceQueueMetricsFromSEMP(ctx context.Context) (Solac
 	return metricValues, nil
 }
 
-//	INTERFACE METHOD
-//	Call SEMP API to retrieve metrics
-//	returns value for named metric
+// INTERFACE METHOD
+// Call SEMP API to retrieve metrics
+// returns value for named metric
 func (s *SolaceScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
 	var metricValues, mv SolaceMetricValues
 	var mve error
@@ -355,9 +358,9 @@ func (s *SolaceScaler) GetMetrics(ctx context.Context, metricName string, metric
 	return append([]external_metrics.ExternalMetricValue{}, metric), nil
 }
 
-//	INTERFACE METHOD
-//	Call SEMP API to retrieve metrics
-//	IsActive returns true if queue messageCount > 0 || msgSpoolUsage > 0
+// INTERFACE METHOD
+// Call SEMP API to retrieve metrics
+// IsActive returns true if queue messageCount > 0 || msgSpoolUsage > 0
 func (s *SolaceScaler) IsActive(ctx context.Context) (bool, error) {
 	metricValues, err := s.getSolaceQueueMetricsFromSEMP(ctx)
 	if err != nil {
@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spiffe
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+)
+
+const (
+	// EndpointSocketEnv is the environment variable holding the address of the Workload API socket, like unix:///run/spire/sockets/agent.sock
+	EndpointSocketEnv = "SPIFFE_ENDPOINT_SOCKET"
+
+	// firstSVIDTimeout is how long DefaultX509Source waits for the first X.509 SVID
+	firstSVIDTimeout = 10 * time.Second
+)
+
+var (
+	defaultSourceLock sync.Mutex
+	defaultSource     *X509Source
+)
+
+// DefaultX509Source returns the X509Source of the Workload API socket set in SPIFFE_ENDPOINT_SOCKET, shared by all scalers.
+// It waits for the first X.509 SVID, the source is connected again on the next call when there is none yet.
+func DefaultX509Source() (*X509Source, error) {
+	defaultSourceLock.Lock()
+	defer defaultSourceLock.Unlock()
+	if defaultSource != nil {
+		return defaultSource, nil
+	}
+
+	address := os.Getenv(EndpointSocketEnv)
+	if address == "" {
+		return nil, fmt.Errorf("%s isn't set", EndpointSocketEnv)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), firstSVIDTimeout)
+	defer cancel()
+	source, err := NewX509Source(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	defaultSource = source
+	return source, nil
+}
+
+// X509Source holds the latest X.509 SVID and trust bundle streamed by the Workload API
+type X509Source struct {
+	source *workloadapi.X509Source
+}
+
+// NewX509Source connects to the Workload API at the address, unix:///path or tcp://ip:port, and waits for the first X.509 SVID
+// until the context is done. The SVIDs are then updated in the background until Close is called.
+func NewX509Source(ctx context.Context, address string) (*X509Source, error) {
+	source, err := workloadapi.NewX509Source(ctx, workloadapi.WithClientOptions(workloadapi.WithAddr(address)))
+	if err != nil {
+		return nil, fmt.Errorf("no X.509 SVID received from the Workload API at %s: %s", address, err)
+	}
+	return &X509Source{source: source}, nil
+}
+
+// Close stops updating the SVIDs and closes the connection to the Workload API
+func (s *X509Source) Close() error {
+	return s.source.Close()
+}
+
+// GetClientCertificate returns the current X.509 SVID, it can be used as tls.Config.GetClientCertificate
+func (s *X509Source) GetClientCertificate(info *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return tlsconfig.GetClientCertificate(s.source)(info)
+}
+
+// PEM returns the current X.509 SVID, its private key and the trust bundle PEM encoded
+func (s *X509Source) PEM() (cert, key, bundle []byte, err error) {
+	svid, err := s.source.GetX509SVID()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	cert, key, err = svid.Marshal()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	trustBundle, err := s.source.GetX509BundleForTrustDomain(svid.ID.TrustDomain())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	bundle, err = trustBundle.Marshal()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return cert, key, bundle, nil
+}
+
+// ConfigureTLS makes the config present the current X.509 SVID as client certificate instead of its certificates.
+// Without serverID the server is verified as usual, by the CAs of the config for its host name. With serverID, a SPIFFE
+// ID like spiffe://example.org/scaler, the server must present an X.509 SVID of the trust bundle with exactly that ID.
+func (s *X509Source) ConfigureTLS(config *tls.Config, serverID string) error {
+	config.Certificates = nil
+	config.GetClientCertificate = tlsconfig.GetClientCertificate(s.source)
+	if serverID == "" {
+		return nil
+	}
+
+	id, err := spiffeid.FromString(serverID)
+	if err != nil {
+		return fmt.Errorf("invalid server SPIFFE ID %q: %s", serverID, err)
+	}
+	// SVIDs have no DNS names, the host name verification is replaced by the verification of the SPIFFE ID
+	config.InsecureSkipVerify = true
+	config.VerifyPeerCertificate = tlsconfig.VerifyPeerCertificate(s.source, tlsconfig.AuthorizeID(id))
+	return nil
+}
@@ -0,0 +1,256 @@
+package spiffe
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "example.org"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key}
+}
+
+// issue returns an X.509 SVID with the SPIFFE ID and its PKCS#8 private key
+func (ca *testCA) issue(t *testing.T, serial int64, id string) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uri, err := url.Parse(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{uri},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der, keyDER
+}
+
+func (ca *testCA) response(t *testing.T, serial int64) *workload.X509SVIDResponse {
+	cert, key := ca.issue(t, serial, "spiffe://example.org/keda")
+	return &workload.X509SVIDResponse{Svids: []*workload.X509SVID{{
+		SpiffeId:    "spiffe://example.org/keda",
+		X509Svid:    cert,
+		X509SvidKey: key,
+		Bundle:      ca.cert.Raw,
+	}}}
+}
+
+// fakeWorkloadAPI streams the responses sent on its channel to the FetchX509SVID calls
+type fakeWorkloadAPI struct {
+	workload.UnimplementedSpiffeWorkloadAPIServer
+	t         *testing.T
+	responses <-chan *workload.X509SVIDResponse
+}
+
+func (w *fakeWorkloadAPI) FetchX509SVID(_ *workload.X509SVIDRequest, stream workload.SpiffeWorkloadAPI_FetchX509SVIDServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	if len(md.Get("workload.spiffe.io")) == 0 {
+		w.t.Errorf("Unexpected call without security header, metadata %v", md)
+		return nil
+	}
+	for {
+		select {
+		case response := <-w.responses:
+			if err := stream.Send(response); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// serveWorkloadAPI serves a fake Workload API at the socket
+func serveWorkloadAPI(t *testing.T, socket string, responses <-chan *workload.X509SVIDResponse) *grpc.Server {
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	workload.RegisterSpiffeWorkloadAPIServer(server, &fakeWorkloadAPI{t: t, responses: responses})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	return server
+}
+
+// newScaler starts a TLS server presenting an X.509 SVID with the SPIFFE ID and requiring a client certificate issued by the CA,
+// the serial numbers of the client certificates are sent on the returned channel
+func newScaler(t *testing.T, ca *testCA, serial int64, id string) (*httptest.Server, <-chan int64) {
+	cert, key := ca.issue(t, serial, id)
+	privateKey, err := x509.ParsePKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	clientSerials := make(chan int64, 2)
+	scaler := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientSerials <- r.TLS.PeerCertificates[0].SerialNumber.Int64()
+	}))
+	scaler.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{cert}, PrivateKey: privateKey}},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    roots,
+	}
+	scaler.StartTLS()
+	return scaler, clientSerials
+}
+
+func get(config *tls.Config, url string) error {
+	// a new transport for each request, so the TLS handshake isn't skipped by keep alive connections
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: config.Clone()}}
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestX509Source(t *testing.T) {
+	ca := newTestCA(t)
+	socket := filepath.Join(t.TempDir(), "agent.sock")
+	responses := make(chan *workload.X509SVIDResponse, 1)
+	server := serveWorkloadAPI(t, socket, responses)
+	defer server.Stop()
+
+	responses <- ca.response(t, 2)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	source, err := NewX509Source(ctx, "unix://"+socket)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer source.Close()
+	serial := func() int64 {
+		cert, _ := source.GetClientCertificate(nil)
+		leaf, _ := x509.ParseCertificate(cert.Certificate[0])
+		return leaf.SerialNumber.Int64()
+	}
+	if serial() != 2 {
+		t.Errorf("Expected the first SVID, got serial %d", serial())
+	}
+
+	// the client certificate of the TLS config follows the rotations of the SVID
+	scaler, clientSerials := newScaler(t, ca, 10, "spiffe://example.org/scaler")
+	defer scaler.Close()
+	config := &tls.Config{}
+	if err := source.ConfigureTLS(config, "spiffe://example.org/scaler"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := get(config, scaler.URL); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if clientSerial := <-clientSerials; clientSerial != 2 {
+		t.Errorf("Expected the first SVID as client certificate, got serial %d", clientSerial)
+	}
+
+	responses <- ca.response(t, 3)
+	deadline := time.Now().Add(5 * time.Second)
+	for serial() != 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := get(config, scaler.URL); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if clientSerial := <-clientSerials; clientSerial != 3 {
+		t.Errorf("Expected the rotated SVID as client certificate, got serial %d", clientSerial)
+	}
+
+	// servers presenting another SVID of the trust domain are rejected
+	other, _ := newScaler(t, ca, 11, "spiffe://example.org/other")
+	defer other.Close()
+	if err := get(config, other.URL); err == nil {
+		t.Error("Expected an error requesting a server with another SPIFFE ID")
+	}
+
+	// without server SPIFFE ID, SVIDs aren't accepted and the host name is verified by the CAs of the config
+	config = &tls.Config{}
+	if err := source.ConfigureTLS(config, ""); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := get(config, scaler.URL); err == nil {
+		t.Error("Expected an error requesting a server with an SVID without server SPIFFE ID")
+	}
+	web := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer web.Close()
+	if err := get(config, web.URL); err == nil {
+		t.Error("Expected an error requesting an untrusted server")
+	}
+	config.RootCAs = x509.NewCertPool()
+	config.RootCAs.AddCert(web.Certificate())
+	if err := get(config, web.URL); err != nil {
+		t.Errorf("Expected the server trusted by the CAs of the config, got %s", err)
+	}
+
+	if err := source.ConfigureTLS(&tls.Config{}, "example.org/scaler"); err == nil {
+		t.Error("Expected an error for an invalid server SPIFFE ID")
+	}
+}
+
+func TestX509SourceWithoutWorkloadAPI(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := NewX509Source(ctx, "unix://"+filepath.Join(t.TempDir(), "agent.sock")); err == nil {
+		t.Error("Expected an error without Workload API")
+	}
+	if _, err := NewX509Source(ctx, "/run/spire/sockets/agent.sock"); err == nil {
+		t.Error("Expected an error for an address without scheme")
+	}
+}
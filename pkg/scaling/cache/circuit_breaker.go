@@ -0,0 +1,229 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metrics"
+)
+
+// CircuitBreakerConfig holds the settings of the per trigger circuit breakers
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed checks (each including a rebuild of the scaler)
+	// after which the circuit is opened
+	FailureThreshold int
+	// InitialBackoff is how long the circuit stays open the first time it is opened
+	InitialBackoff time.Duration
+	// MaxBackoff caps the exponentially growing time the circuit stays open
+	MaxBackoff time.Duration
+}
+
+// DefaultCircuitBreakerConfig is used for all triggers built by the scale handler
+var DefaultCircuitBreakerConfig = CircuitBreakerConfig{
+	FailureThreshold: 3,
+	InitialBackoff:   5 * time.Second,
+	MaxBackoff:       5 * time.Minute,
+}
+
+// ErrCircuitOpen is returned for a trigger whose circuit breaker is open
+type ErrCircuitOpen struct {
+	Trigger      string
+	NextProbeAt  time.Time
+	Failures     int
+	LastErrorMsg string
+}
+
+func (e *ErrCircuitOpen) Error() string {
+	return fmt.Sprintf("circuit breaker for trigger %s is open after %d consecutive failures, next probe at %s, last error: %s",
+		e.Trigger, e.Failures, e.NextProbeAt.Format(time.RFC3339), e.LastErrorMsg)
+}
+
+// CircuitBreaker guards a single trigger, while it is open the scaler is neither called nor rebuilt,
+// once the backoff expires a single probe is allowed (half-open) and its result closes or reopens the circuit
+type CircuitBreaker struct {
+	config    CircuitBreakerConfig
+	namespace string
+	name      string
+	trigger   string
+
+	lock      sync.Mutex
+	state     kedav1alpha1.CircuitBreakerState
+	failures  int
+	trips     int
+	openUntil time.Time
+	probing   bool
+	lastError error
+	now       func() time.Time
+}
+
+// NewCircuitBreaker creates a closed CircuitBreaker for a trigger of the object with the passed namespace and name
+func NewCircuitBreaker(config CircuitBreakerConfig, namespace, name, trigger string) *CircuitBreaker {
+	if config.FailureThreshold < 1 {
+		config.FailureThreshold = 1
+	}
+	return &CircuitBreaker{
+		config:    config,
+		namespace: namespace,
+		name:      name,
+		trigger:   trigger,
+		state:     kedav1alpha1.CircuitBreakerClosed,
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether the trigger may be checked now, it returns ErrCircuitOpen otherwise.
+// When the backoff of an open circuit has expired, the caller becomes the single half-open probe.
+func (b *CircuitBreaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	switch b.state {
+	case kedav1alpha1.CircuitBreakerOpen:
+		if b.now().Before(b.openUntil) {
+			return b.openError()
+		}
+		b.setState(kedav1alpha1.CircuitBreakerHalfOpen)
+		b.probing = true
+		return nil
+	case kedav1alpha1.CircuitBreakerHalfOpen:
+		if b.probing {
+			return b.openError()
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// RecordSuccess closes the circuit and resets the backoff
+func (b *CircuitBreaker) RecordSuccess() {
+	if b == nil {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.failures = 0
+	b.trips = 0
+	b.probing = false
+	b.lastError = nil
+	b.setState(kedav1alpha1.CircuitBreakerClosed)
+}
+
+// RecordFailure counts a failed check, the circuit is opened once the failure threshold is reached
+// and reopened with a doubled backoff when a half-open probe fails
+func (b *CircuitBreaker) RecordFailure(err error) {
+	if b == nil {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.failures++
+	b.probing = false
+	b.lastError = err
+	if b.state == kedav1alpha1.CircuitBreakerHalfOpen || b.failures >= b.config.FailureThreshold {
+		b.trips++
+		b.openUntil = b.now().Add(b.backoff())
+		b.setState(kedav1alpha1.CircuitBreakerOpen)
+	}
+}
+
+// Status returns the current state of the circuit breaker
+func (b *CircuitBreaker) Status() kedav1alpha1.TriggerCircuitBreakerStatus {
+	if b == nil {
+		return kedav1alpha1.TriggerCircuitBreakerStatus{State: kedav1alpha1.CircuitBreakerClosed}
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	failures := int32(b.failures)
+	status := kedav1alpha1.TriggerCircuitBreakerStatus{
+		State:               b.state,
+		ConsecutiveFailures: &failures,
+	}
+	if b.state == kedav1alpha1.CircuitBreakerOpen {
+		nextProbe := metav1.NewTime(b.openUntil)
+		status.NextProbeTime = &nextProbe
+	}
+	return status
+}
+
+// Forget drops the metrics of the circuit breaker, it is called once the trigger is not used anymore
+func (b *CircuitBreaker) Forget() {
+	if b == nil {
+		return
+	}
+	metrics.DeleteCircuitBreakerState(b.namespace, b.name, b.trigger)
+}
+
+// Trigger returns the name of the trigger guarded by the circuit breaker
+func (b *CircuitBreaker) Trigger() string {
+	if b == nil {
+		return ""
+	}
+	return b.trigger
+}
+
+// backoff must be called with the lock held
+func (b *CircuitBreaker) backoff() time.Duration {
+	backoff := b.config.InitialBackoff
+	for i := 1; i < b.trips && backoff < b.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if b.config.MaxBackoff > 0 && backoff > b.config.MaxBackoff {
+		backoff = b.config.MaxBackoff
+	}
+	return backoff
+}
+
+// openError must be called with the lock held
+func (b *CircuitBreaker) openError() error {
+	msg := ""
+	if b.lastError != nil {
+		msg = b.lastError.Error()
+	}
+	return &ErrCircuitOpen{
+		Trigger:      b.trigger,
+		NextProbeAt:  b.openUntil,
+		Failures:     b.failures,
+		LastErrorMsg: msg,
+	}
+}
+
+// setState must be called with the lock held
+func (b *CircuitBreaker) setState(state kedav1alpha1.CircuitBreakerState) {
+	if b.state == state {
+		return
+	}
+	b.state = state
+	metrics.RecordCircuitBreakerState(b.namespace, b.name, b.trigger, string(state))
+}
@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+)
+
+func newTestCircuitBreaker(now *time.Time) *CircuitBreaker {
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{
+		FailureThreshold: 2,
+		InitialBackoff:   10 * time.Second,
+		MaxBackoff:       30 * time.Second,
+	}, "default", "test", "s0-kafka")
+	breaker.now = func() time.Time { return *now }
+	return breaker
+}
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	now := time.Now()
+	breaker := newTestCircuitBreaker(&now)
+	failure := errors.New("connection refused")
+
+	assert.Nil(t, breaker.Allow())
+	breaker.RecordFailure(failure)
+	assert.Equal(t, kedav1alpha1.CircuitBreakerClosed, breaker.Status().State)
+
+	assert.Nil(t, breaker.Allow())
+	breaker.RecordFailure(failure)
+	status := breaker.Status()
+	assert.Equal(t, kedav1alpha1.CircuitBreakerOpen, status.State)
+	assert.Equal(t, int32(2), *status.ConsecutiveFailures)
+	assert.Equal(t, now.Add(10*time.Second).Unix(), status.NextProbeTime.Unix())
+
+	err := breaker.Allow()
+	var openErr *ErrCircuitOpen
+	assert.True(t, errors.As(err, &openErr))
+	assert.Equal(t, "s0-kafka", openErr.Trigger)
+	assert.Equal(t, failure.Error(), openErr.LastErrorMsg)
+}
+
+func TestCircuitBreakerHalfOpenProbe(t *testing.T) {
+	now := time.Now()
+	breaker := newTestCircuitBreaker(&now)
+	failure := errors.New("connection refused")
+	breaker.RecordFailure(failure)
+	breaker.RecordFailure(failure)
+
+	// a single probe is allowed once the backoff expired
+	now = now.Add(11 * time.Second)
+	assert.Nil(t, breaker.Allow())
+	assert.Equal(t, kedav1alpha1.CircuitBreakerHalfOpen, breaker.Status().State)
+	assert.NotNil(t, breaker.Allow())
+
+	// a failed probe reopens the circuit with a doubled backoff
+	breaker.RecordFailure(failure)
+	status := breaker.Status()
+	assert.Equal(t, kedav1alpha1.CircuitBreakerOpen, status.State)
+	assert.Equal(t, now.Add(20*time.Second).Unix(), status.NextProbeTime.Unix())
+
+	// the backoff is capped
+	now = now.Add(21 * time.Second)
+	assert.Nil(t, breaker.Allow())
+	breaker.RecordFailure(failure)
+	assert.Equal(t, now.Add(30*time.Second).Unix(), breaker.Status().NextProbeTime.Unix())
+
+	// a successful probe closes the circuit
+	now = now.Add(31 * time.Second)
+	assert.Nil(t, breaker.Allow())
+	breaker.RecordSuccess()
+	status = breaker.Status()
+	assert.Equal(t, kedav1alpha1.CircuitBreakerClosed, status.State)
+	assert.Equal(t, int32(0), *status.ConsecutiveFailures)
+	assert.Nil(t, status.NextProbeTime)
+}
+
+func TestCircuitBreakerNil(t *testing.T) {
+	var breaker *CircuitBreaker
+	assert.Nil(t, breaker.Allow())
+	breaker.RecordFailure(errors.New("failure"))
+	breaker.RecordSuccess()
+	assert.Equal(t, kedav1alpha1.CircuitBreakerClosed, breaker.Status().State)
+}
+
+func TestScalersCacheSkipsRebuildWhileCircuitOpen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Now()
+	breaker := newTestCircuitBreaker(&now)
+	failure := errors.New("connection refused")
+
+	failingScaler := func() scalers.Scaler {
+		s := mock_scalers.NewMockScaler(ctrl)
+		s.EXPECT().IsActive(gomock.Any()).Return(false, failure).AnyTimes()
+		s.EXPECT().Close(gomock.Any()).AnyTimes()
+		return s
+	}
+	rebuilds := 0
+	cache := ScalersCache{
+		Scalers: []ScalerBuilder{{
+			Scaler: failingScaler(),
+			Factory: func(context.Context) (scalers.Scaler, error) {
+				rebuilds++
+				return failingScaler(), nil
+			},
+			Breaker: breaker,
+		}},
+	}
+
+	check := func() error {
+		return cache.checkScaler(context.Background(), 0, "IsActive", func(ctx context.Context, s scalers.Scaler) error {
+			_, err := s.IsActive(ctx)
+			return err
+		})
+	}
+
+	assert.NotNil(t, check())
+	assert.NotNil(t, check())
+	assert.Equal(t, 2, rebuilds)
+	assert.Equal(t, kedav1alpha1.CircuitBreakerOpen, cache.GetCircuitBreakerStatuses()["s0-kafka"].State)
+
+	// while the circuit is open the scaler is neither called nor rebuilt
+	var openErr *ErrCircuitOpen
+	assert.True(t, errors.As(check(), &openErr))
+	assert.Equal(t, 2, rebuilds)
+
+	now = now.Add(11 * time.Second)
+	assert.NotNil(t, check())
+	assert.Equal(t, 3, rebuilds)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kedacore/keda/v2/pkg/scalers"
+)
+
+// Dependency identifies an object that was read to build the scaler of a trigger, Namespace is empty for cluster scoped objects
+type Dependency struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// Dependencies is the set of objects the scaler of a trigger was built from, it is replaced every time the scaler is rebuilt
+type Dependencies struct {
+	lock sync.RWMutex
+	set  map[Dependency]bool
+}
+
+// Replace replaces the recorded dependencies
+func (d *Dependencies) Replace(dependencies map[Dependency]bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.set = dependencies
+}
+
+// Contains reports whether the scaler was built from the passed object
+func (d *Dependencies) Contains(dependency Dependency) bool {
+	if d == nil {
+		return false
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.set[dependency]
+}
+
+// DependsOn reports whether any scaler of the cache was built from the passed object
+func (c *ScalersCache) DependsOn(dependency Dependency) bool {
+	for _, s := range c.Scalers {
+		if s.Dependencies.Contains(dependency) {
+			return true
+		}
+	}
+	return false
+}
+
+// RebuildDependentScalers builds new scalers for the triggers built from the passed object, keyed by the index of the trigger,
+// and returns the names of the rebuilt triggers. The scalers of the cache aren't replaced until ReplaceScalers is called,
+// so the rebuild doesn't have to hold the locks guarding the cache. A scaler that can't be rebuilt is kept until its next failure.
+func (c *ScalersCache) RebuildDependentScalers(ctx context.Context, dependency Dependency) (map[int]scalers.Scaler, []string, error) {
+	rebuilt := map[int]scalers.Scaler{}
+	var refreshed, failed []string
+	for id, s := range c.Scalers {
+		if !s.Dependencies.Contains(dependency) {
+			continue
+		}
+		scaler, err := s.Factory(ctx)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", s.TriggerName, err))
+			continue
+		}
+		rebuilt[id] = scaler
+		refreshed = append(refreshed, s.TriggerName)
+	}
+	if len(failed) > 0 {
+		return rebuilt, refreshed, fmt.Errorf("error rebuilding triggers %s", strings.Join(failed, "; "))
+	}
+	return rebuilt, refreshed, nil
+}
+
+// ReplaceScalers replaces the scalers of the triggers with the ones built by RebuildDependentScalers
+// and returns the replaced scalers, they have to be closed by the caller
+func (c *ScalersCache) ReplaceScalers(rebuilt map[int]scalers.Scaler) []scalers.Scaler {
+	replaced := make([]scalers.Scaler, 0, len(rebuilt))
+	for id, scaler := range rebuilt {
+		replaced = append(replaced, c.replaceScaler(id, scaler))
+	}
+	return replaced
+}
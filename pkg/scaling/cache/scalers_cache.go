@@ -19,30 +19,56 @@ package cache
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
-	"k8s.io/api/autoscaling/v2beta2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	v2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/metrics"
 	"github.com/kedacore/keda/v2/pkg/scalers"
+	"github.com/kedacore/keda/v2/pkg/scaling/hpa"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 )
 
+var metricsServer metrics.PrometheusMetricServer
+
 type ScalersCache struct {
 	Generation int64
+	BuiltAt    time.Time
 	Scalers    []ScalerBuilder
 	Logger     logr.Logger
 	Recorder   record.EventRecorder
+	// Object is the ScaledObject or ScaledJob the scalers were built for, events about the cache are recorded on it
+	Object runtime.Object
+
+	// ObjectKind, ObjectNamespace and ObjectName identify the ScaledObject or ScaledJob in the recorded metrics
+	ObjectKind      string
+	ObjectNamespace string
+	ObjectName      string
 }
 
 type ScalerBuilder struct {
-	Scaler  scalers.Scaler
-	Factory func() (scalers.Scaler, error)
+	Scaler      scalers.Scaler
+	Factory     func(ctx context.Context) (scalers.Scaler, error)
+	Breaker     *CircuitBreaker
+	TriggerName string
+	TriggerType string
+	BuiltAt     time.Time
+	// Dependencies are the objects the Factory read on its last call
+	Dependencies *Dependencies
+
+	// failing is set while the checks of the trigger fail, so only the transitions are recorded as events
+	failing bool
 }
 
 func (c *ScalersCache) GetScalers() []scalers.Scaler {
@@ -67,31 +93,45 @@ func (c *ScalersCache) GetMetricsForScaler(ctx context.Context, id int, metricNa
 	if id < 0 || id >= len(c.Scalers) {
 		return nil, fmt.Errorf("scaler with id %d not found. Len = %d", id, len(c.Scalers))
 	}
-	m, err := c.Scalers[id].Scaler.GetMetrics(ctx, metricName, metricSelector)
-	if err == nil {
-		return m, nil
-	}
-
-	ns, err := c.refreshScaler(ctx, id)
+	var m []external_metrics.ExternalMetricValue
+	err := c.checkScaler(ctx, id, "GetMetrics", func(ctx context.Context, s scalers.Scaler) (err error) {
+		m, err = s.GetMetrics(ctx, metricName, metricSelector)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	return ns.GetMetrics(ctx, metricName, metricSelector)
+	return m, nil
 }
 
-func (c *ScalersCache) IsScaledObjectActive(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (bool, bool, []external_metrics.ExternalMetricValue) {
+// IsScaledObjectActive checks the activity of every trigger, the returned bools report whether any trigger is active and whether any failed.
+// For scale targets with a replicasPath, which no HPA scales, the metrics of the triggers are read by the same check and returned,
+// so each trigger is queried and recorded on its circuit breaker once per scale loop.
+func (c *ScalersCache) IsScaledObjectActive(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (bool, bool, []hpa.Metric) {
 	isActive := false
 	isError := false
+	withMetrics := scaledObject.Spec.ScaleTargetRef != nil && scaledObject.Spec.ScaleTargetRef.ReplicasPath != ""
+	var metrics []hpa.Metric
 	// Let's collect status of all scalers, no matter if any scaler raises error or is active
-	for i, s := range c.Scalers {
-		isTriggerActive, err := s.Scaler.IsActive(ctx)
-		if err != nil {
-			var ns scalers.Scaler
-			ns, err = c.refreshScaler(ctx, i)
-			if err == nil {
-				isTriggerActive, err = ns.IsActive(ctx)
+	for i := range c.Scalers {
+		var isTriggerActive bool
+		var triggerMetrics []hpa.Metric
+		err := c.checkScaler(ctx, i, "IsActive", func(ctx context.Context, s scalers.Scaler) (err error) {
+			isTriggerActive, err = s.IsActive(ctx)
+			if err != nil || !withMetrics {
+				return err
+			}
+			triggerMetrics, err = getScaledObjectMetrics(ctx, s)
+			return err
+		})
+		c.recordTriggerHealth(scaledObject, i, err)
+		s := c.Scalers[i]
+		c.recordTriggerActive(s, err == nil && isTriggerActive)
+		if withMetrics {
+			if err != nil {
+				triggerMetrics = failedMetrics(s.Scaler.GetMetricSpecForScaling(ctx), err)
 			}
+			metrics = append(metrics, triggerMetrics...)
 		}
 
 		logger := c.Logger.WithValues("scaledobject.Name", scaledObject.Name, "scaledObject.Namespace", scaledObject.Namespace,
@@ -112,7 +152,7 @@ func (c *ScalersCache) IsScaledObjectActive(ctx context.Context, scaledObject *k
 		}
 	}
 
-	return isActive, isError, []external_metrics.ExternalMetricValue{}
+	return isActive, isError, metrics
 }
 
 func (c *ScalersCache) IsScaledJobActive(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (bool, int64, int64) {
@@ -172,17 +212,14 @@ func (c *ScalersCache) IsScaledJobActive(ctx context.Context, scaledJob *kedav1a
 
 func (c *ScalersCache) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
 	var metrics []external_metrics.ExternalMetricValue
-	for i, s := range c.Scalers {
-		m, err := s.Scaler.GetMetrics(ctx, metricName, metricSelector)
+	for i := range c.Scalers {
+		var m []external_metrics.ExternalMetricValue
+		err := c.checkScaler(ctx, i, "GetMetrics", func(ctx context.Context, s scalers.Scaler) (err error) {
+			m, err = s.GetMetrics(ctx, metricName, metricSelector)
+			return err
+		})
 		if err != nil {
-			ns, err := c.refreshScaler(ctx, i)
-			if err != nil {
-				return metrics, err
-			}
-			m, err = ns.GetMetrics(ctx, metricName, metricSelector)
-			if err != nil {
-				return metrics, err
-			}
+			return metrics, err
 		}
 		metrics = append(metrics, m...)
 	}
@@ -190,28 +227,127 @@ func (c *ScalersCache) GetMetrics(ctx context.Context, metricName string, metric
 	return metrics, nil
 }
 
+// getScaledObjectMetrics reads the metrics of the scaler, the values of each metric are summed like the HPA does for external metrics.
+// Only external metrics can be read by KEDA, the other metrics are returned with an error.
+func getScaledObjectMetrics(ctx context.Context, s scalers.Scaler) ([]hpa.Metric, error) {
+	var result []hpa.Metric
+	for _, spec := range s.GetMetricSpecForScaling(ctx) {
+		metric := hpa.Metric{Spec: spec}
+		if spec.External == nil {
+			metric.Err = fmt.Errorf("%s metrics are only supported with an HPA", spec.Type)
+			result = append(result, metric)
+			continue
+		}
+		values, err := s.GetMetrics(ctx, spec.External.Metric.Name, labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			metric.Value += value.Value.AsApproximateFloat64()
+		}
+		result = append(result, metric)
+	}
+	return result, nil
+}
+
+// failedMetrics returns the metrics of a trigger whose check failed
+func failedMetrics(specs []v2.MetricSpec, err error) []hpa.Metric {
+	result := make([]hpa.Metric, 0, len(specs))
+	for _, spec := range specs {
+		result = append(result, hpa.Metric{Spec: spec, Err: err})
+	}
+	return result
+}
+
+// checkScaler runs check against the scaler with the passed id, on error the scaler is rebuilt and the check retried.
+// The circuit breaker of the trigger is consulted first, so a failing trigger is only rebuilt once its backoff expired.
+func (c *ScalersCache) checkScaler(ctx context.Context, id int, operation string, check func(context.Context, scalers.Scaler) error) (err error) {
+	if id < 0 || id >= len(c.Scalers) {
+		return fmt.Errorf("scaler with id %d not found. Len = %d", id, len(c.Scalers))
+	}
+
+	ctx, span := tracing.StartSpan(ctx, "Scaler."+operation, trace.WithAttributes(tracing.TriggerAttributes(c.Scalers[id].TriggerType, id)...))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	breaker := c.Scalers[id].Breaker
+	if err := breaker.Allow(); err != nil {
+		span.SetAttributes(attribute.String("keda.circuit_breaker.state", string(kedav1alpha1.CircuitBreakerOpen)))
+		return err
+	}
+
+	err = check(ctx, c.Scalers[id].Scaler)
+	if err != nil {
+		span.AddEvent("rebuilding scaler", trace.WithAttributes(attribute.String("error", err.Error())))
+		var ns scalers.Scaler
+		ns, err = c.refreshScaler(ctx, id)
+		if err == nil {
+			err = check(ctx, ns)
+		}
+	}
+
+	if err != nil {
+		breaker.RecordFailure(err)
+		return err
+	}
+	breaker.RecordSuccess()
+	return nil
+}
+
+// GetCircuitBreakerStatuses returns the status of the circuit breakers that are not closed, keyed by trigger
+func (c *ScalersCache) GetCircuitBreakerStatuses() map[string]kedav1alpha1.TriggerCircuitBreakerStatus {
+	var result map[string]kedav1alpha1.TriggerCircuitBreakerStatus
+	for _, s := range c.Scalers {
+		if s.Breaker == nil {
+			continue
+		}
+		status := s.Breaker.Status()
+		if status.State == kedav1alpha1.CircuitBreakerClosed {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]kedav1alpha1.TriggerCircuitBreakerStatus)
+		}
+		result[s.Breaker.Trigger()] = status
+	}
+	return result
+}
+
 func (c *ScalersCache) refreshScaler(ctx context.Context, id int) (scalers.Scaler, error) {
 	if id < 0 || id >= len(c.Scalers) {
 		return nil, fmt.Errorf("scaler with id %d not found. Len = %d", id, len(c.Scalers))
 	}
 
-	sb := c.Scalers[id]
-	ns, err := sb.Factory()
+	ns, err := c.Scalers[id].Factory(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	c.Scalers[id] = ScalerBuilder{
-		Scaler:  ns,
-		Factory: sb.Factory,
-	}
-	sb.Scaler.Close(ctx)
+	c.replaceScaler(id, ns).Close(ctx)
 
 	return ns, nil
 }
 
-func (c *ScalersCache) GetMetricSpecForScaling(ctx context.Context) []v2beta2.MetricSpec {
-	var spec []v2beta2.MetricSpec
+// replaceScaler replaces the scaler of the trigger and returns the previous one
+func (c *ScalersCache) replaceScaler(id int, ns scalers.Scaler) scalers.Scaler {
+	sb := c.Scalers[id]
+	c.Scalers[id] = ScalerBuilder{
+		Scaler:       ns,
+		Factory:      sb.Factory,
+		Breaker:      sb.Breaker,
+		TriggerName:  sb.TriggerName,
+		TriggerType:  sb.TriggerType,
+		BuiltAt:      time.Now(),
+		Dependencies: sb.Dependencies,
+		failing:      sb.failing,
+	}
+	metricsServer.RecordScalersCacheRebuild(c.ObjectNamespace, c.ObjectKind, c.ObjectName, metrics.ScalersCacheRebuildTrigger)
+	return sb.Scaler
+}
+
+func (c *ScalersCache) GetMetricSpecForScaling(ctx context.Context) []v2.MetricSpec {
+	var spec []v2.MetricSpec
 	for _, s := range c.Scalers {
 		spec = append(spec, s.Scaler.GetMetricSpecForScaling(ctx)...)
 	}
@@ -222,6 +358,8 @@ func (c *ScalersCache) Close(ctx context.Context) {
 	scalers := c.Scalers
 	c.Scalers = nil
 	for _, s := range scalers {
+		s.Breaker.Forget()
+		metricsServer.DeleteTriggerActive(c.ObjectNamespace, c.ObjectKind, c.ObjectName, s.TriggerName, s.TriggerType)
 		err := s.Scaler.Close(ctx)
 		if err != nil {
 			c.Logger.Error(err, "error closing scaler", "scaler", s)
@@ -229,6 +367,83 @@ func (c *ScalersCache) Close(ctx context.Context) {
 	}
 }
 
+// recordTriggerHealth records an event when the trigger with the passed id started failing or recovered
+func (c *ScalersCache) recordTriggerHealth(object runtime.Object, id int, err error) {
+	s := &c.Scalers[id]
+	switch {
+	case err != nil && !s.failing:
+		s.failing = true
+		c.Recorder.Eventf(object, corev1.EventTypeWarning, eventreason.KEDATriggerFailing, "Trigger %s started failing: %s", s.TriggerName, err)
+	case err == nil && s.failing:
+		s.failing = false
+		c.Recorder.Eventf(object, corev1.EventTypeNormal, eventreason.KEDATriggerRecovered, "Trigger %s recovered", s.TriggerName)
+	}
+}
+
+func (c *ScalersCache) recordTriggerActive(s ScalerBuilder, active bool) {
+	metricsServer.RecordTriggerActive(c.ObjectNamespace, c.ObjectKind, c.ObjectName, s.TriggerName, s.TriggerType, active)
+}
+
+// TriggerEvaluation is the result of evaluating a single trigger on demand
+type TriggerEvaluation struct {
+	Name             string                                   `json:"name"`
+	Type             string                                   `json:"type"`
+	BuiltAt          time.Time                                `json:"builtAt"`
+	SinceLastRebuild string                                   `json:"sinceLastRebuild"`
+	CircuitBreaker   kedav1alpha1.TriggerCircuitBreakerStatus `json:"circuitBreaker"`
+	IsActive         *bool                                    `json:"isActive,omitempty"`
+	MetricSpecs      []v2.MetricSpec                          `json:"metricSpecs,omitempty"`
+	Metrics          []external_metrics.ExternalMetricValue   `json:"metrics,omitempty"`
+	Errors           []string                                 `json:"errors,omitempty"`
+}
+
+// EvaluateScalers calls IsActive, GetMetricSpecForScaling and GetMetrics on the live scalers of the passed triggers and reports the results,
+// the caller has to hold the scaling mutex of the object so the scalers aren't rebuilt or closed meanwhile. Unlike the checks of the
+// scale loop, failures aren't recorded on the circuit breaker and don't rebuild the scaler, and triggers with an open circuit are not evaluated at all.
+func EvaluateScalers(ctx context.Context, builders []ScalerBuilder) []TriggerEvaluation {
+	now := time.Now()
+	result := make([]TriggerEvaluation, 0, len(builders))
+	for _, sb := range builders {
+		evaluation := TriggerEvaluation{
+			Name:             sb.TriggerName,
+			Type:             sb.TriggerType,
+			BuiltAt:          sb.BuiltAt,
+			SinceLastRebuild: now.Sub(sb.BuiltAt).Round(time.Millisecond).String(),
+			CircuitBreaker:   sb.Breaker.Status(),
+		}
+		if evaluation.CircuitBreaker.State == kedav1alpha1.CircuitBreakerOpen {
+			evaluation.Errors = append(evaluation.Errors, "circuit breaker is open, the trigger is not evaluated")
+		} else {
+			evaluateScaler(ctx, sb.Scaler, &evaluation)
+		}
+		result = append(result, evaluation)
+	}
+	return result
+}
+
+func evaluateScaler(ctx context.Context, scaler scalers.Scaler, evaluation *TriggerEvaluation) {
+	isActive, err := scaler.IsActive(ctx)
+	if err != nil {
+		evaluation.Errors = append(evaluation.Errors, fmt.Sprintf("IsActive: %s", err))
+	} else {
+		evaluation.IsActive = &isActive
+	}
+
+	evaluation.MetricSpecs = scaler.GetMetricSpecForScaling(ctx)
+	for _, spec := range evaluation.MetricSpecs {
+		// resource metrics (cpu/memory) are served by the metrics server, not by the scaler
+		if spec.External == nil {
+			continue
+		}
+		metrics, err := scaler.GetMetrics(ctx, spec.External.Metric.Name, labels.Everything())
+		if err != nil {
+			evaluation.Errors = append(evaluation.Errors, fmt.Sprintf("GetMetrics %s: %s", spec.External.Metric.Name, err))
+			continue
+		}
+		evaluation.Metrics = append(evaluation.Metrics, metrics...)
+	}
+}
+
 type scalerMetrics struct {
 	queueLength int64
 	maxValue    int64
@@ -254,14 +469,14 @@ func (c *ScalersCache) getScaledJobMetrics(ctx context.Context, scaledJob *kedav
 			continue
 		}
 
-		isTriggerActive, err := s.Scaler.IsActive(ctx)
-		if err != nil {
-			var ns scalers.Scaler
-			ns, err = c.refreshScaler(ctx, i)
-			if err == nil {
-				isTriggerActive, err = ns.IsActive(ctx)
-			}
-		}
+		var isTriggerActive bool
+		err := c.checkScaler(ctx, i, "IsActive", func(ctx context.Context, s scalers.Scaler) (err error) {
+			isTriggerActive, err = s.IsActive(ctx)
+			return err
+		})
+		c.recordTriggerHealth(scaledJob, i, err)
+		s = c.Scalers[i]
+		c.recordTriggerActive(s, err == nil && isTriggerActive)
 
 		if err != nil {
 			scalerLogger.V(1).Info("Error getting scaler.IsActive, but continue", "Error", err)
@@ -304,7 +519,7 @@ func (c *ScalersCache) getScaledJobMetrics(ctx context.Context, scaledJob *kedav
 	return scalersMetrics
 }
 
-func getTargetAverageValue(metricSpecs []v2beta2.MetricSpec) int64 {
+func getTargetAverageValue(metricSpecs []v2.MetricSpec) int64 {
 	var targetAverageValue int64
 	var metricValue int64
 	var flag bool
@@ -2,14 +2,17 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
-	"k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
@@ -20,19 +23,19 @@ import (
 
 func TestTargetAverageValue(t *testing.T) {
 	// count = 0
-	specs := []v2beta2.MetricSpec{}
+	specs := []v2.MetricSpec{}
 	metricName := "s0-messageCount"
 	targetAverageValue := getTargetAverageValue(specs)
 	assert.Equal(t, int64(0), targetAverageValue)
 	// 1 1
-	specs = []v2beta2.MetricSpec{
+	specs = []v2.MetricSpec{
 		createMetricSpec(1, metricName),
 		createMetricSpec(1, metricName),
 	}
 	targetAverageValue = getTargetAverageValue(specs)
 	assert.Equal(t, int64(1), targetAverageValue)
 	// 5 5 3
-	specs = []v2beta2.MetricSpec{
+	specs = []v2.MetricSpec{
 		createMetricSpec(5, metricName),
 		createMetricSpec(5, metricName),
 		createMetricSpec(3, metricName),
@@ -41,7 +44,7 @@ func TestTargetAverageValue(t *testing.T) {
 	assert.Equal(t, int64(4), targetAverageValue)
 
 	// 5 5 4
-	specs = []v2beta2.MetricSpec{
+	specs = []v2.MetricSpec{
 		createMetricSpec(5, metricName),
 		createMetricSpec(5, metricName),
 		createMetricSpec(3, metricName),
@@ -50,14 +53,14 @@ func TestTargetAverageValue(t *testing.T) {
 	assert.Equal(t, int64(4), targetAverageValue)
 }
 
-func createMetricSpec(averageValue int64, metricName string) v2beta2.MetricSpec {
+func createMetricSpec(averageValue int64, metricName string) v2.MetricSpec {
 	qty := resource.NewQuantity(averageValue, resource.DecimalSI)
-	return v2beta2.MetricSpec{
-		External: &v2beta2.ExternalMetricSource{
-			Target: v2beta2.MetricTarget{
+	return v2.MetricSpec{
+		External: &v2.ExternalMetricSource{
+			Target: v2.MetricTarget{
 				AverageValue: qty,
 			},
-			Metric: v2beta2.MetricIdentifier{
+			Metric: v2.MetricIdentifier{
 				Name: metricName,
 			},
 		},
@@ -73,7 +76,7 @@ func TestIsScaledJobActive(t *testing.T) {
 	scaledJobSingle := createScaledObject(100, "") // testing default = max
 	scalerSingle := []ScalerBuilder{{
 		Scaler: createScaler(ctrl, int64(20), int64(2), true, metricName),
-		Factory: func() (scalers.Scaler, error) {
+		Factory: func(context.Context) (scalers.Scaler, error) {
 			return createScaler(ctrl, int64(20), int64(2), true, metricName), nil
 		},
 	}}
@@ -93,7 +96,7 @@ func TestIsScaledJobActive(t *testing.T) {
 	// Non-Active trigger only
 	scalerSingle = []ScalerBuilder{{
 		Scaler: createScaler(ctrl, int64(0), int64(2), false, metricName),
-		Factory: func() (scalers.Scaler, error) {
+		Factory: func(context.Context) (scalers.Scaler, error) {
 			return createScaler(ctrl, int64(0), int64(2), false, metricName), nil
 		},
 	}}
@@ -123,22 +126,22 @@ func TestIsScaledJobActive(t *testing.T) {
 		scaledJob := createScaledObject(scalerTestData.MaxReplicaCount, scalerTestData.MultipleScalersCalculation)
 		scalersToTest := []ScalerBuilder{{
 			Scaler: createScaler(ctrl, scalerTestData.Scaler1QueueLength, scalerTestData.Scaler1AverageValue, scalerTestData.Scaler1IsActive, scalerTestData.MetricName),
-			Factory: func() (scalers.Scaler, error) {
+			Factory: func(context.Context) (scalers.Scaler, error) {
 				return createScaler(ctrl, scalerTestData.Scaler1QueueLength, scalerTestData.Scaler1AverageValue, scalerTestData.Scaler1IsActive, scalerTestData.MetricName), nil
 			},
 		}, {
 			Scaler: createScaler(ctrl, scalerTestData.Scaler2QueueLength, scalerTestData.Scaler2AverageValue, scalerTestData.Scaler2IsActive, scalerTestData.MetricName),
-			Factory: func() (scalers.Scaler, error) {
+			Factory: func(context.Context) (scalers.Scaler, error) {
 				return createScaler(ctrl, scalerTestData.Scaler2QueueLength, scalerTestData.Scaler2AverageValue, scalerTestData.Scaler2IsActive, scalerTestData.MetricName), nil
 			},
 		}, {
 			Scaler: createScaler(ctrl, scalerTestData.Scaler3QueueLength, scalerTestData.Scaler3AverageValue, scalerTestData.Scaler3IsActive, scalerTestData.MetricName),
-			Factory: func() (scalers.Scaler, error) {
+			Factory: func(context.Context) (scalers.Scaler, error) {
 				return createScaler(ctrl, scalerTestData.Scaler3QueueLength, scalerTestData.Scaler3AverageValue, scalerTestData.Scaler3IsActive, scalerTestData.MetricName), nil
 			},
 		}, {
 			Scaler: createScaler(ctrl, scalerTestData.Scaler4QueueLength, scalerTestData.Scaler4AverageValue, scalerTestData.Scaler4IsActive, scalerTestData.MetricName),
-			Factory: func() (scalers.Scaler, error) {
+			Factory: func(context.Context) (scalers.Scaler, error) {
 				return createScaler(ctrl, scalerTestData.Scaler4QueueLength, scalerTestData.Scaler4AverageValue, scalerTestData.Scaler4IsActive, scalerTestData.MetricName), nil
 			},
 		}}
@@ -158,6 +161,106 @@ func TestIsScaledJobActive(t *testing.T) {
 	}
 }
 
+func TestIsScaledObjectActiveMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	failure := errors.New("connection refused")
+	queue := mock_scalers.NewMockScaler(ctrl)
+	queue.EXPECT().IsActive(gomock.Any()).Return(true, nil)
+	queue.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(10, "s0-queue")}).AnyTimes()
+	queue.EXPECT().GetMetrics(gomock.Any(), "s0-queue", labels.Everything()).Return([]external_metrics.ExternalMetricValue{
+		{MetricName: "s0-queue", Value: *resource.NewQuantity(30, resource.DecimalSI)},
+		{MetricName: "s0-queue", Value: *resource.NewQuantity(12, resource.DecimalSI)},
+	}, nil)
+	cpu := mock_scalers.NewMockScaler(ctrl)
+	cpu.EXPECT().IsActive(gomock.Any()).Return(false, nil)
+	cpu.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{{Type: v2.ResourceMetricSourceType, Resource: &v2.ResourceMetricSource{Name: "cpu"}}})
+	// the failing trigger is queried once, its rebuilt scaler fails as well
+	lag := mock_scalers.NewMockScaler(ctrl)
+	lag.EXPECT().IsActive(gomock.Any()).Return(false, failure)
+	lag.EXPECT().Close(gomock.Any())
+	rebuiltLag := mock_scalers.NewMockScaler(ctrl)
+	rebuiltLag.EXPECT().IsActive(gomock.Any()).Return(false, failure)
+	rebuiltLag.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(5, "s2-lag")})
+	breaker := NewCircuitBreaker(DefaultCircuitBreakerConfig, "default", "test", "s2-lag")
+
+	cache := ScalersCache{
+		Scalers: []ScalerBuilder{
+			{Scaler: queue},
+			{Scaler: cpu},
+			{Scaler: lag, Breaker: breaker, Factory: func(context.Context) (scalers.Scaler, error) { return rebuiltLag, nil }},
+		},
+		Logger:   logr.Discard(),
+		Recorder: record.NewFakeRecorder(10),
+	}
+	scaledObject := &kedav1alpha1.ScaledObject{Spec: kedav1alpha1.ScaledObjectSpec{
+		ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "cluster", ReplicasPath: "spec.instances"},
+	}}
+
+	isActive, isError, metrics := cache.IsScaledObjectActive(context.TODO(), scaledObject)
+	assert.True(t, isActive)
+	assert.True(t, isError)
+	assert.Len(t, metrics, 3)
+	assert.NoError(t, metrics[0].Err)
+	assert.Equal(t, 42.0, metrics[0].Value)
+	assert.Error(t, metrics[1].Err)
+	assert.Equal(t, failure, metrics[2].Err)
+	assert.Equal(t, int32(1), *breaker.Status().ConsecutiveFailures)
+}
+
+func TestEvaluateScalers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Now()
+	failure := errors.New("connection refused")
+
+	activeScaler := mock_scalers.NewMockScaler(ctrl)
+	activeScaler.EXPECT().IsActive(gomock.Any()).Return(true, nil)
+	activeScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(2, "s0-queueLength")})
+	activeScaler.EXPECT().GetMetrics(gomock.Any(), "s0-queueLength", labels.Everything()).Return([]external_metrics.ExternalMetricValue{
+		{MetricName: "s0-queueLength", Value: *resource.NewQuantity(20, resource.DecimalSI)},
+	}, nil)
+
+	failingScaler := mock_scalers.NewMockScaler(ctrl)
+	failingScaler.EXPECT().IsActive(gomock.Any()).Return(false, failure)
+	failingScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(2, "s1-lag")})
+	failingScaler.EXPECT().GetMetrics(gomock.Any(), "s1-lag", labels.Everything()).Return(nil, failure)
+
+	// the scaler of an open circuit must not be called
+	openBreaker := newTestCircuitBreaker(&now)
+	openBreaker.RecordFailure(failure)
+	openBreaker.RecordFailure(failure)
+
+	// the live scalers are evaluated, they are neither rebuilt nor closed
+	factory := func(context.Context) (scalers.Scaler, error) {
+		t.Error("Expected the live scaler to be evaluated instead of a new one")
+		return nil, failure
+	}
+	builders := []ScalerBuilder{
+		{Scaler: activeScaler, Factory: factory, TriggerName: "queue", TriggerType: "rabbitmq", BuiltAt: now.Add(-time.Minute)},
+		{Scaler: failingScaler, Factory: factory, TriggerName: "s1-kafka", TriggerType: "kafka", BuiltAt: now},
+		{Scaler: mock_scalers.NewMockScaler(ctrl), Factory: factory, TriggerName: "s2-kafka", TriggerType: "kafka", BuiltAt: now, Breaker: openBreaker},
+	}
+
+	evaluations := EvaluateScalers(context.Background(), builders)
+	assert.Len(t, evaluations, 3)
+
+	assert.Equal(t, "queue", evaluations[0].Name)
+	assert.Equal(t, "rabbitmq", evaluations[0].Type)
+	assert.True(t, *evaluations[0].IsActive)
+	assert.Len(t, evaluations[0].MetricSpecs, 1)
+	assert.Len(t, evaluations[0].Metrics, 1)
+	assert.Equal(t, int64(20), evaluations[0].Metrics[0].Value.Value())
+	assert.Empty(t, evaluations[0].Errors)
+	assert.Equal(t, kedav1alpha1.CircuitBreakerClosed, evaluations[0].CircuitBreaker.State)
+
+	assert.Nil(t, evaluations[1].IsActive)
+	assert.Empty(t, evaluations[1].Metrics)
+	assert.Len(t, evaluations[1].Errors, 2)
+
+	assert.Nil(t, evaluations[2].IsActive)
+	assert.Equal(t, kedav1alpha1.CircuitBreakerOpen, evaluations[2].CircuitBreaker.State)
+	assert.Len(t, evaluations[2].Errors, 1)
+}
+
 func newScalerTestData(
 	metricName string,
 	maxReplicaCount int,
@@ -240,7 +343,7 @@ func createScaledObject(maxReplicaCount int32, multipleScalersCalculation string
 
 func createScaler(ctrl *gomock.Controller, queueLength int64, averageValue int64, isActive bool, metricName string) *mock_scalers.MockScaler {
 	scaler := mock_scalers.NewMockScaler(ctrl)
-	metricsSpecs := []v2beta2.MetricSpec{createMetricSpec(averageValue, metricName)}
+	metricsSpecs := []v2.MetricSpec{createMetricSpec(averageValue, metricName)}
 
 	metrics := []external_metrics.ExternalMetricValue{
 		{
@@ -254,3 +357,33 @@ func createScaler(ctrl *gomock.Controller, queueLength int64, averageValue int64
 	scaler.EXPECT().Close(gomock.Any())
 	return scaler
 }
+
+type contextKey struct{}
+
+func TestRebuildDependentScalersUsesTheContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	dependency := Dependency{Kind: "Secret", Namespace: "test", Name: "credentials"}
+	dependencies := &Dependencies{}
+	dependencies.Replace(map[Dependency]bool{dependency: true})
+
+	rebuilt := mock_scalers.NewMockScaler(ctrl)
+	cache := ScalersCache{
+		Scalers: []ScalerBuilder{{
+			Scaler: mock_scalers.NewMockScaler(ctrl),
+			// the secret sources are read with the context of the rebuild, not with the one of the first build
+			Factory: func(ctx context.Context) (scalers.Scaler, error) {
+				if ctx.Value(contextKey{}) != "rebuild" {
+					return nil, errors.New("unexpected context")
+				}
+				return rebuilt, nil
+			},
+			Dependencies: dependencies,
+			TriggerName:  "s0-test",
+		}},
+	}
+
+	rebuiltScalers, refreshed, err := cache.RebuildDependentScalers(context.WithValue(context.Background(), contextKey{}, "rebuild"), dependency)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"s0-test"}, refreshed)
+	assert.Same(t, rebuilt, rebuiltScalers[0])
+}
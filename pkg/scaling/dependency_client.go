@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+// dependencyRecordingClient records the Secrets, ConfigMaps, TriggerAuthentications and ClusterTriggerAuthentications read while
+// the scaler of a trigger is built, objects that don't exist are recorded as well, so their creation refreshes the scaler too.
+// The resolver records the HashiCorp Vault secrets read through RecordDependency.
+type dependencyRecordingClient struct {
+	client.Client
+
+	lock         sync.Mutex
+	dependencies map[cache.Dependency]bool
+}
+
+func newDependencyRecordingClient(c client.Client) *dependencyRecordingClient {
+	return &dependencyRecordingClient{
+		Client:       c,
+		dependencies: map[cache.Dependency]bool{},
+	}
+}
+
+func (c *dependencyRecordingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	if kind := dependencyKind(obj); kind != "" {
+		c.lock.Lock()
+		c.dependencies[cache.Dependency{Kind: kind, Namespace: key.Namespace, Name: key.Name}] = true
+		c.lock.Unlock()
+	}
+	return c.Client.Get(ctx, key, obj)
+}
+
+// RecordDependency records a dependency that isn't read with the client, like a HashiCorp Vault secret
+func (c *dependencyRecordingClient) RecordDependency(kind, namespace, name string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.dependencies[cache.Dependency{Kind: kind, Namespace: namespace, Name: name}] = true
+}
+
+// recorded returns the dependencies recorded so far
+func (c *dependencyRecordingClient) recorded() map[cache.Dependency]bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	result := make(map[cache.Dependency]bool, len(c.dependencies))
+	for dependency := range c.dependencies {
+		result[dependency] = true
+	}
+	return result
+}
+
+// dependencyKind returns the kind of the objects whose changes refresh the scalers built from them
+func dependencyKind(obj client.Object) string {
+	switch obj.(type) {
+	case *corev1.ConfigMap:
+		return "ConfigMap"
+	case *corev1.Secret:
+		return "Secret"
+	case *kedav1alpha1.TriggerAuthentication:
+		return "TriggerAuthentication"
+	case *kedav1alpha1.ClusterTriggerAuthentication:
+		return "ClusterTriggerAuthentication"
+	default:
+		return ""
+	}
+}
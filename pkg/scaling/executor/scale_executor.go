@@ -19,6 +19,9 @@ package executor
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,17 +32,44 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metrics"
+	"github.com/kedacore/keda/v2/pkg/scaling/hpa"
 )
 
-const (
-	// Default cooldown period for a ScaleTarget if no cooldownPeriod is defined on the scaledObject
-	defaultCooldownPeriod = 5 * 60 // 5 minutes
-)
+// defaultCooldownPeriod is the cooldown period of a ScaleTarget if no cooldownPeriod is defined on the scaledObject,
+// it is stored atomically as it can be changed while the scale loops run
+var defaultCooldownPeriod = int64(5 * time.Minute)
+
+// SetDefaultCooldownPeriod changes the cooldown period used for ScaledObjects without a cooldownPeriod
+func SetDefaultCooldownPeriod(period time.Duration) {
+	atomic.StoreInt64(&defaultCooldownPeriod, int64(period))
+}
 
-// ScaleExecutor contains methods RequestJobScale and RequestScale
+var metricsServer metrics.PrometheusMetricServer
+
+// ScaleExecutor contains methods RequestJobScale, RequestScale and RequestReplicas
 type ScaleExecutor interface {
 	RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64)
 	RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool)
+	RequestReplicas(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, metricValues []hpa.Metric)
+	GetLastDecision(kind, namespace, name string) (ScaleDecision, bool)
+	DeleteLastDecision(kind, namespace, name string)
+}
+
+// ScaleDecision describes the last scaling request handled for a ScaledObject or ScaledJob
+type ScaleDecision struct {
+	Time     time.Time `json:"time"`
+	IsActive bool      `json:"isActive"`
+	IsError  bool      `json:"isError"`
+	// Action is the scaling action performed on the scale target, empty if the replicas were not changed
+	Action string `json:"action,omitempty"`
+
+	// ScaledJob only
+	ScaleTo           int64 `json:"scaleTo,omitempty"`
+	MaxScale          int64 `json:"maxScale,omitempty"`
+	EffectiveMaxScale int64 `json:"effectiveMaxScale,omitempty"`
+	RunningJobs       int64 `json:"runningJobs,omitempty"`
+	PendingJobs       int64 `json:"pendingJobs,omitempty"`
 }
 
 type scaleExecutor struct {
@@ -48,23 +78,69 @@ type scaleExecutor struct {
 	reconcilerScheme *runtime.Scheme
 	logger           logr.Logger
 	recorder         record.EventRecorder
+	now              func() time.Time
+
+	// decisions holds the last ScaleDecision per scalable object, keyed by kind/namespace/name
+	decisions sync.Map
+	// autoscalers holds the hpa.Autoscaler per ScaledObject scaled through a replicasPath, keyed like decisions
+	autoscalers sync.Map
 }
 
 // NewScaleExecutor creates a ScaleExecutor object
 func NewScaleExecutor(client runtimeclient.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, recorder record.EventRecorder) ScaleExecutor {
+	return NewScaleExecutorWithClock(client, scaleClient, reconcilerScheme, recorder, time.Now)
+}
+
+// NewScaleExecutorWithClock creates a ScaleExecutor object that reads the current time from the passed clock,
+// it is used to replay scaling decisions over a simulated timeline
+func NewScaleExecutorWithClock(client runtimeclient.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, recorder record.EventRecorder, now func() time.Time) ScaleExecutor {
 	return &scaleExecutor{
 		client:           client,
 		scaleClient:      scaleClient,
 		reconcilerScheme: reconcilerScheme,
 		logger:           logf.Log.WithName("scaleexecutor"),
 		recorder:         recorder,
+		now:              now,
+	}
+}
+
+// GetLastDecision returns the last ScaleDecision for the scalable object, if it has been scaled since the operator started
+func (e *scaleExecutor) GetLastDecision(kind, namespace, name string) (ScaleDecision, bool) {
+	decision, ok := e.decisions.Load(decisionKey(kind, namespace, name))
+	if !ok {
+		return ScaleDecision{}, false
 	}
+	return decision.(ScaleDecision), true
+}
+
+// DeleteLastDecision forgets the last ScaleDecision for the scalable object, and the replica calculation state
+// of a ScaledObject scaled through a replicasPath
+func (e *scaleExecutor) DeleteLastDecision(kind, namespace, name string) {
+	e.decisions.Delete(decisionKey(kind, namespace, name))
+	e.autoscalers.Delete(decisionKey(kind, namespace, name))
+}
+
+func (e *scaleExecutor) storeDecision(kind, namespace, name string, decision ScaleDecision) {
+	e.decisions.Store(decisionKey(kind, namespace, name), decision)
+}
+
+// recordScalingAction records the scaling action in the metrics and in the last decision of the ScaledObject,
+// requests for the same ScaledObject are serialized by the scale handler so the decision can't be replaced concurrently
+func (e *scaleExecutor) recordScalingAction(scaledObject *kedav1alpha1.ScaledObject, action string) {
+	metricsServer.RecordScalingAction(scaledObject.Namespace, scaledObject.Name, action)
+	decision, _ := e.GetLastDecision("ScaledObject", scaledObject.Namespace, scaledObject.Name)
+	decision.Action = action
+	e.storeDecision("ScaledObject", scaledObject.Namespace, scaledObject.Name, decision)
+}
+
+func decisionKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
 }
 
 func (e *scaleExecutor) updateLastActiveTime(ctx context.Context, logger logr.Logger, object interface{}) error {
 	var patch runtimeclient.Patch
 
-	now := metav1.Now()
+	now := metav1.NewTime(e.now())
 	runtimeObj := object.(runtimeclient.Object)
 	switch obj := runtimeObj.(type) {
 	case *kedav1alpha1.ScaledObject:
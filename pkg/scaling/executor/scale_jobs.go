@@ -22,6 +22,8 @@ import (
 	"strconv"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,6 +32,7 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 	version "github.com/kedacore/keda/v2/version"
 )
 
@@ -39,12 +42,20 @@ const (
 )
 
 func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64) {
+	ctx, span := tracing.StartSpan(ctx, "ScaleExecutor.RequestJobScale", trace.WithAttributes(
+		attribute.Bool("keda.is_active", isActive),
+		attribute.Int64("keda.scale_to", scaleTo),
+		attribute.Int64("keda.max_scale", maxScale),
+	))
+	defer span.End()
+
 	logger := e.logger.WithValues("scaledJob.Name", scaledJob.Name, "scaledJob.Namespace", scaledJob.Namespace)
 
 	runningJobCount := e.getRunningJobCount(ctx, scaledJob)
 	pendingJobCount := e.getPendingJobCount(ctx, scaledJob)
 	logger.Info("Scaling Jobs", "Number of running Jobs", runningJobCount)
 	logger.Info("Scaling Jobs", "Number of pending Jobs ", pendingJobCount)
+	metricsServer.RecordScaledJobJobs(scaledJob.Namespace, scaledJob.Name, runningJobCount, pendingJobCount)
 
 	effectiveMaxScale := NewScalingStrategy(logger, scaledJob).GetEffectiveMaxScale(maxScale, runningJobCount, pendingJobCount, scaledJob.MaxReplicaCount())
 
@@ -52,9 +63,19 @@ func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1al
 		effectiveMaxScale = 0
 	}
 
+	e.storeDecision("ScaledJob", scaledJob.Namespace, scaledJob.Name, ScaleDecision{
+		Time:              e.now(),
+		IsActive:          isActive,
+		ScaleTo:           scaleTo,
+		MaxScale:          maxScale,
+		EffectiveMaxScale: effectiveMaxScale,
+		RunningJobs:       runningJobCount,
+		PendingJobs:       pendingJobCount,
+	})
+
 	if isActive {
 		logger.V(1).Info("At least one scaler is active")
-		now := metav1.Now()
+		now := metav1.NewTime(e.now())
 		scaledJob.Status.LastActiveTime = &now
 		err := e.updateLastActiveTime(ctx, logger, scaledJob)
 		if err != nil {
@@ -111,6 +132,7 @@ func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scal
 		labels[key] = value
 	}
 
+	created := int64(0)
 	for i := 0; i < int(scaleTo); i++ {
 		job := &batchv1.Job{
 			ObjectMeta: metav1.ObjectMeta{
@@ -137,8 +159,11 @@ func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scal
 		err = e.client.Create(ctx, job)
 		if err != nil {
 			logger.Error(err, "Failed to create a new Job")
+		} else {
+			created++
 		}
 	}
+	metricsServer.RecordScaledJobJobsCreated(scaledJob.Namespace, scaledJob.Name, created)
 	logger.Info("Created jobs", "Number of jobs", scaleTo)
 	e.recorder.Eventf(scaledJob, corev1.EventTypeNormal, eventreason.KEDAJobsCreated, "Created %d jobs", scaleTo)
 }
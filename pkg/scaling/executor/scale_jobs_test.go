@@ -262,6 +262,7 @@ func getMockScaleExecutor(client *mock_client.MockClient) *scaleExecutor {
 		scaleClient:      nil,
 		reconcilerScheme: nil,
 		logger:           logf.Log.WithName("scaleexecutor"),
+		now:              time.Now,
 	}
 }
 
@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/fallback"
+	"github.com/kedacore/keda/v2/pkg/metrics"
+	"github.com/kedacore/keda/v2/pkg/scaling/hpa"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// replicasPathFieldOwner is the field manager of the server-side apply patches of the replicasPath of scale targets
+	replicasPathFieldOwner = "keda-operator"
+	// replicasPathRoleLabel labels the ClusterRoles aggregated into the keda-operator-replicas-path ClusterRole,
+	// they grant KEDA the patch verb on the resources scaled through a replicasPath
+	replicasPathRoleLabel = "keda.sh/aggregate-to-replicas-path"
+)
+
+// RequestReplicas sets the replicas of a scale target scaled through its replicasPath to the count the trigger metrics propose.
+// No HPA can scale such targets, so KEDA does the job of the HPA once RequestScale activated the target.
+func (e *scaleExecutor) RequestReplicas(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, metricValues []hpa.Metric) {
+	logger := e.logger.WithValues("scaledobject.Name", scaledObject.Name,
+		"scaledObject.Namespace", scaledObject.Namespace,
+		"scaleTarget.Name", scaledObject.Spec.ScaleTargetRef.Name)
+
+	// paused ScaledObjects are kept at their replica count by RequestScale
+	if pausedCount, err := GetPausedReplicaCount(scaledObject); err != nil || pausedCount != nil {
+		return
+	}
+
+	target, err := e.getReplicasPathTarget(ctx, scaledObject)
+	if err != nil {
+		logger.Error(err, "Error getting information on the current Scale (ie. replicas count) on the scaleTarget")
+		return
+	}
+	currentReplicas, err := replicasFromPath(target, scaledObject.Spec.ScaleTargetRef.ReplicasPath)
+	if err != nil {
+		logger.Error(err, "Error getting information on the current Scale (ie. replicas count) on the scaleTarget")
+		return
+	}
+	if currentReplicas == 0 {
+		// like the HPA, scaling from zero is left to the activation by RequestScale
+		return
+	}
+
+	// the proposals are based on the observed replicas, replicas that aren't observed yet count as the desired ones
+	statusReplicas := currentReplicas
+	if path := scaledObject.Spec.ScaleTargetRef.StatusReplicasPath; path != "" {
+		replicas, found, err := kedautil.GetReplicasFromPath(target, path)
+		if err != nil {
+			logger.Error(err, "Error getting the observed replica count of the scaleTarget")
+			return
+		}
+		if found && replicas > 0 {
+			statusReplicas = replicas
+		}
+	}
+
+	var proposals []int32
+	invalidMetrics := 0
+	for _, metric := range metricValues {
+		if metric.Spec.External != nil {
+			// like the metrics server does for the HPA, failing metrics are replaced by the fallback replicas
+			metric = e.withFallback(ctx, scaledObject, metric)
+		}
+		if metric.Err != nil {
+			logger.V(1).Info("Ignoring invalid metric", "error", metric.Err.Error())
+			invalidMetrics++
+			continue
+		}
+		replicas, err := hpa.ReplicasForMetric(metric.Spec, metric.Value, statusReplicas)
+		if err != nil {
+			logger.Error(err, "Error calculating the replica count for metric")
+			invalidMetrics++
+			continue
+		}
+		proposals = append(proposals, replicas)
+	}
+
+	now := e.now()
+	minReplicas, maxReplicas := hpa.Limits(scaledObject)
+	behavior := hpa.Behavior(scaledObject)
+	autoscaler := e.getAutoscaler(scaledObject)
+	desiredReplicas, ok := autoscaler.DesiredReplicas(now, behavior, currentReplicas, minReplicas, maxReplicas, proposals, invalidMetrics)
+	if !ok {
+		logger.V(1).Info("ScaleTarget not scaled because some metrics are invalid")
+		return
+	}
+	if desiredReplicas == currentReplicas {
+		logger.V(1).Info("ScaleTarget no change")
+		return
+	}
+
+	if err := e.applyReplicas(ctx, scaledObject, desiredReplicas); err != nil {
+		logger.Error(err, "Error scaling the scaleTarget", "Original Replicas Count", currentReplicas, "New Replicas Count", desiredReplicas)
+		e.recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetScaleFailed, "Failed to scale %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, desiredReplicas)
+		return
+	}
+	autoscaler.RecordScale(now, behavior, currentReplicas, desiredReplicas)
+	logger.Info("Successfully scaled ScaleTarget", "Original Replicas Count", currentReplicas, "New Replicas Count", desiredReplicas)
+	e.recordScalingAction(scaledObject, metrics.ScalingActionScaled)
+	e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetScaled, "Scaled %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, desiredReplicas)
+}
+
+// withFallback records the health of the external metric in the status of the ScaledObject and returns the fallback value
+// instead of the error once the metric failed more often than the fallback failureThreshold allows
+func (e *scaleExecutor) withFallback(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, metric hpa.Metric) hpa.Metric {
+	values, err := fallback.GetMetricsWithFallback(ctx, e.client, nil, metric.Err, metric.Spec.External.Metric.Name, scaledObject, metric.Spec)
+	if metric.Err == nil || err != nil {
+		return metric
+	}
+	metric.Err = nil
+	metric.Value = 0
+	for _, value := range values {
+		metric.Value += value.Value.AsApproximateFloat64()
+	}
+	return metric
+}
+
+// getAutoscaler returns the replica calculation state of the ScaledObject, it is kept between the scale loops like the HPA controller does
+func (e *scaleExecutor) getAutoscaler(scaledObject *kedav1alpha1.ScaledObject) *hpa.Autoscaler {
+	autoscaler, _ := e.autoscalers.LoadOrStore(decisionKey("ScaledObject", scaledObject.Namespace, scaledObject.Name), &hpa.Autoscaler{})
+	return autoscaler.(*hpa.Autoscaler)
+}
+
+// getReplicasPathTarget reads the scale target of a ScaledObject with a replicasPath
+func (e *scaleExecutor) getReplicasPathTarget(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (*unstructured.Unstructured, error) {
+	if scaledObject.Status.ScaleTargetGVKR == nil {
+		return nil, fmt.Errorf("the scale target of ScaledObject %s/%s wasn't detected yet", scaledObject.Namespace, scaledObject.Name)
+	}
+	target := &unstructured.Unstructured{}
+	target.SetGroupVersionKind(scaledObject.Status.ScaleTargetGVKR.GroupVersionKind())
+	err := e.client.Get(ctx, client.ObjectKey{Name: scaledObject.Spec.ScaleTargetRef.Name, Namespace: scaledObject.Namespace}, target)
+	return target, err
+}
+
+// applyReplicas sets the replicasPath of the scale target with a server-side apply patch, so that KEDA owns only that field.
+// KEDA isn't allowed to patch arbitrary resources, a Forbidden error is reported in the Ready condition with the ClusterRole
+// to create, the condition is reset once a patch succeeds.
+func (e *scaleExecutor) applyReplicas(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, replicas int32) error {
+	gvkr := scaledObject.Status.ScaleTargetGVKR
+	if gvkr == nil {
+		return fmt.Errorf("the scale target of ScaledObject %s/%s wasn't detected yet", scaledObject.Namespace, scaledObject.Name)
+	}
+	patch := &unstructured.Unstructured{}
+	patch.SetGroupVersionKind(gvkr.GroupVersionKind())
+	patch.SetName(scaledObject.Spec.ScaleTargetRef.Name)
+	patch.SetNamespace(scaledObject.Namespace)
+	if err := kedautil.SetReplicasAtPath(patch, scaledObject.Spec.ScaleTargetRef.ReplicasPath, replicas); err != nil {
+		return err
+	}
+
+	logger := e.logger.WithValues("scaledobject.Name", scaledObject.Name, "scaledObject.Namespace", scaledObject.Namespace)
+	readyCondition := scaledObject.Status.Conditions.GetReadyCondition()
+	err := e.client.Patch(ctx, patch, client.Apply, client.FieldOwner(replicasPathFieldOwner), client.ForceOwnership)
+	switch {
+	case apierrors.IsForbidden(err):
+		msg := fmt.Sprintf("KEDA is not allowed to patch %s, grant the patch verb on it with a ClusterRole labeled %s: \"true\"", gvkr.GroupResource(), replicasPathRoleLabel)
+		if err := e.setReadyCondition(ctx, logger, scaledObject, metav1.ConditionFalse, kedav1alpha1.ScaledObjectConditionReadyReplicasPathForbiddenReason, msg); err != nil {
+			logger.Error(err, "error setting ready condition")
+		}
+	case err == nil && readyCondition.Reason == kedav1alpha1.ScaledObjectConditionReadyReplicasPathForbiddenReason:
+		if err := e.setReadyCondition(ctx, logger, scaledObject, metav1.ConditionTrue,
+			kedav1alpha1.ScaledObjectConditionReadySucccesReason, kedav1alpha1.ScaledObjectConditionReadySuccessMessage); err != nil {
+			logger.Error(err, "error setting ready condition")
+		}
+	}
+	return err
+}
+
+// replicasFromPath returns the replica count at the replicasPath of the scale target, an unset field counts as zero replicas
+func replicasFromPath(target *unstructured.Unstructured, path string) (int32, error) {
+	replicas, _, err := kedautil.GetReplicasFromPath(target, path)
+	return replicas, err
+}
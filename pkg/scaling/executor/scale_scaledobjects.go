@@ -19,9 +19,12 @@ package executor
 import (
 	"context"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -31,20 +34,43 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/metrics"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 )
 
 func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool) {
+	ctx, span := tracing.StartSpan(ctx, "ScaleExecutor.RequestScale", trace.WithAttributes(
+		attribute.Bool("keda.is_active", isActive),
+		attribute.Bool("keda.is_error", isError),
+	))
+	defer span.End()
+
 	logger := e.logger.WithValues("scaledobject.Name", scaledObject.Name,
 		"scaledObject.Namespace", scaledObject.Namespace,
 		"scaleTarget.Name", scaledObject.Spec.ScaleTargetRef.Name)
 
+	e.storeDecision("ScaledObject", scaledObject.Namespace, scaledObject.Name, ScaleDecision{
+		Time:     e.now(),
+		IsActive: isActive,
+		IsError:  isError,
+	})
+
 	// Get the current replica count. As a special case, Deployments and StatefulSets fetch directly from the object so they can use the informer cache
-	// to reduce API calls. Everything else uses the scale subresource.
+	// to reduce API calls. Targets with a replicasPath are read from that field, everything else uses the scale subresource.
 	var currentScale *autoscalingv1.Scale
 	var currentReplicas int32
 	targetName := scaledObject.Spec.ScaleTargetRef.Name
 	targetGVKR := scaledObject.Status.ScaleTargetGVKR
 	switch {
+	case scaledObject.Spec.ScaleTargetRef.ReplicasPath != "":
+		target, err := e.getReplicasPathTarget(ctx, scaledObject)
+		if err == nil {
+			currentReplicas, err = replicasFromPath(target, scaledObject.Spec.ScaleTargetRef.ReplicasPath)
+		}
+		if err != nil {
+			logger.Error(err, "Error getting information on the current Scale (ie. replicas count) on the scaleTarget")
+			return
+		}
 	case targetGVKR.Group == "apps" && targetGVKR.Kind == "Deployment":
 		deployment := &appsv1.Deployment{}
 		err := e.client.Get(ctx, client.ObjectKey{Name: targetName, Namespace: scaledObject.Namespace}, deployment)
@@ -73,8 +99,9 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 
 	// if the ScaledObject's triggers aren't in the error state,
 	// but ScaledObject.Status.ReadyCondition is set not set to 'true' -> set it back to 'true'
+	// a ReplicasPathForbidden reason is kept until a patch of the replicasPath succeeds
 	readyCondition := scaledObject.Status.Conditions.GetReadyCondition()
-	if !isError && !readyCondition.IsTrue() {
+	if !isError && !readyCondition.IsTrue() && readyCondition.Reason != kedav1alpha1.ScaledObjectConditionReadyReplicasPathForbiddenReason {
 		if err := e.setReadyCondition(ctx, logger, scaledObject, metav1.ConditionFalse,
 			kedav1alpha1.ScaledObjectConditionReadySucccesReason, kedav1alpha1.ScaledObjectConditionReadySuccessMessage); err != nil {
 			logger.Error(err, "error setting ready condition")
@@ -112,6 +139,8 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 				return
 			}
 			logger.Info("Successfully scaled target to paused replicas count", "paused replicas", *pausedCount)
+			e.recordScalingAction(scaledObject, metrics.ScalingActionPaused)
+			e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetPaused, "Paused %s %s/%s at %d replicas", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, *pausedCount)
 		}
 		return
 	}
@@ -236,6 +265,7 @@ func (e *scaleExecutor) doFallbackScaling(ctx context.Context, scaledObject *ked
 		logger.Info("Successfully set ScaleTarget replicas count to ScaledObject fallback.replicas",
 			"Original Replicas Count", currentReplicas,
 			"New Replicas Count", scaledObject.Spec.Fallback.Replicas)
+		e.recordScalingAction(scaledObject, metrics.ScalingActionFallback)
 	}
 	if e := e.setFallbackCondition(ctx, logger, scaledObject, metav1.ConditionTrue, "FallbackExists", "At least one trigger is falling back on this scaled object"); e != nil {
 		logger.Error(e, "Error setting fallback condition")
@@ -250,13 +280,13 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 	if scaledObject.Spec.CooldownPeriod != nil {
 		cooldownPeriod = time.Second * time.Duration(*scaledObject.Spec.CooldownPeriod)
 	} else {
-		cooldownPeriod = time.Second * time.Duration(defaultCooldownPeriod)
+		cooldownPeriod = time.Duration(atomic.LoadInt64(&defaultCooldownPeriod))
 	}
 
 	// LastActiveTime can be nil if the ScaleTarget was scaled outside of KEDA.
 	// In this case we will ignore the cooldown period and scale it down
 	if scaledObject.Status.LastActiveTime == nil ||
-		scaledObject.Status.LastActiveTime.Add(cooldownPeriod).Before(time.Now()) {
+		scaledObject.Status.LastActiveTime.Add(cooldownPeriod).Before(e.now()) {
 		// or last time a trigger was active was > cooldown period, so scale down.
 
 		idleValue, scaleToReplicas := getIdleOrMinimumReplicaCount(scaledObject)
@@ -270,6 +300,7 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 				msg += " minReplicaCount"
 			}
 			logger.Info(msg, "Original Replicas Count", currentReplicas, "New Replicas Count", scaleToReplicas)
+			e.recordScalingAction(scaledObject, metrics.ScalingActionDeactivated)
 
 			e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetDeactivated,
 				"Deactivated %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, scaleToReplicas)
@@ -310,6 +341,7 @@ func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Log
 		logger.Info("Successfully updated ScaleTarget",
 			"Original Replicas Count", currentReplicas,
 			"New Replicas Count", replicas)
+		e.recordScalingAction(scaledObject, metrics.ScalingActionActivated)
 		e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "Scaled %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas)
 
 		// Scale was successful. Update lastScaleTime and lastActiveTime on the scaledObject
@@ -327,6 +359,18 @@ func (e *scaleExecutor) getScaleTargetScale(ctx context.Context, scaledObject *k
 }
 
 func (e *scaleExecutor) updateScaleOnScaleTarget(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, replicas int32) (int32, error) {
+	if scaledObject.Spec.ScaleTargetRef.ReplicasPath != "" {
+		target, err := e.getReplicasPathTarget(ctx, scaledObject)
+		if err != nil {
+			return -1, err
+		}
+		currentReplicas, err := replicasFromPath(target, scaledObject.Spec.ScaleTargetRef.ReplicasPath)
+		if err != nil {
+			return -1, err
+		}
+		return currentReplicas, e.applyReplicas(ctx, scaledObject, replicas)
+	}
+
 	if scale == nil {
 		// Wasn't retrieved earlier, grab it now.
 		var err error
@@ -18,18 +18,27 @@ package executor
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/record"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metrics"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_scale"
+	"github.com/kedacore/keda/v2/pkg/scaling/hpa"
 )
 
 func TestScaleToFallbackReplicasWhenNotActiveAndIsError(t *testing.T) {
@@ -92,6 +101,12 @@ func TestScaleToFallbackReplicasWhenNotActiveAndIsError(t *testing.T) {
 	assert.Equal(t, int32(5), scale.Spec.Replicas)
 	condition := scaledObject.Status.Conditions.GetFallbackCondition()
 	assert.Equal(t, true, condition.IsTrue())
+
+	decision, ok := scaleExecutor.GetLastDecision("ScaledObject", "namespace", "name")
+	assert.True(t, ok)
+	assert.False(t, decision.IsActive)
+	assert.True(t, decision.IsError)
+	assert.Equal(t, metrics.ScalingActionFallback, decision.Action)
 }
 
 func TestScaleToMinReplicasWhenNotActive(t *testing.T) {
@@ -460,3 +475,185 @@ func TestScaleToPausedReplicasCount(t *testing.T) {
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
 	assert.Equal(t, false, condition.IsTrue())
 }
+
+func replicasPathScaledObject() *v1alpha1.ScaledObject {
+	scaledObject := &v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name:               "name",
+				APIVersion:         "example.com/v1",
+				Kind:               "Database",
+				ReplicasPath:       ".spec.cluster.instances",
+				StatusReplicasPath: ".status.instances",
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group:    "example.com",
+				Version:  "v1",
+				Kind:     "Database",
+				Resource: "databases",
+			},
+		},
+	}
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+	return scaledObject
+}
+
+func expectReplicasPathTarget(client *mock_client.MockClient, replicas int64) {
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ runtimeclient.ObjectKey, obj runtimeclient.Object) error {
+		target := obj.(*unstructured.Unstructured)
+		target.Object["spec"] = map[string]interface{}{"cluster": map[string]interface{}{"instances": replicas}}
+		target.Object["status"] = map[string]interface{}{"instances": replicas}
+		return nil
+	}).AnyTimes()
+}
+
+func TestScaleFromZeroThroughReplicasPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, nil, nil, recorder)
+	scaledObject := replicasPathScaledObject()
+
+	expectReplicasPathTarget(client, 0)
+	var patched *unstructured.Unstructured
+	client.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Eq(runtimeclient.Apply), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, obj runtimeclient.Object, _ runtimeclient.Patch, _ ...runtimeclient.PatchOption) error {
+			patched = obj.(*unstructured.Unstructured)
+			return nil
+		})
+	client.EXPECT().Status().AnyTimes().Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	scaleExecutor.RequestScale(context.TODO(), scaledObject, true, false)
+
+	assert.NotNil(t, patched)
+	assert.Equal(t, "example.com/v1", patched.GetAPIVersion())
+	assert.Equal(t, "Database", patched.GetKind())
+	assert.Equal(t, "name", patched.GetName())
+	replicas, _, _ := unstructured.NestedInt64(patched.Object, "spec", "cluster", "instances")
+	assert.Equal(t, int64(1), replicas)
+
+	decision, ok := scaleExecutor.GetLastDecision("ScaledObject", "namespace", "name")
+	assert.True(t, ok)
+	assert.Equal(t, metrics.ScalingActionActivated, decision.Action)
+}
+
+func queueMetric(value float64) hpa.Metric {
+	return hpa.Metric{
+		Spec: autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ExternalMetricSourceType,
+			External: &autoscalingv2.ExternalMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: "s0-queue"},
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: resource.NewQuantity(10, resource.DecimalSI)},
+			},
+		},
+		Value: value,
+	}
+}
+
+func TestRequestReplicasThroughReplicasPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, nil, nil, recorder)
+	scaledObject := replicasPathScaledObject()
+
+	expectReplicasPathTarget(client, 2)
+	var patched *unstructured.Unstructured
+	client.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Eq(runtimeclient.Apply), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, obj runtimeclient.Object, _ runtimeclient.Patch, _ ...runtimeclient.PatchOption) error {
+			patched = obj.(*unstructured.Unstructured)
+			return nil
+		})
+	// the health of the metrics is recorded in the status
+	client.EXPECT().Status().AnyTimes().Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	queue := queueMetric(55)
+	scaleExecutor.RequestReplicas(context.TODO(), scaledObject, []hpa.Metric{queue})
+
+	// without a behavior the HPA at most doubles the replicas, but scales to 4 at least
+	assert.NotNil(t, patched)
+	replicas, _, _ := unstructured.NestedInt64(patched.Object, "spec", "cluster", "instances")
+	assert.Equal(t, int64(4), replicas)
+
+	// an invalid metric doesn't let the HPA scale down
+	patched = nil
+	queue.Value = 5
+	scaleExecutor.RequestReplicas(context.TODO(), scaledObject, []hpa.Metric{queue, {Spec: queue.Spec, Err: fmt.Errorf("unavailable")}})
+	assert.Nil(t, patched)
+}
+
+func TestRequestReplicasFallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(10)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, nil, nil, recorder)
+	scaledObject := replicasPathScaledObject()
+	scaledObject.Spec.Fallback = &v1alpha1.Fallback{FailureThreshold: 1, Replicas: 6}
+
+	expectReplicasPathTarget(client, 4)
+	var patched *unstructured.Unstructured
+	client.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Eq(runtimeclient.Apply), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, obj runtimeclient.Object, _ runtimeclient.Patch, _ ...runtimeclient.PatchOption) error {
+			patched = obj.(*unstructured.Unstructured)
+			return nil
+		}).AnyTimes()
+	client.EXPECT().Status().AnyTimes().Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	failing := queueMetric(0)
+	failing.Err = fmt.Errorf("unavailable")
+
+	// below the failureThreshold the failing metric is invalid
+	scaleExecutor.RequestReplicas(context.TODO(), scaledObject, []hpa.Metric{failing})
+	assert.Nil(t, patched)
+	condition := scaledObject.Status.Conditions.GetFallbackCondition()
+	assert.False(t, condition.IsTrue())
+
+	scaleExecutor.RequestReplicas(context.TODO(), scaledObject, []hpa.Metric{failing})
+	assert.NotNil(t, patched)
+	replicas, _, _ := unstructured.NestedInt64(patched.Object, "spec", "cluster", "instances")
+	assert.Equal(t, int64(6), replicas)
+
+	// like for the metrics server, the condition follows the health recorded by the previous checks
+	scaleExecutor.RequestReplicas(context.TODO(), scaledObject, []hpa.Metric{failing})
+	condition = scaledObject.Status.Conditions.GetFallbackCondition()
+	assert.True(t, condition.IsTrue())
+	assert.Equal(t, int32(3), *scaledObject.Status.Health["s0-queue"].NumberOfFailures)
+}
+
+func TestReplicasPathForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, nil, nil, recorder)
+	scaledObject := replicasPathScaledObject()
+
+	expectReplicasPathTarget(client, 0)
+	client.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Eq(runtimeclient.Apply), gomock.Any(), gomock.Any()).
+		Return(apierrors.NewForbidden(schema.GroupResource{Group: "example.com", Resource: "databases"}, "name", fmt.Errorf("no patch verb")))
+	client.EXPECT().Status().AnyTimes().Return(statusWriter)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	scaleExecutor.RequestScale(context.TODO(), scaledObject, true, false)
+
+	condition := scaledObject.Status.Conditions.GetReadyCondition()
+	assert.True(t, condition.IsFalse())
+	assert.Equal(t, v1alpha1.ScaledObjectConditionReadyReplicasPathForbiddenReason, condition.Reason)
+	assert.Contains(t, condition.Message, "keda.sh/aggregate-to-replicas-path")
+}
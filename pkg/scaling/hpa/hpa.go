@@ -0,0 +1,411 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hpa calculates replica counts like the horizontal pod autoscaler controller of Kubernetes v1.23
+// with its default flags, assuming all pods of the scale target are ready.
+package hpa
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	v2 "k8s.io/api/autoscaling/v2"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const (
+	hpaTolerance                        = 0.1
+	defaultDownscaleStabilisationWindow = 5 * time.Minute
+
+	// DefaultMinReplicas is the minReplicas of the HPA of a ScaledObject without a minReplicaCount above zero
+	DefaultMinReplicas = int32(1)
+	// DefaultMaxReplicas is the maxReplicas of the HPA of a ScaledObject without a maxReplicaCount
+	DefaultMaxReplicas = int32(100)
+)
+
+// Metric is a metric of a ScaledObject with the value KEDA read for it, Err is set if the value couldn't be read
+type Metric struct {
+	Spec  v2.MetricSpec
+	Value float64
+	Err   error
+}
+
+type timestampedRecommendation struct {
+	recommendation int32
+	timestamp      time.Time
+}
+
+type timestampedScaleEvent struct {
+	replicaChange int32
+	timestamp     time.Time
+	outdated      bool
+}
+
+// Autoscaler holds the state the HPA controller keeps between the syncs of the HPA of a ScaledObject
+type Autoscaler struct {
+	recommendations []timestampedRecommendation
+	scaleUpEvents   []timestampedScaleEvent
+	scaleDownEvents []timestampedScaleEvent
+}
+
+// Limits returns the minReplicas and maxReplicas of the HPA, like the ScaledObject controller sets them
+// for a ScaledObject that isn't paused
+func Limits(scaledObject *kedav1alpha1.ScaledObject) (int32, int32) {
+	minReplicas := DefaultMinReplicas
+	if scaledObject.Spec.MinReplicaCount != nil && *scaledObject.Spec.MinReplicaCount > 0 {
+		minReplicas = *scaledObject.Spec.MinReplicaCount
+	}
+	maxReplicas := DefaultMaxReplicas
+	if scaledObject.Spec.MaxReplicaCount != nil {
+		maxReplicas = *scaledObject.Spec.MaxReplicaCount
+	}
+	return minReplicas, maxReplicas
+}
+
+// Behavior returns the behavior of the HPA with the defaults the API server and the HPA controller fill in,
+// or nil if the ScaledObject doesn't configure one
+func Behavior(scaledObject *kedav1alpha1.ScaledObject) *v2.HorizontalPodAutoscalerBehavior {
+	if scaledObject.Spec.Advanced == nil || scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig == nil ||
+		scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig.Behavior == nil {
+		return nil
+	}
+	behavior := scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig.Behavior.DeepCopy()
+
+	scaleUpStabilization := int32(0)
+	behavior.ScaleUp = defaultScalingRules(behavior.ScaleUp, scaleUpStabilization, []v2.HPAScalingPolicy{
+		{Type: v2.PodsScalingPolicy, Value: 4, PeriodSeconds: 15},
+		{Type: v2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+	})
+	scaleDownStabilization := int32(defaultDownscaleStabilisationWindow / time.Second)
+	behavior.ScaleDown = defaultScalingRules(behavior.ScaleDown, scaleDownStabilization, []v2.HPAScalingPolicy{
+		{Type: v2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+	})
+	return behavior
+}
+
+func defaultScalingRules(rules *v2.HPAScalingRules, stabilization int32, policies []v2.HPAScalingPolicy) *v2.HPAScalingRules {
+	if rules == nil {
+		rules = &v2.HPAScalingRules{}
+	}
+	if rules.StabilizationWindowSeconds == nil {
+		rules.StabilizationWindowSeconds = &stabilization
+	}
+	if rules.SelectPolicy == nil {
+		selectPolicy := v2.MaxChangePolicySelect
+		rules.SelectPolicy = &selectPolicy
+	}
+	if len(rules.Policies) == 0 {
+		rules.Policies = policies
+	}
+	return rules
+}
+
+// ReplicasForMetric returns the replica count the metric value proposes for its target
+func ReplicasForMetric(spec v2.MetricSpec, value float64, currentReplicas int32) (int32, error) {
+	var target v2.MetricTarget
+	switch {
+	case spec.External != nil:
+		target = spec.External.Target
+	case spec.Resource != nil:
+		target = spec.Resource.Target
+	default:
+		return 0, fmt.Errorf("unsupported metric source type %s", spec.Type)
+	}
+
+	switch {
+	case target.Type == v2.AverageValueMetricType && spec.External != nil && target.AverageValue != nil:
+		targetValue := target.AverageValue.AsApproximateFloat64()
+		usageRatio := value / (targetValue * float64(currentReplicas))
+		if math.Abs(1.0-usageRatio) <= hpaTolerance {
+			return currentReplicas, nil
+		}
+		return int32(math.Ceil(value / targetValue)), nil
+	case target.Type == v2.AverageValueMetricType && target.AverageValue != nil:
+		return replicasForUsageRatio(value/target.AverageValue.AsApproximateFloat64(), currentReplicas), nil
+	case target.Type == v2.ValueMetricType && target.Value != nil:
+		return replicasForUsageRatio(value/target.Value.AsApproximateFloat64(), currentReplicas), nil
+	case target.Type == v2.UtilizationMetricType && target.AverageUtilization != nil:
+		return replicasForUsageRatio(value/float64(*target.AverageUtilization), currentReplicas), nil
+	default:
+		return 0, fmt.Errorf("unsupported metric target type %s", target.Type)
+	}
+}
+
+func replicasForUsageRatio(usageRatio float64, currentReplicas int32) int32 {
+	if math.Abs(1.0-usageRatio) <= hpaTolerance {
+		return currentReplicas
+	}
+	return int32(math.Ceil(usageRatio * float64(currentReplicas)))
+}
+
+// DesiredReplicas returns the replica count of the next HPA sync. proposals holds the replica counts proposed
+// by the valid metrics, invalidMetrics the number of metrics that couldn't be read.
+// It returns false when the HPA doesn't scale because of invalid metrics.
+func (h *Autoscaler) DesiredReplicas(now time.Time, behavior *v2.HorizontalPodAutoscalerBehavior, currentReplicas, minReplicas, maxReplicas int32, proposals []int32, invalidMetrics int) (int32, bool) {
+	switch {
+	case currentReplicas > maxReplicas:
+		return maxReplicas, true
+	case currentReplicas < minReplicas:
+		return minReplicas, true
+	}
+
+	proposed := int32(0)
+	for _, replicas := range proposals {
+		if replicas > proposed {
+			proposed = replicas
+		}
+	}
+	// the HPA doesn't scale if all metrics are invalid, or if some are invalid and the others would scale down
+	if len(proposals) == 0 || (invalidMetrics > 0 && proposed < currentReplicas) {
+		return currentReplicas, false
+	}
+
+	if behavior == nil {
+		stabilized := h.stabilizeRecommendation(now, proposed)
+		return convertDesiredReplicasWithRules(currentReplicas, stabilized, minReplicas, maxReplicas), true
+	}
+	stabilized := h.stabilizeRecommendationWithBehaviors(now, behavior, currentReplicas, proposed)
+	return h.convertDesiredReplicasWithBehaviorRate(now, behavior, currentReplicas, stabilized, minReplicas, maxReplicas), true
+}
+
+// RecordScale stores a scaling of the HPA, the scaling policies of the behavior limit the next changes by it
+func (h *Autoscaler) RecordScale(now time.Time, behavior *v2.HorizontalPodAutoscalerBehavior, previousReplicas, newReplicas int32) {
+	if behavior == nil {
+		return
+	}
+	if newReplicas > previousReplicas {
+		h.scaleUpEvents = storeScaleEvent(now, h.scaleUpEvents, behavior.ScaleUp, newReplicas-previousReplicas)
+	} else {
+		h.scaleDownEvents = storeScaleEvent(now, h.scaleDownEvents, behavior.ScaleDown, previousReplicas-newReplicas)
+	}
+}
+
+func (h *Autoscaler) stabilizeRecommendation(now time.Time, prenormalizedDesiredReplicas int32) int32 {
+	maxRecommendation := prenormalizedDesiredReplicas
+	foundOldSample := false
+	oldSampleIndex := 0
+	cutoff := now.Add(-defaultDownscaleStabilisationWindow)
+	for i, rec := range h.recommendations {
+		if rec.timestamp.Before(cutoff) {
+			foundOldSample = true
+			oldSampleIndex = i
+		} else if rec.recommendation > maxRecommendation {
+			maxRecommendation = rec.recommendation
+		}
+	}
+	h.storeRecommendation(now, prenormalizedDesiredReplicas, foundOldSample, oldSampleIndex)
+	return maxRecommendation
+}
+
+func (h *Autoscaler) stabilizeRecommendationWithBehaviors(now time.Time, behavior *v2.HorizontalPodAutoscalerBehavior, currentReplicas, desiredReplicas int32) int32 {
+	upDelay := time.Second * time.Duration(*behavior.ScaleUp.StabilizationWindowSeconds)
+	downDelay := time.Second * time.Duration(*behavior.ScaleDown.StabilizationWindowSeconds)
+	maxDelay := upDelay
+	if downDelay > maxDelay {
+		maxDelay = downDelay
+	}
+
+	upRecommendation := desiredReplicas
+	downRecommendation := desiredReplicas
+	foundOldSample := false
+	oldSampleIndex := 0
+	for i, rec := range h.recommendations {
+		if rec.timestamp.After(now.Add(-upDelay)) && rec.recommendation < upRecommendation {
+			upRecommendation = rec.recommendation
+		}
+		if rec.timestamp.After(now.Add(-downDelay)) && rec.recommendation > downRecommendation {
+			downRecommendation = rec.recommendation
+		}
+		if rec.timestamp.Before(now.Add(-maxDelay)) {
+			foundOldSample = true
+			oldSampleIndex = i
+		}
+	}
+
+	recommendation := currentReplicas
+	if recommendation < upRecommendation {
+		recommendation = upRecommendation
+	}
+	if recommendation > downRecommendation {
+		recommendation = downRecommendation
+	}
+	h.storeRecommendation(now, desiredReplicas, foundOldSample, oldSampleIndex)
+	return recommendation
+}
+
+func (h *Autoscaler) storeRecommendation(now time.Time, recommendation int32, replace bool, index int) {
+	rec := timestampedRecommendation{recommendation: recommendation, timestamp: now}
+	if replace {
+		h.recommendations[index] = rec
+	} else {
+		h.recommendations = append(h.recommendations, rec)
+	}
+}
+
+func convertDesiredReplicasWithRules(currentReplicas, desiredReplicas, minReplicas, maxReplicas int32) int32 {
+	scaleUpLimit := currentReplicas * 2
+	if scaleUpLimit < 4 {
+		scaleUpLimit = 4
+	}
+	maximumAllowedReplicas := maxReplicas
+	if maximumAllowedReplicas > scaleUpLimit {
+		maximumAllowedReplicas = scaleUpLimit
+	}
+
+	switch {
+	case desiredReplicas < minReplicas:
+		return minReplicas
+	case desiredReplicas > maximumAllowedReplicas:
+		return maximumAllowedReplicas
+	default:
+		return desiredReplicas
+	}
+}
+
+func (h *Autoscaler) convertDesiredReplicasWithBehaviorRate(now time.Time, behavior *v2.HorizontalPodAutoscalerBehavior, currentReplicas, desiredReplicas, minReplicas, maxReplicas int32) int32 {
+	switch {
+	case desiredReplicas > currentReplicas:
+		scaleUpLimit := scaleUpLimit(now, currentReplicas, h.scaleUpEvents, behavior.ScaleUp)
+		if scaleUpLimit < currentReplicas {
+			// the scale up limit can't be below the current replica count
+			scaleUpLimit = currentReplicas
+		}
+		maximumAllowedReplicas := maxReplicas
+		if maximumAllowedReplicas > scaleUpLimit {
+			maximumAllowedReplicas = scaleUpLimit
+		}
+		if desiredReplicas > maximumAllowedReplicas {
+			return maximumAllowedReplicas
+		}
+	case desiredReplicas < currentReplicas:
+		scaleDownLimit := scaleDownLimit(now, currentReplicas, h.scaleDownEvents, behavior.ScaleDown)
+		if scaleDownLimit > currentReplicas {
+			// the scale down limit can't be above the current replica count
+			scaleDownLimit = currentReplicas
+		}
+		minimumAllowedReplicas := minReplicas
+		if minimumAllowedReplicas < scaleDownLimit {
+			minimumAllowedReplicas = scaleDownLimit
+		}
+		if desiredReplicas < minimumAllowedReplicas {
+			return minimumAllowedReplicas
+		}
+	}
+	return desiredReplicas
+}
+
+func scaleUpLimit(now time.Time, currentReplicas int32, events []timestampedScaleEvent, rules *v2.HPAScalingRules) int32 {
+	var result int32
+	var selectPolicy func(int32, int32) int32
+	switch *rules.SelectPolicy {
+	case v2.DisabledPolicySelect:
+		return currentReplicas
+	case v2.MinChangePolicySelect:
+		result = math.MaxInt32
+		selectPolicy = minInt32
+	default:
+		result = math.MinInt32
+		selectPolicy = maxInt32
+	}
+	for _, policy := range rules.Policies {
+		periodStartReplicas := currentReplicas - replicasChangePerPeriod(now, policy.PeriodSeconds, events)
+		var proposed int32
+		if policy.Type == v2.PodsScalingPolicy {
+			proposed = periodStartReplicas + policy.Value
+		} else {
+			proposed = int32(math.Ceil(float64(periodStartReplicas) * (1 + float64(policy.Value)/100)))
+		}
+		result = selectPolicy(result, proposed)
+	}
+	return result
+}
+
+func scaleDownLimit(now time.Time, currentReplicas int32, events []timestampedScaleEvent, rules *v2.HPAScalingRules) int32 {
+	var result int32
+	var selectPolicy func(int32, int32) int32
+	switch *rules.SelectPolicy {
+	case v2.DisabledPolicySelect:
+		return currentReplicas
+	case v2.MinChangePolicySelect:
+		result = math.MinInt32
+		selectPolicy = maxInt32
+	default:
+		result = math.MaxInt32
+		selectPolicy = minInt32
+	}
+	for _, policy := range rules.Policies {
+		periodStartReplicas := currentReplicas + replicasChangePerPeriod(now, policy.PeriodSeconds, events)
+		var proposed int32
+		if policy.Type == v2.PodsScalingPolicy {
+			proposed = periodStartReplicas - policy.Value
+		} else {
+			proposed = int32(float64(periodStartReplicas) * (1 - float64(policy.Value)/100))
+		}
+		result = selectPolicy(result, proposed)
+	}
+	return result
+}
+
+func replicasChangePerPeriod(now time.Time, periodSeconds int32, events []timestampedScaleEvent) int32 {
+	cutoff := now.Add(-time.Second * time.Duration(periodSeconds))
+	var change int32
+	for _, event := range events {
+		if event.timestamp.After(cutoff) && !event.outdated {
+			change += event.replicaChange
+		}
+	}
+	return change
+}
+
+func storeScaleEvent(now time.Time, events []timestampedScaleEvent, rules *v2.HPAScalingRules, replicaChange int32) []timestampedScaleEvent {
+	longestPeriod := int32(0)
+	for _, policy := range rules.Policies {
+		if policy.PeriodSeconds > longestPeriod {
+			longestPeriod = policy.PeriodSeconds
+		}
+	}
+	cutoff := now.Add(-time.Second * time.Duration(longestPeriod))
+	for i := range events {
+		if events[i].timestamp.Before(cutoff) {
+			events[i].outdated = true
+		}
+	}
+
+	event := timestampedScaleEvent{replicaChange: replicaChange, timestamp: now}
+	for i := range events {
+		if events[i].outdated {
+			events[i] = event
+			return events
+		}
+	}
+	return append(events, event)
+}
+
+func minInt32(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt32(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,87 @@
+package hpa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func int32Ptr(value int32) *int32 {
+	return &value
+}
+
+func TestReplicasForMetric(t *testing.T) {
+	external := v2.MetricSpec{
+		Type: v2.ExternalMetricSourceType,
+		External: &v2.ExternalMetricSource{
+			Metric: v2.MetricIdentifier{Name: "s0-queue"},
+			Target: v2.MetricTarget{Type: v2.AverageValueMetricType, AverageValue: resource.NewQuantity(10, resource.DecimalSI)},
+		},
+	}
+	utilization := v2.MetricSpec{
+		Type: v2.ResourceMetricSourceType,
+		Resource: &v2.ResourceMetricSource{
+			Name:   "cpu",
+			Target: v2.MetricTarget{Type: v2.UtilizationMetricType, AverageUtilization: int32Ptr(50)},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		spec     v2.MetricSpec
+		value    float64
+		current  int32
+		expected int32
+	}{
+		{name: "average value", spec: external, value: 95, current: 4, expected: 10},
+		{name: "average value within tolerance", spec: external, value: 43, current: 4, expected: 4},
+		{name: "utilization", spec: utilization, value: 90, current: 4, expected: 8},
+		{name: "utilization within tolerance", spec: utilization, value: 54, current: 4, expected: 4},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			replicas, err := ReplicasForMetric(test.spec, test.value, test.current)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, replicas)
+		})
+	}
+}
+
+func TestDesiredReplicasWithBehavior(t *testing.T) {
+	now := time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+	selectPolicy := v2.MaxChangePolicySelect
+	behavior := &v2.HorizontalPodAutoscalerBehavior{
+		ScaleUp: &v2.HPAScalingRules{
+			StabilizationWindowSeconds: int32Ptr(0),
+			SelectPolicy:               &selectPolicy,
+			Policies:                   []v2.HPAScalingPolicy{{Type: v2.PodsScalingPolicy, Value: 2, PeriodSeconds: 60}},
+		},
+		ScaleDown: &v2.HPAScalingRules{
+			StabilizationWindowSeconds: int32Ptr(0),
+			SelectPolicy:               &selectPolicy,
+			Policies:                   []v2.HPAScalingPolicy{{Type: v2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15}},
+		},
+	}
+
+	autoscaler := &Autoscaler{}
+	desired, ok := autoscaler.DesiredReplicas(now, behavior, 2, 1, 10, []int32{8}, 0)
+	assert.True(t, ok)
+	assert.Equal(t, int32(4), desired)
+	autoscaler.RecordScale(now, behavior, 2, desired)
+
+	// the scale up of the last minute counts against the policy
+	desired, ok = autoscaler.DesiredReplicas(now.Add(30*time.Second), behavior, 4, 1, 10, []int32{8}, 0)
+	assert.True(t, ok)
+	assert.Equal(t, int32(4), desired)
+
+	desired, ok = autoscaler.DesiredReplicas(now.Add(90*time.Second), behavior, 4, 1, 10, []int32{8}, 0)
+	assert.True(t, ok)
+	assert.Equal(t, int32(6), desired)
+
+	// invalid metrics prevent a scale down
+	_, ok = autoscaler.DesiredReplicas(now.Add(2*time.Minute), behavior, 6, 1, 10, []int32{2}, 1)
+	assert.False(t, ok)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AuthSource is the part of a TriggerAuthentication spec a parameter is resolved from
+type AuthSource string
+
+const (
+	AuthSourceEnv               AuthSource = "env"
+	AuthSourceSecret            AuthSource = "secretTargetRef"
+	AuthSourceConfigMap         AuthSource = "configMapTargetRef"
+	AuthSourceHashiCorpVault    AuthSource = "hashiCorpVault"
+	AuthSourceAzureKeyVault     AuthSource = "azureKeyVault"
+	AuthSourceAwsSecretManager  AuthSource = "awsSecretManager"
+	AuthSourceAwsParameterStore AuthSource = "awsParameterStore"
+	AuthSourceGcpSecretManager  AuthSource = "gcpSecretManager"
+)
+
+// ParameterResolutionError is a parameter of a TriggerAuthentication that can't be resolved from its source
+type ParameterResolutionError struct {
+	Parameter string
+	Source    AuthSource
+	Err       error
+}
+
+func (e *ParameterResolutionError) Error() string {
+	return fmt.Sprintf("parameter %s: %s", e.Parameter, e.Err)
+}
+
+func (e *ParameterResolutionError) Unwrap() error {
+	return e.Err
+}
+
+// AuthResolutionError is returned when the TriggerAuthentication or ClusterTriggerAuthentication referenced by a trigger
+// can't be read, Err is set, or when some of its parameters that aren't optional can't be resolved
+type AuthResolutionError struct {
+	Kind       string
+	Name       string
+	Err        error
+	Parameters []*ParameterResolutionError
+}
+
+func (e *AuthResolutionError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("error reading %s %s: %s", e.Kind, e.Name, e.Err)
+	}
+	messages := make([]string, 0, len(e.Parameters))
+	for _, parameter := range e.Parameters {
+		messages = append(messages, parameter.Error())
+	}
+	return fmt.Sprintf("error resolving %s %s: %s", e.Kind, e.Name, strings.Join(messages, "; "))
+}
+
+func (e *AuthResolutionError) Unwrap() error {
+	return e.Err
+}
+
+// IsAuthResolutionError reports whether the error, or an error it wraps, is an AuthResolutionError
+func IsAuthResolutionError(err error) bool {
+	var authErr *AuthResolutionError
+	return errors.As(err, &authErr)
+}
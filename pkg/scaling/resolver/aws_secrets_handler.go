@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+type AwsSecretManagerHandler struct {
+	secretManager   *kedav1alpha1.AwsSecretManager
+	client          *secretsmanager.SecretsManager
+	podIdentity     kedav1alpha1.PodIdentityProvider
+	workloadRoleArn string
+}
+
+// NewAwsSecretManagerHandler creates a handler for the secrets of s, workloadRoleArn is the role of the aws-eks or aws-kiam
+// pod identity of the scale target
+func NewAwsSecretManagerHandler(s *kedav1alpha1.AwsSecretManager, podIdentity kedav1alpha1.PodIdentityProvider, workloadRoleArn string) *AwsSecretManagerHandler {
+	return &AwsSecretManagerHandler{
+		secretManager:   s,
+		podIdentity:     podIdentity,
+		workloadRoleArn: workloadRoleArn,
+	}
+}
+
+func (h *AwsSecretManagerHandler) Initialize(ctx context.Context, client client.Client, triggerNamespace string) error {
+	sess, config, err := newAwsSession(ctx, client, triggerNamespace, h.secretManager.Region, h.secretManager.Endpoint, h.secretManager.Credentials, h.secretManager.RoleArn, h.podIdentity, h.workloadRoleArn)
+	if err != nil {
+		return err
+	}
+	h.client = secretsmanager.New(sess, config)
+	return nil
+}
+
+func (h *AwsSecretManagerHandler) Read(ctx context.Context, secret kedav1alpha1.AwsSecretManagerSecret) (string, error) {
+	input := &secretsmanager.GetSecretValueInput{SecretId: aws.String(secret.Name)}
+	if secret.VersionID != "" {
+		input.VersionId = aws.String(secret.VersionID)
+	}
+	if secret.VersionStage != "" {
+		input.VersionStage = aws.String(secret.VersionStage)
+	}
+	output, err := h.client.GetSecretValueWithContext(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	value := string(output.SecretBinary)
+	if output.SecretString != nil {
+		value = *output.SecretString
+	}
+	if secret.JSONKey == "" {
+		return value, nil
+	}
+	return resolveJSONKey(value, secret.JSONKey)
+}
+
+type AwsParameterStoreHandler struct {
+	parameterStore  *kedav1alpha1.AwsParameterStore
+	client          *ssm.SSM
+	podIdentity     kedav1alpha1.PodIdentityProvider
+	workloadRoleArn string
+}
+
+// NewAwsParameterStoreHandler creates a handler for the parameters of p, workloadRoleArn is the role of the aws-eks or aws-kiam
+// pod identity of the scale target
+func NewAwsParameterStoreHandler(p *kedav1alpha1.AwsParameterStore, podIdentity kedav1alpha1.PodIdentityProvider, workloadRoleArn string) *AwsParameterStoreHandler {
+	return &AwsParameterStoreHandler{
+		parameterStore:  p,
+		podIdentity:     podIdentity,
+		workloadRoleArn: workloadRoleArn,
+	}
+}
+
+func (h *AwsParameterStoreHandler) Initialize(ctx context.Context, client client.Client, triggerNamespace string) error {
+	sess, config, err := newAwsSession(ctx, client, triggerNamespace, h.parameterStore.Region, h.parameterStore.Endpoint, h.parameterStore.Credentials, h.parameterStore.RoleArn, h.podIdentity, h.workloadRoleArn)
+	if err != nil {
+		return err
+	}
+	h.client = ssm.New(sess, config)
+	return nil
+}
+
+func (h *AwsParameterStoreHandler) Read(ctx context.Context, name string) (string, error) {
+	output, err := h.client.GetParameterWithContext(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return "", err
+	}
+	if output.Parameter == nil || output.Parameter.Value == nil {
+		return "", fmt.Errorf("parameter %s has no value", name)
+	}
+	return *output.Parameter.Value, nil
+}
+
+// newAwsSession returns a session authenticated like the AWS scalers: with the static credentials read from the Secrets of the
+// namespace, or else by assuming the role set in the TriggerAuthentication or the workload role of the aws-eks or aws-kiam
+// pod identity, annotated on the ServiceAccount or the pods of the scale target. The identity of KEDA itself is only used
+// to assume the role, it never reads the secrets. The endpoint is only set on the returned client config so that the role
+// is still assumed through STS.
+func newAwsSession(ctx context.Context, client client.Client, namespace, region, endpoint string, creds *kedav1alpha1.AwsCredentials,
+	roleArn string, podIdentity kedav1alpha1.PodIdentityProvider, workloadRoleArn string) (*session.Session, *aws.Config, error) {
+	sessionConfig := aws.NewConfig()
+	if region != "" {
+		sessionConfig.WithRegion(region)
+	}
+
+	switch {
+	case creds != nil:
+		accessKeyID, err := readAuthSecret(ctx, client, creds.AccessKeyID.SecretKeyRef.Name, namespace, creds.AccessKeyID.SecretKeyRef.Key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading the access key id: %s", err)
+		}
+		secretAccessKey, err := readAuthSecret(ctx, client, creds.SecretAccessKey.SecretKeyRef.Name, namespace, creds.SecretAccessKey.SecretKeyRef.Key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading the secret access key: %s", err)
+		}
+		var sessionToken string
+		if creds.SessionToken != nil {
+			sessionToken, err = readAuthSecret(ctx, client, creds.SessionToken.SecretKeyRef.Name, namespace, creds.SessionToken.SecretKeyRef.Key)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error reading the session token: %s", err)
+			}
+		}
+		sessionConfig.WithCredentials(credentials.NewStaticCredentials(accessKeyID, secretAccessKey, sessionToken))
+	case roleArn != "":
+		// the default credential chain uses the identity of KEDA to assume the role
+	case isAwsPodIdentity(podIdentity):
+		if workloadRoleArn == "" {
+			// ScaledJobs and scale targets without the annotation have no workload role, KEDA doesn't read the secrets with its own identity
+			if podIdentity == kedav1alpha1.PodIdentityProviderAwsKiam {
+				return nil, nil, fmt.Errorf("the %s pod identity needs the %s annotation on the pods of the scale target", podIdentity, kedav1alpha1.PodIdentityAnnotationKiam)
+			}
+			return nil, nil, fmt.Errorf("the %s pod identity needs the %s annotation on the ServiceAccount of the scale target", podIdentity, kedav1alpha1.PodIdentityAnnotationEKS)
+		}
+		roleArn = workloadRoleArn
+	default:
+		return nil, nil, fmt.Errorf("credentials, a role ARN or the %s pod identity are required", kedav1alpha1.PodIdentityProviderAwsEKS)
+	}
+
+	sess, err := session.NewSession(sessionConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	config := aws.NewConfig()
+	if endpoint != "" {
+		config.WithEndpoint(endpoint)
+	}
+	if roleArn != "" {
+		config.WithCredentials(stscreds.NewCredentials(sess, roleArn))
+	}
+	return sess, config, nil
+}
+
+// resolveJSONKey returns the value of the key of a JSON object, values that aren't strings are returned as JSON
+func resolveJSONKey(data, key string) (string, error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &object); err != nil {
+		return "", fmt.Errorf("the secret isn't a JSON object: %s", err)
+	}
+	value, ok := object[key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in the secret", key)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
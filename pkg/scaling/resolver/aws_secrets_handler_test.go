@@ -0,0 +1,195 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// fakeAws answers the GetSecretValue and GetParameter calls of the AWS SDK
+func fakeAws(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "Credential=access-key/") {
+			t.Errorf("Expected a request signed with the static credentials, got %q", r.Header.Get("Authorization"))
+		}
+		var input map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			t.Errorf("Unexpected request body: %s", err)
+		}
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		switch r.Header.Get("X-Amz-Target") {
+		case "secretsmanager.GetSecretValue":
+			switch {
+			case input["SecretId"] == "database" && input["VersionStage"] == "AWSPREVIOUS":
+				fmt.Fprint(w, `{"Name": "database", "SecretString": "{\"password\": \"previous\"}"}`)
+			case input["SecretId"] == "database":
+				fmt.Fprint(w, `{"Name": "database", "SecretString": "{\"password\": \"current\", \"port\": 5432}"}`)
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"__type": "ResourceNotFoundException", "message": "Secrets Manager can't find the specified secret."}`)
+			}
+		case "AmazonSSM.GetParameter":
+			if input["WithDecryption"] != true {
+				t.Error("Expected the parameter to be decrypted")
+			}
+			if input["Name"] == "/app/host" {
+				fmt.Fprint(w, `{"Parameter": {"Name": "/app/host", "Value": "db.example.com"}}`)
+				return
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"__type": "ParameterNotFound"}`)
+		default:
+			t.Errorf("Unexpected call %s", r.Header.Get("X-Amz-Target"))
+		}
+	}))
+}
+
+func TestResolveAwsSecrets(t *testing.T) {
+	server := fakeAws(t)
+	defer server.Close()
+
+	awsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws", Namespace: namespace},
+		Data:       map[string][]byte{"id": []byte("access-key"), "secret": []byte("secret-key")},
+	}
+	credentials := &kedav1alpha1.AwsCredentials{
+		AccessKeyID:     kedav1alpha1.ValueFromSecret{SecretKeyRef: kedav1alpha1.SecretKeyRef{Name: "aws", Key: "id"}},
+		SecretAccessKey: kedav1alpha1.ValueFromSecret{SecretKeyRef: kedav1alpha1.SecretKeyRef{Name: "aws", Key: "secret"}},
+	}
+
+	tests := []struct {
+		name            string
+		spec            kedav1alpha1.TriggerAuthenticationSpec
+		podIdentity     kedav1alpha1.PodIdentityProvider
+		workloadRoleArn string
+		expected        map[string]string
+		expectedFailed  []string
+	}{
+		{
+			name: "secrets manager",
+			spec: kedav1alpha1.TriggerAuthenticationSpec{
+				AwsSecretManager: &kedav1alpha1.AwsSecretManager{
+					Region:      "eu-west-1",
+					Endpoint:    server.URL,
+					Credentials: credentials,
+					Secrets: []kedav1alpha1.AwsSecretManagerSecret{
+						{Parameter: "connection", Name: "database"},
+						{Parameter: "password", Name: "database", JSONKey: "password"},
+						{Parameter: "port", Name: "database", JSONKey: "port"},
+						{Parameter: "previousPassword", Name: "database", JSONKey: "password", VersionStage: "AWSPREVIOUS"},
+					},
+				},
+			},
+			expected: map[string]string{
+				"connection":       `{"password": "current", "port": 5432}`,
+				"password":         "current",
+				"port":             "5432",
+				"previousPassword": "previous",
+			},
+		},
+		{
+			name: "missing secret and key",
+			spec: kedav1alpha1.TriggerAuthenticationSpec{
+				AwsSecretManager: &kedav1alpha1.AwsSecretManager{
+					Region:      "eu-west-1",
+					Endpoint:    server.URL,
+					Credentials: credentials,
+					Secrets: []kedav1alpha1.AwsSecretManagerSecret{
+						{Parameter: "password", Name: "missing"},
+						{Parameter: "user", Name: "database", JSONKey: "user"},
+						{Parameter: "token", Name: "missing", Optional: true},
+					},
+				},
+			},
+			expected:       map[string]string{},
+			expectedFailed: []string{"password", "user"},
+		},
+		{
+			name: "parameter store",
+			spec: kedav1alpha1.TriggerAuthenticationSpec{
+				AwsParameterStore: &kedav1alpha1.AwsParameterStore{
+					Region:      "eu-west-1",
+					Endpoint:    server.URL,
+					Credentials: credentials,
+					Parameters: []kedav1alpha1.AwsParameterStoreParameter{
+						{Parameter: "host", Name: "/app/host"},
+						{Parameter: "port", Name: "/app/port"},
+					},
+				},
+			},
+			expected:       map[string]string{"host": "db.example.com"},
+			expectedFailed: []string{"port"},
+		},
+		{
+			name: "no credentials nor pod identity",
+			spec: kedav1alpha1.TriggerAuthenticationSpec{
+				AwsParameterStore: &kedav1alpha1.AwsParameterStore{
+					Region:     "eu-west-1",
+					Endpoint:   server.URL,
+					Parameters: []kedav1alpha1.AwsParameterStoreParameter{{Parameter: "host", Name: "/app/host"}},
+				},
+			},
+			podIdentity:    kedav1alpha1.PodIdentityProviderAzure,
+			expected:       map[string]string{},
+			expectedFailed: []string{"host"},
+		}, {
+			name: "pod identity without a role of the scale target",
+			spec: kedav1alpha1.TriggerAuthenticationSpec{
+				AwsSecretManager: &kedav1alpha1.AwsSecretManager{
+					Region:   "eu-west-1",
+					Endpoint: server.URL,
+					Secrets:  []kedav1alpha1.AwsSecretManagerSecret{{Parameter: "password", Name: "database"}},
+				},
+			},
+			podIdentity:    kedav1alpha1.PodIdentityProviderAwsEKS,
+			expected:       map[string]string{},
+			expectedFailed: []string{"password"},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(awsSecret).Build()
+			result := map[string]string{}
+			var failed []string
+			for _, parameter := range resolveAuthSecrets(context.TODO(), client, logf.Log.WithName("test"), &test.spec, test.podIdentity, test.workloadRoleArn, namespace, result) {
+				failed = append(failed, parameter.Parameter)
+			}
+			if diff := cmp.Diff(test.expected, result); diff != "" {
+				t.Errorf("Resolved parameters are different: %s", diff)
+			}
+			if diff := cmp.Diff(test.expectedFailed, failed); diff != "" {
+				t.Errorf("Failed parameters are different: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/gcp"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	gcpSecretManagerEndpoint = "https://secretmanager.googleapis.com"
+	gcpSecretManagerTimeout  = 30 * time.Second
+)
+
+type GcpSecretManagerHandler struct {
+	secretManager *kedav1alpha1.GcpSecretManager
+	httpClient    *http.Client
+	podIdentity   kedav1alpha1.PodIdentityProvider
+}
+
+func NewGcpSecretManagerHandler(s *kedav1alpha1.GcpSecretManager, podIdentity kedav1alpha1.PodIdentityProvider) *GcpSecretManagerHandler {
+	return &GcpSecretManagerHandler{
+		secretManager: s,
+		podIdentity:   podIdentity,
+	}
+}
+
+// Initialize authenticates with the gcp pod identity of KEDA, or else with the service account key of the credentials
+func (h *GcpSecretManagerHandler) Initialize(ctx context.Context, client client.Client, triggerNamespace string) error {
+	credentials, err := gcp.GetCredentials(h.podIdentity, func() (gcp.Credentials, error) {
+		if h.secretManager.Credentials == nil {
+			return gcp.Credentials{}, fmt.Errorf("credentials or the %s pod identity are required", kedav1alpha1.PodIdentityProviderGCP)
+		}
+		ref := h.secretManager.Credentials.ClientSecret.SecretKeyRef
+		key, err := readAuthSecret(ctx, client, ref.Name, triggerNamespace, ref.Key)
+		if err != nil {
+			return gcp.Credentials{}, fmt.Errorf("error reading the service account key: %s", err)
+		}
+		return gcp.Credentials{ServiceAccountKey: key}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// the tokens are requested and the secrets read with the cluster-wide proxy and CA bundle
+	httpClient, err := kedautil.NewHTTPClient(kedautil.HTTPClientOptions{Timeout: gcpSecretManagerTimeout})
+	if err != nil {
+		return err
+	}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+	tokenSource, err := credentials.TokenSource(ctx)
+	if err != nil {
+		return err
+	}
+	h.httpClient = oauth2.NewClient(ctx, tokenSource)
+	return nil
+}
+
+// Read returns the payload of the secret version, named projects/<project>/secrets/<secret>/versions/<version>
+func (h *GcpSecretManagerHandler) Read(ctx context.Context, name string) (string, error) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 6 || parts[0] != "projects" || parts[2] != "secrets" || parts[4] != "versions" {
+		return "", fmt.Errorf("%s isn't a secret version name like projects/<project>/secrets/<secret>/versions/<version>", name)
+	}
+
+	endpoint := h.secretManager.Endpoint
+	if endpoint == "" {
+		endpoint = gcpSecretManagerEndpoint
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/%s:access", strings.TrimSuffix(endpoint, "/"), name), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var version struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(body, &version); err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(version.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("error decoding the payload: %s", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// fakeGcp issues access tokens for service account keys and serves the secret versions of the Secret Manager API
+func fakeGcp(t *testing.T) *httptest.Server {
+	return httptest.NewServer(fakeGcpHandler(t))
+}
+
+func fakeGcpHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/token":
+			fmt.Fprint(w, `{"access_token": "access-token", "token_type": "Bearer", "expires_in": 3600}`)
+			return
+		case "/v1/projects/keda/secrets/database/versions/latest:access":
+			if r.Header.Get("Authorization") != "Bearer access-token" {
+				t.Errorf("Expected the access token, got %q", r.Header.Get("Authorization"))
+			}
+			fmt.Fprintf(w, `{"name": "projects/keda/secrets/database/versions/3", "payload": {"data": "%s"}}`, base64.StdEncoding.EncodeToString([]byte("password")))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "status": "NOT_FOUND"}}`)
+		}
+	})
+}
+
+// serviceAccountKey returns a service account JSON key whose tokens are issued by the server
+func serviceAccountKey(t *testing.T, server *httptest.Server) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	credentials, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "keda@keda.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		"token_uri":    server.URL + "/token",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return credentials
+}
+
+func TestResolveGcpSecretManager(t *testing.T) {
+	server := fakeGcp(t)
+	defer server.Close()
+
+	gcpSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gcp", Namespace: namespace},
+		Data:       map[string][]byte{"key.json": serviceAccountKey(t, server)},
+	}
+	serviceAccount := &kedav1alpha1.GcpCredentials{
+		ClientSecret: kedav1alpha1.ValueFromSecret{SecretKeyRef: kedav1alpha1.SecretKeyRef{Name: "gcp", Key: "key.json"}},
+	}
+
+	tests := []struct {
+		name           string
+		spec           kedav1alpha1.GcpSecretManager
+		expected       map[string]string
+		expectedFailed []string
+	}{
+		{
+			name: "service account key",
+			spec: kedav1alpha1.GcpSecretManager{
+				Endpoint:    server.URL,
+				Credentials: serviceAccount,
+				Secrets: []kedav1alpha1.GcpSecretManagerSecret{
+					{Parameter: "password", Name: "projects/keda/secrets/database/versions/latest"},
+					{Parameter: "token", Name: "projects/keda/secrets/token/versions/latest", Optional: true},
+				},
+			},
+			expected: map[string]string{"password": "password"},
+		},
+		{
+			name: "missing and invalid secrets",
+			spec: kedav1alpha1.GcpSecretManager{
+				Endpoint:    server.URL,
+				Credentials: serviceAccount,
+				Secrets: []kedav1alpha1.GcpSecretManagerSecret{
+					{Parameter: "token", Name: "projects/keda/secrets/token/versions/latest"},
+					{Parameter: "password", Name: "projects/keda/secrets/database"},
+				},
+			},
+			expected:       map[string]string{},
+			expectedFailed: []string{"token", "password"},
+		},
+		{
+			name: "no credentials nor pod identity",
+			spec: kedav1alpha1.GcpSecretManager{
+				Endpoint: server.URL,
+				Secrets:  []kedav1alpha1.GcpSecretManagerSecret{{Parameter: "password", Name: "projects/keda/secrets/database/versions/latest"}},
+			},
+			expected:       map[string]string{},
+			expectedFailed: []string{"password"},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(gcpSecret).Build()
+			spec := &kedav1alpha1.TriggerAuthenticationSpec{GcpSecretManager: &test.spec}
+			result := map[string]string{}
+			var failed []string
+			for _, parameter := range resolveAuthSecrets(context.TODO(), client, logf.Log.WithName("test"), spec, kedav1alpha1.PodIdentityProviderNone, "", namespace, result) {
+				failed = append(failed, parameter.Parameter)
+			}
+			if diff := cmp.Diff(test.expected, result); diff != "" {
+				t.Errorf("Resolved parameters are different: %s", diff)
+			}
+			if diff := cmp.Diff(test.expectedFailed, failed); diff != "" {
+				t.Errorf("Failed parameters are different: %s", diff)
+			}
+		})
+	}
+}
+
+func TestGcpSecretManagerUsesClusterCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(fakeGcpHandler(t))
+	defer server.Close()
+
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := kedautil.SetCABundle(bundle); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = kedautil.SetCABundle(nil)
+	}()
+
+	gcpSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gcp", Namespace: namespace},
+		Data:       map[string][]byte{"key.json": serviceAccountKey(t, server)},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(gcpSecret).Build()
+	handler := NewGcpSecretManagerHandler(&kedav1alpha1.GcpSecretManager{
+		Endpoint: server.URL,
+		Credentials: &kedav1alpha1.GcpCredentials{
+			ClientSecret: kedav1alpha1.ValueFromSecret{SecretKeyRef: kedav1alpha1.SecretKeyRef{Name: "gcp", Key: "key.json"}},
+		},
+	}, kedav1alpha1.PodIdentityProviderNone)
+	if err := handler.Initialize(context.TODO(), client, namespace); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	value, err := handler.Read(context.TODO(), "projects/keda/secrets/database/versions/latest")
+	if err != nil {
+		t.Fatalf("Expected the token and the secret to be requested trusting the cluster CA bundle, got %s", err)
+	}
+	if value != "password" {
+		t.Errorf("Expected password, got %q", value)
+	}
+}
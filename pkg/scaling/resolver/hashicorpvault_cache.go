@@ -0,0 +1,277 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	vaultapi "github.com/hashicorp/vault/api"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const (
+	// HashiCorpVaultSecretDependencyKind is the dependency kind of the scalers built from a HashiCorp Vault secret,
+	// the dependency name is the address of the Vault followed by the path of the secret
+	HashiCorpVaultSecretDependencyKind = "HashiCorpVaultSecret"
+
+	// vaultSecretCacheTTL is how long secrets without a lease, like KV secrets, are cached
+	vaultSecretCacheTTL = 5 * time.Minute
+
+	// vaultClientIdleTTL is how long a client without leased secrets is kept after it was last used,
+	// its token isn't renewed anymore once it is evicted
+	vaultClientIdleTTL = 30 * time.Minute
+)
+
+// DependencyRecorder is implemented by clients recording the objects and external secrets the scaler of a trigger is built from
+type DependencyRecorder interface {
+	RecordDependency(kind, namespace, name string)
+}
+
+var (
+	vaultClients = newVaultClientCache(vaultClientIdleTTL)
+
+	vaultRotationLock     sync.RWMutex
+	vaultRotationHandlers []func(dependency string)
+)
+
+// OnHashiCorpVaultSecretRotated registers a function called with the dependency name of a leased HashiCorp Vault secret,
+// like database credentials, once its lease ended and the secret has to be read again
+func OnHashiCorpVaultSecretRotated(handler func(dependency string)) {
+	vaultRotationLock.Lock()
+	defer vaultRotationLock.Unlock()
+	vaultRotationHandlers = append(vaultRotationHandlers, handler)
+}
+
+func notifyVaultSecretRotated(dependency string) {
+	vaultRotationLock.RLock()
+	handlers := vaultRotationHandlers
+	vaultRotationLock.RUnlock()
+	for _, handler := range handlers {
+		handler(dependency)
+	}
+}
+
+// vaultSecretDependency returns the dependency name of a HashiCorp Vault secret
+func vaultSecretDependency(address, path string) string {
+	return strings.TrimSuffix(address, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// vaultClientCache holds a logged in Vault client per address, namespace and authentication.
+// Clients are evicted once they haven't been used for idleTTL and don't watch the lease of a secret anymore.
+type vaultClientCache struct {
+	lock    sync.Mutex
+	clients map[string]*sharedVaultClient
+	idleTTL time.Duration
+}
+
+func newVaultClientCache(idleTTL time.Duration) *vaultClientCache {
+	return &vaultClientCache{
+		clients: map[string]*sharedVaultClient{},
+		idleTTL: idleTTL,
+	}
+}
+
+// get returns the client of the login, namespace is the namespace of the Secrets holding the credentials of the approle and cert authentication
+func (c *vaultClientCache) get(spec *kedav1alpha1.HashiCorpVault, namespace string) *sharedVaultClient {
+	key := vaultClientKey(spec, namespace)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	client, ok := c.clients[key]
+	if !ok {
+		client = &sharedVaultClient{
+			spec:    spec.DeepCopy(),
+			secrets: map[string]*cachedVaultSecret{},
+		}
+		client.idleTimer = time.AfterFunc(c.idleTTL, func() {
+			c.evictIdle(key, client)
+		})
+		c.clients[key] = client
+	}
+
+	client.lock.Lock()
+	client.lastUsed = time.Now()
+	client.lock.Unlock()
+	return client
+}
+
+// evictIdle removes the client and stops the renewal of its token when it is idle, otherwise it checks again once it could be
+func (c *vaultClientCache) evictIdle(key string, client *sharedVaultClient) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	client.lock.Lock()
+	defer client.lock.Unlock()
+
+	if idle := time.Since(client.lastUsed); idle < c.idleTTL || client.watchesLeases() {
+		client.idleTimer.Reset(c.idleTTL - idle)
+		return
+	}
+	if c.clients[key] == client {
+		delete(c.clients, key)
+	}
+	if client.handler != nil {
+		client.handler.Stop()
+		client.handler = nil
+	}
+	client.secrets = map[string]*cachedVaultSecret{}
+}
+
+// vaultClientKey identifies the login of a Vault client, the credentials are only part of it as a hash
+func vaultClientKey(spec *kedav1alpha1.HashiCorpVault, namespace string) string {
+	credentials := sha256.New()
+	if spec.Credential != nil {
+		parts := []string{spec.Credential.Token, spec.Credential.ServiceAccount, spec.Credential.ClientCertSecret, spec.Credential.IAMServerID}
+		for _, ref := range []*kedav1alpha1.ValueFromSecret{spec.Credential.RoleID, spec.Credential.SecretID} {
+			if ref != nil {
+				parts = append(parts, ref.SecretKeyRef.Name, ref.SecretKeyRef.Key)
+			}
+		}
+		for _, part := range parts {
+			credentials.Write([]byte(part))
+			credentials.Write([]byte{0})
+		}
+	}
+	switch spec.Authentication {
+	case kedav1alpha1.VaultAuthenticationAppRole, kedav1alpha1.VaultAuthenticationCert:
+	default:
+		// only the approle and cert credentials are read from Secrets of the namespace
+		namespace = ""
+	}
+	return fmt.Sprintf("%s|%s|%s|%s|%s|%s|%x", spec.Address, spec.Namespace, spec.Authentication, spec.Mount, spec.Role, namespace, credentials.Sum(nil))
+}
+
+// sharedVaultClient logs in once, renews its token and logs in again once the token can't be renewed anymore.
+// It caches the secrets read: secrets without a lease for vaultSecretCacheTTL, pinned KV v2 versions until the process ends
+// and leased secrets until their lease can't be renewed anymore.
+type sharedVaultClient struct {
+	lock    sync.Mutex
+	spec    *kedav1alpha1.HashiCorpVault
+	handler *HashicorpVaultHandler
+	secrets map[string]*cachedVaultSecret
+	// lastUsed and idleTimer are maintained by the vaultClientCache to evict the client once it is idle
+	lastUsed  time.Time
+	idleTimer *time.Timer
+}
+
+type cachedVaultSecret struct {
+	secret *vaultapi.Secret
+	// expiresAt is zero for secrets cached until their lease ends or forever for pinned versions
+	expiresAt time.Time
+}
+
+// Read returns the secret at the path, the latest version is read for version 0. The credentials of the login are read with readSecret.
+func (c *sharedVaultClient) Read(logger logr.Logger, readSecret vaultSecretReader, path string, version int) (*vaultapi.Secret, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := fmt.Sprintf("%s?version=%d", path, version)
+	if cached, ok := c.secrets[key]; ok && (cached.expiresAt.IsZero() || time.Now().Before(cached.expiresAt)) {
+		return cached.secret, nil
+	}
+
+	handler, err := c.login(logger, readSecret)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := handler.ReadVersion(path, version)
+	if err != nil || secret == nil {
+		return secret, err
+	}
+
+	cached := &cachedVaultSecret{secret: secret}
+	switch {
+	case secret.LeaseID != "" && secret.LeaseDuration > 0:
+		go c.watchLease(logger, handler, key, path, cached)
+	case version == 0:
+		cached.expiresAt = time.Now().Add(vaultSecretCacheTTL)
+	}
+	c.secrets[key] = cached
+	return secret, nil
+}
+
+// watchesLeases reports whether the lease of a cached secret is still watched, the scalers built from it have to be notified once it ends
+func (c *sharedVaultClient) watchesLeases() bool {
+	for _, cached := range c.secrets {
+		if cached.secret.LeaseID != "" && cached.expiresAt.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// login returns the handler of the current login, it logs in again when the token expired or isn't renewed anymore
+func (c *sharedVaultClient) login(logger logr.Logger, readSecret vaultSecretReader) (*HashicorpVaultHandler, error) {
+	if c.handler != nil && c.handler.Valid() {
+		return c.handler, nil
+	}
+	if c.handler != nil {
+		c.handler.Stop()
+		c.handler = nil
+	}
+
+	handler := NewHashicorpVaultHandler(c.spec)
+	handler.readSecret = readSecret
+	if err := handler.Initialize(logger); err != nil {
+		return nil, err
+	}
+	c.handler = handler
+	return handler, nil
+}
+
+// watchLease renews the lease of a secret as long as possible, or until shortly before it ends if it isn't renewable.
+// The secret is then dropped from the cache and the scalers built from it are notified to read it again.
+func (c *sharedVaultClient) watchLease(logger logr.Logger, handler *HashicorpVaultHandler, key, path string, cached *cachedVaultSecret) {
+	var done <-chan error
+	if cached.secret.Renewable {
+		watcher, err := handler.client.NewLifetimeWatcher(&vaultapi.LifetimeWatcherInput{Secret: cached.secret})
+		if err != nil {
+			logger.Error(err, "Error watching the lease of HashiCorp Vault secret", "path", path)
+		} else {
+			go watcher.Start()
+			defer watcher.Stop()
+			done = watcher.DoneCh()
+		}
+	}
+	if done == nil {
+		expired := make(chan error, 1)
+		timer := time.AfterFunc(time.Duration(cached.secret.LeaseDuration)*time.Second*2/3, func() {
+			expired <- nil
+		})
+		defer timer.Stop()
+		done = expired
+	}
+
+	if err := <-done; err != nil {
+		logger.Error(err, "Error renewing the lease of HashiCorp Vault secret", "path", path)
+	}
+
+	c.lock.Lock()
+	current, ok := c.secrets[key]
+	if ok && current == cached {
+		delete(c.secrets, key)
+	}
+	c.lock.Unlock()
+	if ok && current == cached {
+		logger.V(1).Info("Lease of HashiCorp Vault secret ended", "path", path)
+		notifyVaultSecretRotated(vaultSecretDependency(c.spec.Address, path))
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+type fakeVault struct {
+	lock     sync.Mutex
+	requests map[string]int
+	leases   int
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.requests[r.URL.RequestURI()]++
+
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/v1/auth/token/lookup-self":
+		fmt.Fprint(w, `{"data": {"renewable": false, "ttl": 0}}`)
+	case "/v1/secret/data/app":
+		version := r.URL.Query().Get("version")
+		if version == "" {
+			version = "3"
+		}
+		fmt.Fprintf(w, `{"data": {"data": {"password": "v%s"}, "metadata": {"version": %s}}}`, version, version)
+	case "/v1/database/creds/app":
+		v.leases++
+		fmt.Fprintf(w, `{"lease_id": "database/creds/app/%d", "lease_duration": 1, "renewable": false, "data": {"username": "user-%d"}}`, v.leases, v.leases)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors": []}`)
+	}
+}
+
+func (v *fakeVault) count(uri string) int {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.requests[uri]
+}
+
+func TestSharedVaultClient(t *testing.T) {
+	vault := &fakeVault{requests: map[string]int{}}
+	server := httptest.NewServer(vault)
+	defer server.Close()
+
+	rotated := make(chan string, 1)
+	OnHashiCorpVaultSecretRotated(func(dependency string) {
+		rotated <- dependency
+	})
+
+	clients := newVaultClientCache(time.Hour)
+	spec := &kedav1alpha1.HashiCorpVault{
+		Address:        server.URL,
+		Authentication: kedav1alpha1.VaultAuthenticationToken,
+		Credential:     &kedav1alpha1.Credential{Token: "token"},
+	}
+	client := clients.get(spec, "test")
+	if clients.get(spec.DeepCopy(), "other") != client {
+		t.Fatal("Expected the same client for the same login")
+	}
+	logger := logf.Log.WithName("test")
+
+	for i := 0; i < 2; i++ {
+		secret, err := client.Read(logger, nil, "secret/data/app", 0)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if value, _ := resolveVaultSecret(secret.Data, "password"); value != "v3" {
+			t.Errorf("Expected the latest version, got %q", value)
+		}
+	}
+	secret, err := client.Read(logger, nil, "secret/data/app", 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if value, _ := resolveVaultSecret(secret.Data, "password"); value != "v2" {
+		t.Errorf("Expected the pinned version, got %q", value)
+	}
+	if count := vault.count("/v1/secret/data/app"); count != 1 {
+		t.Errorf("Expected the latest version to be read once, read %d times", count)
+	}
+	if count := vault.count("/v1/secret/data/app?version=2"); count != 1 {
+		t.Errorf("Expected the pinned version to be read once, read %d times", count)
+	}
+	if count := vault.count("/v1/auth/token/lookup-self"); count != 1 {
+		t.Errorf("Expected a single login, got %d", count)
+	}
+
+	secret, err = client.Read(logger, nil, "database/creds/app", 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if value, _ := resolveVaultSecret(secret.Data, "username"); value != "user-1" {
+		t.Errorf("Expected the first credentials, got %q", value)
+	}
+	select {
+	case dependency := <-rotated:
+		if expected := server.URL + "/database/creds/app"; dependency != expected {
+			t.Errorf("Expected rotation of %s, got %s", expected, dependency)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the leased secret to be rotated")
+	}
+	secret, err = client.Read(logger, nil, "database/creds/app", 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if value, _ := resolveVaultSecret(secret.Data, "username"); value != "user-2" {
+		t.Errorf("Expected new credentials after the rotation, got %q", value)
+	}
+}
+
+func TestVaultClientCacheEvictsIdleClients(t *testing.T) {
+	vault := &fakeVault{requests: map[string]int{}}
+	server := httptest.NewServer(vault)
+	defer server.Close()
+
+	clients := newVaultClientCache(50 * time.Millisecond)
+	spec := &kedav1alpha1.HashiCorpVault{
+		Address:        server.URL,
+		Authentication: kedav1alpha1.VaultAuthenticationToken,
+		Credential:     &kedav1alpha1.Credential{Token: "token"},
+	}
+	logger := logf.Log.WithName("test")
+
+	client := clients.get(spec, "test")
+	if _, err := client.Read(logger, nil, "secret/data/app", 0); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		clients.lock.Lock()
+		count := len(clients.clients)
+		clients.lock.Unlock()
+		if count == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the idle client to be evicted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	client.lock.Lock()
+	handler := client.handler
+	client.lock.Unlock()
+	if handler != nil {
+		t.Error("Expected the login of the evicted client to be stopped")
+	}
+
+	if clients.get(spec, "test") == client {
+		t.Fatal("Expected a new client after the eviction")
+	}
+}
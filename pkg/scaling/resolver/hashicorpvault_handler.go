@@ -17,21 +17,47 @@ limitations under the License.
 package resolver
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
-
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/go-logr/logr"
 	vaultapi "github.com/hashicorp/vault/api"
+	corev1 "k8s.io/api/core/v1"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
 
+// VaultJWTServiceAccountPath is the projected service account token with the vault audience the jwt authentication uses by default
+const VaultJWTServiceAccountPath = "/var/run/secrets/vault/serviceaccount/token"
+
+// vaultSecretReader returns the value of a key of a Secret of the namespace of the authentication
+type vaultSecretReader func(name, key string) (string, error)
+
 // HashicorpVaultHandler is specification of Hashi Corp Vault
 type HashicorpVaultHandler struct {
-	vault  *kedav1alpha1.HashiCorpVault
-	client *vaultapi.Client
-	stopCh chan struct{}
+	vault *kedav1alpha1.HashiCorpVault
+	// readSecret reads the credentials of the approle and cert authentication
+	readSecret vaultSecretReader
+	client     *vaultapi.Client
+	stopCh     chan struct{}
+	stopOnce   sync.Once
+	// renewalDone is closed once a renewable token isn't renewed anymore
+	renewalDone chan struct{}
+	// expiresAt is when a token that isn't renewable expires, zero for tokens without a TTL
+	expiresAt time.Time
 }
 
 // NewHashicorpVaultHandler creates a HashicorpVaultHandler object
@@ -44,6 +70,11 @@ func NewHashicorpVaultHandler(v *kedav1alpha1.HashiCorpVault) *HashicorpVaultHan
 // Initialize the Vault client
 func (vh *HashicorpVaultHandler) Initialize(logger logr.Logger) error {
 	config := vaultapi.DefaultConfig()
+	if vh.vault.Authentication == kedav1alpha1.VaultAuthenticationCert {
+		if err := vh.configureClientCert(config); err != nil {
+			return err
+		}
+	}
 	client, err := vaultapi.NewClient(config)
 	if err != nil {
 		return err
@@ -73,14 +104,26 @@ func (vh *HashicorpVaultHandler) Initialize(logger logr.Logger) error {
 		return err
 	}
 
-	renew := lookup.Data["renewable"].(bool)
+	renew, err := lookup.TokenIsRenewable()
+	if err != nil {
+		return err
+	}
+	// the renewal goroutine uses the client, it has to be set before it starts
+	vh.client = client
 	if renew {
 		vh.stopCh = make(chan struct{})
+		vh.renewalDone = make(chan struct{})
 		go vh.renewToken(logger)
+	} else {
+		ttl, err := lookup.TokenTTL()
+		if err != nil {
+			return err
+		}
+		if ttl > 0 {
+			vh.expiresAt = time.Now().Add(ttl)
+		}
 	}
 
-	vh.client = client
-
 	return nil
 }
 
@@ -93,7 +136,7 @@ func (vh *HashicorpVaultHandler) token(client *vaultapi.Client) (string, error)
 		switch {
 		case len(client.Token()) > 0:
 			break
-		case len(vh.vault.Credential.Token) > 0:
+		case vh.vault.Credential != nil && len(vh.vault.Credential.Token) > 0:
 			token = vh.vault.Credential.Token
 		default:
 			return token, errors.New("could not get Vault token")
@@ -107,7 +150,7 @@ func (vh *HashicorpVaultHandler) token(client *vaultapi.Client) (string, error)
 			return token, errors.New("k8s role not in config")
 		}
 
-		if len(vh.vault.Credential.ServiceAccount) == 0 {
+		if vh.vault.Credential == nil || len(vh.vault.Credential.ServiceAccount) == 0 {
 			return token, errors.New("k8s SA file not in config")
 		}
 
@@ -117,13 +160,54 @@ func (vh *HashicorpVaultHandler) token(client *vaultapi.Client) (string, error)
 			return token, err
 		}
 
-		data := map[string]interface{}{"jwt": string(jwt), "role": vh.vault.Role}
-		secret, err := client.Logical().Write(fmt.Sprintf("auth/%s/login", vh.vault.Mount), data)
+		return vh.login(client, "kubernetes", map[string]interface{}{"jwt": string(jwt), "role": vh.vault.Role})
+	case kedav1alpha1.VaultAuthenticationAppRole:
+		if vh.vault.Credential == nil || vh.vault.Credential.RoleID == nil || vh.vault.Credential.SecretID == nil {
+			return token, errors.New("approle authentication requires credential.roleId and credential.secretId")
+		}
+		roleID, err := vh.secretValue(vh.vault.Credential.RoleID)
+		if err != nil {
+			return token, fmt.Errorf("error reading role_id: %s", err)
+		}
+		secretID, err := vh.secretValue(vh.vault.Credential.SecretID)
+		if err != nil {
+			return token, fmt.Errorf("error reading secret_id: %s", err)
+		}
+
+		return vh.login(client, "approle", map[string]interface{}{"role_id": roleID, "secret_id": secretID})
+	case kedav1alpha1.VaultAuthenticationJWT:
+		if len(vh.vault.Role) == 0 {
+			return token, errors.New("jwt role not in config")
+		}
+
+		path := VaultJWTServiceAccountPath
+		if vh.vault.Credential != nil && len(vh.vault.Credential.ServiceAccount) > 0 {
+			path = vh.vault.Credential.ServiceAccount
+		}
+		jwt, err := ioutil.ReadFile(path)
+		if err != nil {
+			return token, err
+		}
+
+		return vh.login(client, "jwt", map[string]interface{}{"jwt": string(jwt), "role": vh.vault.Role})
+	case kedav1alpha1.VaultAuthenticationCert:
+		// the client certificate is presented by the TLS connection, configured by Initialize
+		data := map[string]interface{}{}
+		if len(vh.vault.Role) > 0 {
+			data["name"] = vh.vault.Role
+		}
+
+		return vh.login(client, "cert", data)
+	case kedav1alpha1.VaultAuthenticationAWS:
+		data, err := vh.awsLoginData()
 		if err != nil {
 			return token, err
 		}
+		if len(vh.vault.Role) > 0 {
+			data["role"] = vh.vault.Role
+		}
 
-		token = secret.Auth.ClientToken
+		return vh.login(client, "aws", data)
 	default:
 		return token, fmt.Errorf("vault auth method %s is not supported", vh.vault.Authentication)
 	}
@@ -131,10 +215,113 @@ func (vh *HashicorpVaultHandler) token(client *vaultapi.Client) (string, error)
 	return token, nil
 }
 
+// login logs in with the auth method mounted at the configured mount, or at its default mount, and returns the client token
+func (vh *HashicorpVaultHandler) login(client *vaultapi.Client, defaultMount string, data map[string]interface{}) (string, error) {
+	mount := vh.vault.Mount
+	if len(mount) == 0 {
+		mount = defaultMount
+	}
+
+	secret, err := client.Logical().Write(fmt.Sprintf("auth/%s/login", mount), data)
+	if err != nil {
+		return "", err
+	}
+	if secret == nil || secret.Auth == nil {
+		return "", fmt.Errorf("no token returned by the %s login", vh.vault.Authentication)
+	}
+	return secret.Auth.ClientToken, nil
+}
+
+func (vh *HashicorpVaultHandler) secretValue(ref *kedav1alpha1.ValueFromSecret) (string, error) {
+	if vh.readSecret == nil {
+		return "", errors.New("secrets can't be read")
+	}
+	return vh.readSecret(ref.SecretKeyRef.Name, ref.SecretKeyRef.Key)
+}
+
+// configureClientCert presents the certificate of the kubernetes.io/tls Secret of the cert authentication
+// and trusts its optional CA
+func (vh *HashicorpVaultHandler) configureClientCert(config *vaultapi.Config) error {
+	if vh.vault.Credential == nil || len(vh.vault.Credential.ClientCertSecret) == 0 {
+		return errors.New("cert authentication requires credential.clientCertSecret")
+	}
+	if vh.readSecret == nil {
+		return errors.New("secrets can't be read")
+	}
+	name := vh.vault.Credential.ClientCertSecret
+	cert, err := vh.readSecret(name, corev1.TLSCertKey)
+	if err != nil {
+		return err
+	}
+	key, err := vh.readSecret(name, corev1.TLSPrivateKeyKey)
+	if err != nil {
+		return err
+	}
+	certificate, err := tls.X509KeyPair([]byte(cert), []byte(key))
+	if err != nil {
+		return fmt.Errorf("error parsing the client certificate of secret %s: %s", name, err)
+	}
+
+	transport, ok := config.HttpClient.Transport.(*http.Transport)
+	if !ok {
+		return errors.New("unexpected Vault HTTP transport")
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	transport.TLSClientConfig.Certificates = []tls.Certificate{certificate}
+	if ca, err := vh.readSecret(name, "ca.crt"); err == nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(ca)) {
+			return fmt.Errorf("error parsing ca.crt of secret %s", name)
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+	return nil
+}
+
+// awsLoginData signs a sts:GetCallerIdentity request with the AWS credentials of the KEDA pods, like their IRSA role
+func (vh *HashicorpVaultHandler) awsLoginData() (map[string]interface{}, error) {
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = "us-east-1"
+	}
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(region)})
+	if err != nil {
+		return nil, err
+	}
+
+	request, _ := sts.New(sess).GetCallerIdentityRequest(&sts.GetCallerIdentityInput{})
+	if vh.vault.Credential != nil && len(vh.vault.Credential.IAMServerID) > 0 {
+		request.HTTPRequest.Header.Add("X-Vault-AWS-IAM-Server-ID", vh.vault.Credential.IAMServerID)
+	}
+	if err := request.Sign(); err != nil {
+		return nil, fmt.Errorf("error signing the AWS login request: %s", err)
+	}
+
+	headers, err := json.Marshal(request.HTTPRequest.Header)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(request.HTTPRequest.Body)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"iam_http_request_method": request.HTTPRequest.Method,
+		"iam_request_url":         base64.StdEncoding.EncodeToString([]byte(request.HTTPRequest.URL.String())),
+		"iam_request_headers":     base64.StdEncoding.EncodeToString(headers),
+		"iam_request_body":        base64.StdEncoding.EncodeToString(body),
+	}, nil
+}
+
 func (vh *HashicorpVaultHandler) renewToken(logger logr.Logger) {
+	defer close(vh.renewalDone)
+
 	secret, err := vh.client.Auth().Token().RenewSelf(0)
 	if err != nil {
 		logger.Error(err, "Vault renew token: failed to create the payload")
+		return
 	}
 
 	renewer, err := vh.client.NewLifetimeWatcher(&vaultapi.RenewerInput{
@@ -144,35 +331,54 @@ func (vh *HashicorpVaultHandler) renewToken(logger logr.Logger) {
 	})
 	if err != nil {
 		logger.Error(err, "Vault renew token: cannot create the renewer")
+		return
 	}
 
 	go renewer.Renew()
-	defer func() {
-		renewer.Stop()
-		close(vh.stopCh)
-	}()
+	defer renewer.Stop()
 
-RenewWatcherLoop:
-	for {
+	select {
+	case <-vh.stopCh:
+	case err := <-renewer.DoneCh():
+		if err != nil {
+			logger.Error(err, "error renewing token")
+		}
+	}
+}
+
+// Valid reports whether the token is still usable, it isn't once it expired or, for renewable tokens, once it isn't renewed anymore
+func (vh *HashicorpVaultHandler) Valid() bool {
+	if vh.client == nil {
+		return false
+	}
+	if vh.renewalDone != nil {
 		select {
-		case <-vh.stopCh:
-			break RenewWatcherLoop
-		case err := <-renewer.DoneCh():
-			if err != nil {
-				logger.Error(err, "error renewing token")
-			}
-			break RenewWatcherLoop
+		case <-vh.renewalDone:
+			return false
+		default:
+			return true
 		}
 	}
+	return vh.expiresAt.IsZero() || time.Now().Before(vh.expiresAt)
 }
 
 func (vh *HashicorpVaultHandler) Read(path string) (*vaultapi.Secret, error) {
 	return vh.client.Logical().Read(path)
 }
 
+// ReadVersion reads the passed version of a KV v2 secret, the latest version is read for version 0
+func (vh *HashicorpVaultHandler) ReadVersion(path string, version int) (*vaultapi.Secret, error) {
+	if version == 0 {
+		return vh.Read(path)
+	}
+	return vh.client.Logical().ReadWithData(path, map[string][]string{"version": {strconv.Itoa(version)}})
+}
+
 // Stop is responsible for stoping the renew token process
 func (vh *HashicorpVaultHandler) Stop() {
 	if vh.stopCh != nil {
-		vh.stopCh <- struct{}{}
+		vh.stopOnce.Do(func() {
+			close(vh.stopCh)
+		})
 	}
 }
@@ -0,0 +1,157 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	vaultapi "github.com/hashicorp/vault/api"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestHashicorpVaultHandlerLogin(t *testing.T) {
+	jwtPath := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(jwtPath, []byte("projected-jwt"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	secrets := map[string]string{"approle/role_id": "role", "approle/secret_id": "secret"}
+	readSecret := func(name, key string) (string, error) {
+		value, ok := secrets[name+"/"+key]
+		if !ok {
+			return "", fmt.Errorf("key %s not found in secret %s", key, name)
+		}
+		return value, nil
+	}
+
+	tests := []struct {
+		name          string
+		vault         kedav1alpha1.HashiCorpVault
+		expectedPath  string
+		expectedLogin map[string]interface{}
+		isError       bool
+	}{
+		{
+			name: "approle",
+			vault: kedav1alpha1.HashiCorpVault{
+				Authentication: kedav1alpha1.VaultAuthenticationAppRole,
+				Credential: &kedav1alpha1.Credential{
+					RoleID:   &kedav1alpha1.ValueFromSecret{SecretKeyRef: kedav1alpha1.SecretKeyRef{Name: "approle", Key: "role_id"}},
+					SecretID: &kedav1alpha1.ValueFromSecret{SecretKeyRef: kedav1alpha1.SecretKeyRef{Name: "approle", Key: "secret_id"}},
+				},
+			},
+			expectedPath:  "/v1/auth/approle/login",
+			expectedLogin: map[string]interface{}{"role_id": "role", "secret_id": "secret"},
+		},
+		{
+			name: "approle with missing secret_id",
+			vault: kedav1alpha1.HashiCorpVault{
+				Authentication: kedav1alpha1.VaultAuthenticationAppRole,
+				Credential: &kedav1alpha1.Credential{
+					RoleID:   &kedav1alpha1.ValueFromSecret{SecretKeyRef: kedav1alpha1.SecretKeyRef{Name: "approle", Key: "role_id"}},
+					SecretID: &kedav1alpha1.ValueFromSecret{SecretKeyRef: kedav1alpha1.SecretKeyRef{Name: "approle", Key: "missing"}},
+				},
+			},
+			isError: true,
+		},
+		{
+			name: "jwt on a custom mount",
+			vault: kedav1alpha1.HashiCorpVault{
+				Authentication: kedav1alpha1.VaultAuthenticationJWT,
+				Role:           "keda",
+				Mount:          "oidc",
+				Credential:     &kedav1alpha1.Credential{ServiceAccount: jwtPath},
+			},
+			expectedPath:  "/v1/auth/oidc/login",
+			expectedLogin: map[string]interface{}{"jwt": "projected-jwt", "role": "keda"},
+		},
+		{
+			name: "jwt without role",
+			vault: kedav1alpha1.HashiCorpVault{
+				Authentication: kedav1alpha1.VaultAuthenticationJWT,
+				Credential:     &kedav1alpha1.Credential{ServiceAccount: jwtPath},
+			},
+			isError: true,
+		},
+		{
+			name:    "cert without secret",
+			vault:   kedav1alpha1.HashiCorpVault{Authentication: kedav1alpha1.VaultAuthenticationCert},
+			isError: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			var path string
+			var login map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				if err := json.NewDecoder(r.Body).Decode(&login); err != nil {
+					t.Errorf("Unexpected login body: %s", err)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"auth": {"client_token": "client-token"}}`)
+			}))
+			defer server.Close()
+
+			test.vault.Address = server.URL
+			handler := NewHashicorpVaultHandler(&test.vault)
+			handler.readSecret = readSecret
+			config := vaultapi.DefaultConfig()
+			if test.vault.Authentication == kedav1alpha1.VaultAuthenticationCert {
+				if err := handler.configureClientCert(config); err == nil {
+					t.Fatal("Expected an error configuring the client certificate")
+				}
+				return
+			}
+			client, err := vaultapi.NewClient(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := client.SetAddress(server.URL); err != nil {
+				t.Fatal(err)
+			}
+
+			token, err := handler.token(client)
+			if test.isError {
+				if err == nil {
+					t.Errorf("Expected an error, got token %q", token)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if token != "client-token" {
+				t.Errorf("Expected the client token, got %q", token)
+			}
+			if path != test.expectedPath {
+				t.Errorf("Expected a login at %s, got %s", test.expectedPath, path)
+			}
+			if diff := cmp.Diff(test.expectedLogin, login); diff != "" {
+				t.Errorf("Login data is different: %s", diff)
+			}
+		})
+	}
+}
@@ -22,10 +22,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/pkg/apis/duck"
@@ -41,6 +43,10 @@ const (
 	referenceCloser   = ')'
 )
 
+// TriggerDefaultsConfigMapName is the name of the optional ConfigMap holding the default trigger metadata of a namespace,
+// its keys are <trigger type>.<parameter>, eg. kafka.bootstrapServers
+const TriggerDefaultsConfigMapName = "keda-trigger-defaults"
+
 // ResolveScaleTargetPodSpec for given scalableObject inspects the scale target workload,
 // which could be almost any k8s resource (Deployment, StatefulSet, CustomResource...)
 // and for the given resource returns *corev1.PodTemplateSpec and a name of the container
@@ -129,113 +135,318 @@ func ResolveContainerEnv(ctx context.Context, client client.Client, logger logr.
 	return resolveEnv(ctx, client, logger, &container, namespace)
 }
 
-// ResolveAuthRefAndPodIdentity provides authentication parameters and pod identity needed authenticate scaler with the environment.
-func ResolveAuthRefAndPodIdentity(ctx context.Context, client client.Client, logger logr.Logger, triggerAuthRef *kedav1alpha1.ScaledObjectAuthRef, podTemplateSpec *corev1.PodTemplateSpec, namespace string) (map[string]string, kedav1alpha1.PodIdentityProvider, error) {
-	if podTemplateSpec != nil {
-		authParams, podIdentity := resolveAuthRef(ctx, client, logger, triggerAuthRef, &podTemplateSpec.Spec, namespace)
+// ResolveTriggerMetadata merges the metadata of the trigger from the defaults of the namespace, the ConfigMaps referenced
+// in metadataFrom in their order and the inline metadata, later sources take precedence over earlier ones.
+func ResolveTriggerMetadata(ctx context.Context, client client.Client, trigger kedav1alpha1.ScaleTriggers, namespace string) (map[string]string, error) {
+	result := make(map[string]string)
+
+	defaults, err := resolveOptionalConfigMap(ctx, client, TriggerDefaultsConfigMapName, namespace, true)
+	if err != nil {
+		return nil, fmt.Errorf("error reading trigger defaults from ConfigMap %s: %s", TriggerDefaultsConfigMapName, err)
+	}
+	prefix := trigger.Type + "."
+	for key, value := range defaults {
+		if strings.HasPrefix(key, prefix) && len(key) > len(prefix) {
+			result[key[len(prefix):]] = value
+		}
+	}
 
-		if podIdentity == kedav1alpha1.PodIdentityProviderAwsEKS {
-			serviceAccountName := podTemplateSpec.Spec.ServiceAccountName
-			serviceAccount := &corev1.ServiceAccount{}
-			err := client.Get(ctx, types.NamespacedName{Name: serviceAccountName, Namespace: namespace}, serviceAccount)
+	for i, source := range trigger.MetadataFrom {
+		switch {
+		case source.ConfigMapRef != nil && source.ConfigMapKeyRef != nil, source.ConfigMapRef == nil && source.ConfigMapKeyRef == nil:
+			return nil, fmt.Errorf("metadataFrom[%d] must set exactly one of configMapRef and configMapKeyRef", i)
+		case source.ConfigMapRef != nil:
+			data, err := resolveOptionalConfigMap(ctx, client, source.ConfigMapRef.Name, namespace, source.ConfigMapRef.Optional)
 			if err != nil {
-				return nil, kedav1alpha1.PodIdentityProviderNone, fmt.Errorf("error getting service account: %s", err)
+				return nil, fmt.Errorf("error reading trigger metadata from ConfigMap %s: %s", source.ConfigMapRef.Name, err)
+			}
+			for key, value := range data {
+				result[key] = value
+			}
+		default:
+			keyRef := source.ConfigMapKeyRef
+			data, err := resolveOptionalConfigMap(ctx, client, keyRef.Name, namespace, keyRef.Optional)
+			if err != nil {
+				return nil, fmt.Errorf("error reading trigger metadata parameter %s from ConfigMap %s: %s", keyRef.Parameter, keyRef.Name, err)
+			}
+			value, ok := data[keyRef.Key]
+			if !ok {
+				if keyRef.Optional {
+					continue
+				}
+				return nil, fmt.Errorf("error reading trigger metadata parameter %s: key %s not found in ConfigMap %s", keyRef.Parameter, keyRef.Key, keyRef.Name)
 			}
-			authParams["awsRoleArn"] = serviceAccount.Annotations[kedav1alpha1.PodIdentityAnnotationEKS]
-		} else if podIdentity == kedav1alpha1.PodIdentityProviderAwsKiam {
-			authParams["awsRoleArn"] = podTemplateSpec.ObjectMeta.Annotations[kedav1alpha1.PodIdentityAnnotationKiam]
+			result[keyRef.Parameter] = value
 		}
-		return authParams, podIdentity, nil
 	}
 
-	authParams, _ := resolveAuthRef(ctx, client, logger, triggerAuthRef, nil, namespace)
-	return authParams, kedav1alpha1.PodIdentityProviderNone, nil
+	for key, value := range trigger.Metadata {
+		result[key] = value
+	}
+	return result, nil
+}
+
+// resolveOptionalConfigMap returns the data of the ConfigMap, a missing optional ConfigMap has no data
+func resolveOptionalConfigMap(ctx context.Context, client client.Client, name, namespace string, optional bool) (map[string]string, error) {
+	data, err := resolveConfigMap(ctx, client, &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}}, namespace)
+	if err != nil && optional && apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// ResolveAuthRefAndPodIdentity provides authentication parameters and pod identity needed authenticate scaler with the environment.
+// An *AuthResolutionError is returned when the referenced authentication or any of its parameters that aren't optional can't be resolved.
+func ResolveAuthRefAndPodIdentity(ctx context.Context, client client.Client, logger logr.Logger, triggerAuthRef *kedav1alpha1.ScaledObjectAuthRef, podTemplateSpec *corev1.PodTemplateSpec, namespace string) (map[string]string, kedav1alpha1.PodIdentityProvider, error) {
+	authParams, podIdentity, err := resolveAuthRef(ctx, client, logger, triggerAuthRef, podTemplateSpec, namespace)
+	if err != nil {
+		return nil, kedav1alpha1.PodIdentityProviderNone, err
+	}
+	if podTemplateSpec == nil && podIdentity != kedav1alpha1.PodIdentityProviderSpiffe {
+		// the X.509 SVID is the identity of KEDA, the other pod identities are the ones of the pods of the scale target
+		return authParams, kedav1alpha1.PodIdentityProviderNone, nil
+	}
+	return authParams, podIdentity, nil
 }
 
 // resolveAuthRef provides authentication parameters needed authenticate scaler with the environment.
 // based on authentication method defined in TriggerAuthentication, authParams and podIdentity is returned
-func resolveAuthRef(ctx context.Context, client client.Client, logger logr.Logger, triggerAuthRef *kedav1alpha1.ScaledObjectAuthRef, podSpec *corev1.PodSpec, namespace string) (map[string]string, kedav1alpha1.PodIdentityProvider) {
+func resolveAuthRef(ctx context.Context, client client.Client, logger logr.Logger, triggerAuthRef *kedav1alpha1.ScaledObjectAuthRef, podTemplateSpec *corev1.PodTemplateSpec, namespace string) (map[string]string, kedav1alpha1.PodIdentityProvider, error) {
 	result := make(map[string]string)
 	var podIdentity kedav1alpha1.PodIdentityProvider
 
-	if namespace != "" && triggerAuthRef != nil && triggerAuthRef.Name != "" {
-		triggerAuthSpec, triggerNamespace, err := getTriggerAuthSpec(ctx, client, triggerAuthRef, namespace)
+	if namespace == "" || triggerAuthRef == nil || triggerAuthRef.Name == "" {
+		return result, podIdentity, nil
+	}
+
+	kind := triggerAuthRef.Kind
+	if kind == "" {
+		kind = "TriggerAuthentication"
+	}
+	triggerAuthSpec, triggerNamespace, err := getTriggerAuthSpec(ctx, client, triggerAuthRef, namespace)
+	if err != nil {
+		return nil, podIdentity, &AuthResolutionError{Kind: kind, Name: triggerAuthRef.Name, Err: err}
+	}
+	if triggerAuthSpec.PodIdentity != nil {
+		podIdentity = triggerAuthSpec.PodIdentity.Provider
+	}
+
+	var podSpec *corev1.PodSpec
+	var awsRoleArn string
+	if podTemplateSpec != nil {
+		podSpec = &podTemplateSpec.Spec
+		awsRoleArn, err = resolveAwsRoleArn(ctx, client, podIdentity, podTemplateSpec, namespace)
 		if err != nil {
-			logger.Error(err, "Error getting triggerAuth", "triggerAuthRef.Name", triggerAuthRef.Name)
-		} else {
-			if triggerAuthSpec.PodIdentity != nil {
-				podIdentity = triggerAuthSpec.PodIdentity.Provider
+			return nil, podIdentity, &AuthResolutionError{Kind: kind, Name: triggerAuthRef.Name, Err: err}
+		}
+	}
+
+	failed := resolveAuthEnv(ctx, client, logger, triggerAuthSpec.Env, podSpec, namespace, result)
+	failed = append(failed, resolveAuthSecrets(ctx, client, logger, triggerAuthSpec, podIdentity, awsRoleArn, triggerNamespace, result)...)
+	if len(failed) > 0 {
+		return nil, podIdentity, &AuthResolutionError{Kind: kind, Name: triggerAuthRef.Name, Parameters: failed}
+	}
+	if podTemplateSpec != nil && isAwsPodIdentity(podIdentity) {
+		result["awsRoleArn"] = awsRoleArn
+	}
+	return result, podIdentity, nil
+}
+
+// resolveAwsRoleArn returns the role ARN of the aws-eks or aws-kiam pod identity of the scale target, read from the annotation
+// of its ServiceAccount or of its pods. It is empty for the other pod identities.
+func resolveAwsRoleArn(ctx context.Context, client client.Client, podIdentity kedav1alpha1.PodIdentityProvider, podTemplateSpec *corev1.PodTemplateSpec, namespace string) (string, error) {
+	switch podIdentity {
+	case kedav1alpha1.PodIdentityProviderAwsEKS:
+		serviceAccount := &corev1.ServiceAccount{}
+		err := client.Get(ctx, types.NamespacedName{Name: podTemplateSpec.Spec.ServiceAccountName, Namespace: namespace}, serviceAccount)
+		if err != nil {
+			return "", fmt.Errorf("error getting the service account of the %s pod identity: %s", podIdentity, err)
+		}
+		return serviceAccount.Annotations[kedav1alpha1.PodIdentityAnnotationEKS], nil
+	case kedav1alpha1.PodIdentityProviderAwsKiam:
+		return podTemplateSpec.ObjectMeta.Annotations[kedav1alpha1.PodIdentityAnnotationKiam], nil
+	default:
+		return "", nil
+	}
+}
+
+func isAwsPodIdentity(podIdentity kedav1alpha1.PodIdentityProvider) bool {
+	return podIdentity == kedav1alpha1.PodIdentityProviderAwsEKS || podIdentity == kedav1alpha1.PodIdentityProviderAwsKiam
+}
+
+// resolveAuthEnv resolves the env parameters of a TriggerAuthentication from the containers of the scale target into result,
+// the parameters that can't be resolved and aren't optional are returned
+func resolveAuthEnv(ctx context.Context, client client.Client, logger logr.Logger, envs []kedav1alpha1.AuthEnvironment, podSpec *corev1.PodSpec, namespace string, result map[string]string) []*ParameterResolutionError {
+	var failed []*ParameterResolutionError
+	fail := func(e kedav1alpha1.AuthEnvironment, err error) {
+		if e.Optional {
+			logger.V(1).Info("Skipping optional parameter", "parameter", e.Parameter, "reason", err.Error())
+			return
+		}
+		failed = append(failed, &ParameterResolutionError{Parameter: e.Parameter, Source: AuthSourceEnv, Err: err})
+	}
+
+	for _, e := range envs {
+		if podSpec == nil {
+			fail(e, fmt.Errorf("the scale target has no containers to read env %s from", e.Name))
+			continue
+		}
+		env, err := ResolveContainerEnv(ctx, client, logger, podSpec, e.ContainerName, namespace)
+		if err != nil {
+			fail(e, fmt.Errorf("error reading env %s: %s", e.Name, err))
+			continue
+		}
+		value, ok := env[e.Name]
+		if !ok {
+			fail(e, fmt.Errorf("env %s not found in the scale target container", e.Name))
+			continue
+		}
+		result[e.Parameter] = value
+	}
+	return failed
+}
+
+// resolveAuthSecrets resolves the secretTargetRef, configMapTargetRef, HashiCorp Vault, Azure Key Vault, AWS and GCP parameters of a TriggerAuthentication into result,
+// the Secrets and ConfigMaps are read from the passed namespace, the parameters that can't be resolved and aren't optional are returned.
+// awsRoleArn is the role of the aws-eks or aws-kiam pod identity of the scale target, the AWS sources assume it.
+func resolveAuthSecrets(ctx context.Context, client client.Client, logger logr.Logger, spec *kedav1alpha1.TriggerAuthenticationSpec, podIdentity kedav1alpha1.PodIdentityProvider, awsRoleArn string, namespace string, result map[string]string) []*ParameterResolutionError {
+	var failed []*ParameterResolutionError
+	fail := func(parameter string, source AuthSource, optional bool, err error) {
+		if optional {
+			logger.V(1).Info("Skipping optional parameter", "parameter", parameter, "reason", err.Error())
+			return
+		}
+		failed = append(failed, &ParameterResolutionError{Parameter: parameter, Source: source, Err: err})
+	}
+
+	for _, e := range spec.SecretTargetRef {
+		value, err := readAuthSecret(ctx, client, e.Name, namespace, e.Key)
+		if err != nil {
+			fail(e.Parameter, AuthSourceSecret, e.Optional, err)
+			continue
+		}
+		result[e.Parameter] = value
+	}
+
+	for _, e := range spec.ConfigMapTargetRef {
+		value, err := readAuthConfigMap(ctx, client, e.Name, namespace, e.Key)
+		if err != nil {
+			fail(e.Parameter, AuthSourceConfigMap, e.Optional, err)
+			continue
+		}
+		result[e.Parameter] = value
+	}
+
+	if spec.HashiCorpVault != nil && len(spec.HashiCorpVault.Secrets) > 0 {
+		vault := vaultClients.get(spec.HashiCorpVault, namespace)
+		readSecret := func(name, key string) (string, error) {
+			return readAuthSecret(ctx, client, name, namespace, key)
+		}
+		recorder, recording := client.(DependencyRecorder)
+		for _, e := range spec.HashiCorpVault.Secrets {
+			if recording {
+				recorder.RecordDependency(HashiCorpVaultSecretDependencyKind, "", vaultSecretDependency(spec.HashiCorpVault.Address, e.Path))
 			}
-			if triggerAuthSpec.Env != nil {
-				for _, e := range triggerAuthSpec.Env {
-					if podSpec == nil {
-						result[e.Parameter] = ""
-						continue
-					}
-					env, err := ResolveContainerEnv(ctx, client, logger, podSpec, e.ContainerName, namespace)
-					if err != nil {
-						result[e.Parameter] = ""
-					} else {
-						result[e.Parameter] = env[e.Name]
-					}
+			secret, err := vault.Read(logger, readSecret, e.Path, e.Version)
+			switch {
+			case err != nil:
+				fail(e.Parameter, AuthSourceHashiCorpVault, e.Optional, fmt.Errorf("error reading HashiCorp Vault %s path %s: %s", spec.HashiCorpVault.Address, e.Path, err))
+			case secret == nil:
+				// sometimes there is no error, but `vault.Read(e.Path)` is not being able to parse the secret and returns nil
+				fail(e.Parameter, AuthSourceHashiCorpVault, e.Optional, fmt.Errorf("no secret found at HashiCorp Vault path %s", e.Path))
+			default:
+				value, err := resolveVaultSecret(secret.Data, e.Key)
+				if err != nil {
+					fail(e.Parameter, AuthSourceHashiCorpVault, e.Optional, fmt.Errorf("error reading HashiCorp Vault path %s: %s", e.Path, err))
+					continue
 				}
+				result[e.Parameter] = value
 			}
-			if triggerAuthSpec.SecretTargetRef != nil {
-				for _, e := range triggerAuthSpec.SecretTargetRef {
-					result[e.Parameter] = resolveAuthSecret(ctx, client, logger, e.Name, triggerNamespace, e.Key)
-				}
+		}
+	}
+
+	if spec.AzureKeyVault != nil && len(spec.AzureKeyVault.Secrets) > 0 {
+		vaultHandler := NewAzureKeyVaultHandler(spec.AzureKeyVault, podIdentity)
+		if err := vaultHandler.Initialize(ctx, client, logger, namespace); err != nil {
+			err = fmt.Errorf("error authenticating to Azure Key Vault %s: %s", spec.AzureKeyVault.VaultURI, err)
+			for _, secret := range spec.AzureKeyVault.Secrets {
+				fail(secret.Parameter, AuthSourceAzureKeyVault, secret.Optional, err)
 			}
-			if triggerAuthSpec.HashiCorpVault != nil && len(triggerAuthSpec.HashiCorpVault.Secrets) > 0 {
-				vault := NewHashicorpVaultHandler(triggerAuthSpec.HashiCorpVault)
-				err := vault.Initialize(logger)
+		} else {
+			for _, secret := range spec.AzureKeyVault.Secrets {
+				res, err := vaultHandler.Read(ctx, secret.Name, secret.Version)
 				if err != nil {
-					logger.Error(err, "Error authenticate to Vault", "triggerAuthRef.Name", triggerAuthRef.Name)
-				} else {
-					for _, e := range triggerAuthSpec.HashiCorpVault.Secrets {
-						secret, err := vault.Read(e.Path)
-						if err != nil {
-							logger.Error(err, "Error trying to read secret from Vault", "triggerAuthRef.Name", triggerAuthRef.Name,
-								"secret.path", e.Path)
-						} else {
-							if secret == nil {
-								// sometimes there is no error, but `vault.Read(e.Path)` is not being able to parse the secret and returns nil
-								logger.Error(fmt.Errorf("unable to parse secret, is the provided path correct?"), "Error trying to read secret from Vault",
-									"triggerAuthRef.Name", triggerAuthRef.Name, "secret.path", e.Path)
-							} else {
-								result[e.Parameter] = resolveVaultSecret(logger, secret.Data, e.Key)
-							}
-						}
-					}
+					fail(secret.Parameter, AuthSourceAzureKeyVault, secret.Optional, fmt.Errorf("error reading Azure Key Vault secret %s: %s", secret.Name, err))
+					continue
+				}
+				result[secret.Parameter] = res
+			}
+		}
+	}
 
-					vault.Stop()
+	if spec.AwsSecretManager != nil && len(spec.AwsSecretManager.Secrets) > 0 {
+		handler := NewAwsSecretManagerHandler(spec.AwsSecretManager, podIdentity, awsRoleArn)
+		if err := handler.Initialize(ctx, client, namespace); err != nil {
+			err = fmt.Errorf("error authenticating to AWS Secrets Manager: %s", err)
+			for _, secret := range spec.AwsSecretManager.Secrets {
+				fail(secret.Parameter, AuthSourceAwsSecretManager, secret.Optional, err)
+			}
+		} else {
+			for _, secret := range spec.AwsSecretManager.Secrets {
+				res, err := handler.Read(ctx, secret)
+				if err != nil {
+					fail(secret.Parameter, AuthSourceAwsSecretManager, secret.Optional, fmt.Errorf("error reading AWS Secrets Manager secret %s: %s", secret.Name, err))
+					continue
 				}
+				result[secret.Parameter] = res
+			}
+		}
+	}
+
+	if spec.AwsParameterStore != nil && len(spec.AwsParameterStore.Parameters) > 0 {
+		handler := NewAwsParameterStoreHandler(spec.AwsParameterStore, podIdentity, awsRoleArn)
+		if err := handler.Initialize(ctx, client, namespace); err != nil {
+			err = fmt.Errorf("error authenticating to AWS Parameter Store: %s", err)
+			for _, parameter := range spec.AwsParameterStore.Parameters {
+				fail(parameter.Parameter, AuthSourceAwsParameterStore, parameter.Optional, err)
 			}
-			if triggerAuthSpec.AzureKeyVault != nil && len(triggerAuthSpec.AzureKeyVault.Secrets) > 0 {
-				vaultHandler := NewAzureKeyVaultHandler(triggerAuthSpec.AzureKeyVault, podIdentity)
-				err := vaultHandler.Initialize(ctx, client, logger, triggerNamespace)
+		} else {
+			for _, parameter := range spec.AwsParameterStore.Parameters {
+				res, err := handler.Read(ctx, parameter.Name)
 				if err != nil {
-					logger.Error(err, "Error authenticating to Azure Key Vault", "triggerAuthRef.Name", triggerAuthRef.Name)
-				} else {
-					for _, secret := range triggerAuthSpec.AzureKeyVault.Secrets {
-						res, err := vaultHandler.Read(ctx, secret.Name, secret.Version)
-						if err != nil {
-							logger.Error(err, "Error trying to read secret from Azure Key Vault", "triggerAuthRef.Name", triggerAuthRef.Name,
-								"secret.Name", secret.Name, "secret.Version", secret.Version)
-						} else {
-							result[secret.Parameter] = res
-						}
-					}
+					fail(parameter.Parameter, AuthSourceAwsParameterStore, parameter.Optional, fmt.Errorf("error reading AWS Parameter Store parameter %s: %s", parameter.Name, err))
+					continue
 				}
+				result[parameter.Parameter] = res
 			}
 		}
 	}
 
-	return result, podIdentity
+	if spec.GcpSecretManager != nil && len(spec.GcpSecretManager.Secrets) > 0 {
+		handler := NewGcpSecretManagerHandler(spec.GcpSecretManager, podIdentity)
+		if err := handler.Initialize(ctx, client, namespace); err != nil {
+			err = fmt.Errorf("error authenticating to GCP Secret Manager: %s", err)
+			for _, secret := range spec.GcpSecretManager.Secrets {
+				fail(secret.Parameter, AuthSourceGcpSecretManager, secret.Optional, err)
+			}
+		} else {
+			for _, secret := range spec.GcpSecretManager.Secrets {
+				res, err := handler.Read(ctx, secret.Name)
+				if err != nil {
+					fail(secret.Parameter, AuthSourceGcpSecretManager, secret.Optional, fmt.Errorf("error reading GCP Secret Manager secret %s: %s", secret.Name, err))
+					continue
+				}
+				result[secret.Parameter] = res
+			}
+		}
+	}
+	return failed
 }
 
 var clusterObjectNamespaceCache *string
 
-func getClusterObjectNamespace() (string, error) {
+// GetClusterObjectNamespace returns the namespace the Secrets of ClusterTriggerAuthentications are read from
+func GetClusterObjectNamespace() (string, error) {
 	// Check if a cached value is available.
 	if clusterObjectNamespaceCache != nil {
 		return *clusterObjectNamespaceCache, nil
@@ -263,7 +474,7 @@ func getTriggerAuthSpec(ctx context.Context, client client.Client, triggerAuthRe
 		}
 		return &triggerAuth.Spec, namespace, nil
 	} else if triggerAuthRef.Kind == "ClusterTriggerAuthentication" {
-		clusterNamespace, err := getClusterObjectNamespace()
+		clusterNamespace, err := GetClusterObjectNamespace()
 		if err != nil {
 			return nil, "", err
 		}
@@ -443,38 +654,63 @@ func resolveConfigValue(ctx context.Context, client client.Client, configKeyRef
 }
 
 func resolveAuthSecret(ctx context.Context, client client.Client, logger logr.Logger, name, namespace, key string) string {
-	if name == "" || namespace == "" || key == "" {
-		logger.Error(fmt.Errorf("error trying to get secret"), "name, namespace and key are required", "Secret.Namespace", namespace, "Secret.Name", name, "key", key)
+	value, err := readAuthSecret(ctx, client, name, namespace, key)
+	if err != nil {
+		logger.Error(err, "Error trying to get secret", "Secret.Namespace", namespace, "Secret.Name", name, "key", key)
 		return ""
 	}
+	return value
+}
+
+// readAuthSecret returns the value of the key of the Secret, a missing key is an error
+func readAuthSecret(ctx context.Context, client client.Client, name, namespace, key string) (string, error) {
+	if name == "" || namespace == "" || key == "" {
+		return "", fmt.Errorf("name, namespace and key of the secret are required")
+	}
 
 	secret := &corev1.Secret{}
 	err := client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret)
 	if err != nil {
-		logger.Error(err, "Error trying to get secret from namespace", "Secret.Namespace", namespace, "Secret.Name", name)
-		return ""
+		return "", fmt.Errorf("error reading secret %s/%s: %s", namespace, name, err)
+	}
+	result, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in secret %s/%s", key, namespace, name)
 	}
-	result := secret.Data[key]
+	return string(result), nil
+}
 
-	if result == nil {
-		return ""
+func readAuthConfigMap(ctx context.Context, client client.Client, name, namespace, key string) (string, error) {
+	if name == "" || namespace == "" || key == "" {
+		return "", fmt.Errorf("name, namespace and key of the config map are required")
 	}
 
-	return string(result)
+	configMap := &corev1.ConfigMap{}
+	err := client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, configMap)
+	if err != nil {
+		return "", fmt.Errorf("error reading config map %s/%s: %s", namespace, name, err)
+	}
+	if result, ok := configMap.Data[key]; ok {
+		return result, nil
+	}
+	if result, ok := configMap.BinaryData[key]; ok {
+		return string(result), nil
+	}
+	return "", fmt.Errorf("key %s not found in config map %s/%s", key, namespace, name)
 }
 
-func resolveVaultSecret(logger logr.Logger, data map[string]interface{}, key string) string {
+// resolveVaultSecret returns the key of a KV v2 secret, which nests its keys under data, or of a dynamic secret
+func resolveVaultSecret(data map[string]interface{}, key string) (string, error) {
 	if v2Data, ok := data["data"].(map[string]interface{}); ok {
-		if value, ok := v2Data[key]; ok {
-			if s, ok := value.(string); ok {
-				return s
-			}
-		} else {
-			logger.Error(fmt.Errorf("key '%s' not found", key), "Error trying to get key from Vault secret")
-			return ""
-		}
+		data = v2Data
 	}
-
-	logger.Error(fmt.Errorf("unable to convert Vault Data value"), "Error trying to convert Data secret vaule")
-	return ""
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("key '%s' not found", key)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("value of key '%s' isn't a string", key)
+	}
+	return s, nil
 }
@@ -174,20 +174,21 @@ func TestResolveAuthRef(t *testing.T) {
 		podSpec             *corev1.PodSpec
 		expected            map[string]string
 		expectedPodIdentity kedav1alpha1.PodIdentityProvider
+		expectedError       string
 	}{
 		{
 			name:     "foo",
 			expected: make(map[string]string),
 		},
 		{
-			name:     "no triggerauth exists",
-			soar:     &kedav1alpha1.ScaledObjectAuthRef{Name: "notthere"},
-			expected: make(map[string]string),
+			name:          "no triggerauth exists",
+			soar:          &kedav1alpha1.ScaledObjectAuthRef{Name: "notthere"},
+			expectedError: `error reading TriggerAuthentication notthere: triggerauthentications.keda.sh "notthere" not found`,
 		},
 		{
-			name:     "no triggerauth exists",
-			soar:     &kedav1alpha1.ScaledObjectAuthRef{Name: "notthere"},
-			expected: make(map[string]string),
+			name:          "no clustertriggerauth exists",
+			soar:          &kedav1alpha1.ScaledObjectAuthRef{Name: "notthere", Kind: "ClusterTriggerAuthentication"},
+			expectedError: `error reading ClusterTriggerAuthentication notthere: clustertriggerauthentications.keda.sh "notthere" not found`,
 		},
 		{
 			name: "triggerauth exists, podidentity nil",
@@ -208,8 +209,8 @@ func TestResolveAuthRef(t *testing.T) {
 					},
 				},
 			},
-			soar:     &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName},
-			expected: map[string]string{"host": ""},
+			soar:          &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName},
+			expectedError: `error resolving TriggerAuthentication triggerauth: parameter host: error reading secret test-namespace/supersecret: secrets "supersecret" not found`,
 		},
 		{
 			name: "triggerauth exists and secret",
@@ -243,6 +244,53 @@ func TestResolveAuthRef(t *testing.T) {
 			expected:            map[string]string{"host": secretData},
 			expectedPodIdentity: kedav1alpha1.PodIdentityProviderNone,
 		},
+		{
+			name: "triggerauth exists and config map",
+			existing: []runtime.Object{
+				&kedav1alpha1.TriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      triggerAuthenticationName,
+					},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+							{
+								Parameter: "host",
+								Name:      secretName,
+								Key:       secretKey,
+							},
+						},
+						ConfigMapTargetRef: []kedav1alpha1.AuthConfigMapTargetRef{
+							{
+								Parameter: "ca",
+								Name:      "trust-bundle",
+								Key:       "ca.crt",
+							},
+							{
+								Parameter: "caFile",
+								Name:      "trust-bundle",
+								Key:       "missing",
+								Optional:  true,
+							},
+						},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      secretName,
+					},
+					Data: map[string][]byte{secretKey: []byte(secretData)}},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "trust-bundle",
+					},
+					Data: map[string]string{"ca.crt": "bundle"}},
+			},
+			soar:     &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName},
+			expected: map[string]string{"host": secretData, "ca": "bundle"},
+		},
 		{
 			name: "clustertriggerauth exists, podidentity nil",
 			existing: []runtime.Object{
@@ -261,8 +309,8 @@ func TestResolveAuthRef(t *testing.T) {
 					},
 				},
 			},
-			soar:     &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
-			expected: map[string]string{"host": ""},
+			soar:          &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
+			expectedError: `error resolving ClusterTriggerAuthentication triggerauth: parameter host: error reading secret keda/supersecret: secrets "supersecret" not found`,
 		},
 		{
 			name: "clustertriggerauth exists and secret",
@@ -322,9 +370,127 @@ func TestResolveAuthRef(t *testing.T) {
 					},
 					Data: map[string][]byte{secretKey: []byte(secretData)}},
 			},
-			soar:                &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
-			expected:            map[string]string{"host": ""},
-			expectedPodIdentity: kedav1alpha1.PodIdentityProviderNone,
+			soar:          &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
+			expectedError: `error resolving ClusterTriggerAuthentication triggerauth: parameter host: error reading secret keda/supersecret: secrets "supersecret" not found`,
+		},
+		{
+			name: "triggerauth exists and secret key is missing",
+			existing: []runtime.Object{
+				&kedav1alpha1.TriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      triggerAuthenticationName,
+					},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+							{
+								Parameter: "host",
+								Name:      secretName,
+								Key:       secretKey,
+							},
+							{
+								Parameter: "password",
+								Name:      secretName,
+								Key:       "password",
+							},
+						},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      secretName,
+					},
+					Data: map[string][]byte{secretKey: []byte(secretData)}},
+			},
+			soar:          &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName},
+			expectedError: "error resolving TriggerAuthentication triggerauth: parameter password: key password not found in secret test-namespace/supersecret",
+		},
+		{
+			name: "triggerauth exists and optional parameters are missing",
+			existing: []runtime.Object{
+				&kedav1alpha1.TriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      triggerAuthenticationName,
+					},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+							{
+								Parameter: "host",
+								Name:      secretName,
+								Key:       secretKey,
+							},
+							{
+								Parameter: "password",
+								Name:      secretName,
+								Key:       "password",
+								Optional:  true,
+							},
+						},
+						Env: []kedav1alpha1.AuthEnvironment{
+							{
+								Parameter: "username",
+								Name:      "USERNAME",
+								Optional:  true,
+							},
+						},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      secretName,
+					},
+					Data: map[string][]byte{secretKey: []byte(secretData)}},
+			},
+			soar:     &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName},
+			podSpec:  &corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+			expected: map[string]string{"host": secretData},
+		},
+		{
+			name: "triggerauth exists and env is missing",
+			existing: []runtime.Object{
+				&kedav1alpha1.TriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      triggerAuthenticationName,
+					},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						Env: []kedav1alpha1.AuthEnvironment{
+							{
+								Parameter: "host",
+								Name:      envKey,
+							},
+							{
+								Parameter: "username",
+								Name:      "USERNAME",
+							},
+						},
+					},
+				},
+			},
+			soar:          &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName},
+			podSpec:       &corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Env: []corev1.EnvVar{{Name: envKey, Value: envValue}}}}},
+			expectedError: "error resolving TriggerAuthentication triggerauth: parameter username: env USERNAME not found in the scale target container",
+		},
+		{
+			name: "triggerauth exists and the service account of the aws-eks pod identity is missing",
+			existing: []runtime.Object{
+				&kedav1alpha1.TriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      triggerAuthenticationName,
+					},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						PodIdentity: &kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAwsEKS},
+					},
+				},
+			},
+			soar:    &kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName},
+			podSpec: &corev1.PodSpec{ServiceAccountName: "app", Containers: []corev1.Container{{Name: "app"}}},
+			expectedError: `error reading TriggerAuthentication triggerauth: error getting the service account of the aws-eks pod identity: ` +
+				`serviceaccounts "app" not found`,
 		},
 	}
 	for _, test := range tests {
@@ -332,13 +498,29 @@ func TestResolveAuthRef(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			ctx := context.Background()
 			clusterObjectNamespaceCache = &clusterNamespace // Inject test cluster namespace.
-			gotMap, gotPodIdentity := resolveAuthRef(
+			var podTemplateSpec *corev1.PodTemplateSpec
+			if test.podSpec != nil {
+				podTemplateSpec = &corev1.PodTemplateSpec{Spec: *test.podSpec}
+			}
+			gotMap, gotPodIdentity, err := resolveAuthRef(
 				ctx,
 				fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(test.existing...).Build(),
 				logf.Log.WithName("test"),
 				test.soar,
-				test.podSpec,
+				podTemplateSpec,
 				namespace)
+			if test.expectedError != "" {
+				if err == nil || err.Error() != test.expectedError {
+					t.Fatalf("Expected error %q, got %v", test.expectedError, err)
+				}
+				if !IsAuthResolutionError(err) {
+					t.Errorf("Expected an AuthResolutionError, got %T", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
 			if diff := cmp.Diff(gotMap, test.expected); diff != "" {
 				t.Errorf("Returned authParams are different: %s", diff)
 			}
@@ -463,3 +645,182 @@ func TestResolveDependentEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveTriggerMetadata(t *testing.T) {
+	configMap := func(name string, data map[string]string) runtime.Object {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Data: data}
+	}
+	defaults := configMap(TriggerDefaultsConfigMapName, map[string]string{
+		"kafka.bootstrapServers": "kafka:9092",
+		"kafka.lagThreshold":     "5",
+		"rabbitmq.queueName":     "queue",
+	})
+	shared := configMap("kafka-shared", map[string]string{"lagThreshold": "10", "topic": "shared"})
+
+	tests := []struct {
+		name     string
+		existing []runtime.Object
+		trigger  kedav1alpha1.ScaleTriggers
+		expected map[string]string
+		isError  bool
+	}{
+		{
+			name:     "inline metadata only",
+			trigger:  kedav1alpha1.ScaleTriggers{Type: "kafka", Metadata: map[string]string{"topic": "inline"}},
+			expected: map[string]string{"topic": "inline"},
+		},
+		{
+			name:     "namespace defaults of the trigger type",
+			existing: []runtime.Object{defaults},
+			trigger:  kedav1alpha1.ScaleTriggers{Type: "kafka", Metadata: map[string]string{"topic": "inline"}},
+			expected: map[string]string{"bootstrapServers": "kafka:9092", "lagThreshold": "5", "topic": "inline"},
+		},
+		{
+			name:     "metadataFrom overrides defaults and inline metadata overrides metadataFrom",
+			existing: []runtime.Object{defaults, shared},
+			trigger: kedav1alpha1.ScaleTriggers{
+				Type:     "kafka",
+				Metadata: map[string]string{"topic": "inline"},
+				MetadataFrom: []kedav1alpha1.TriggerMetadataSource{
+					{ConfigMapRef: &kedav1alpha1.TriggerMetadataConfigMapRef{Name: "kafka-shared"}},
+					{ConfigMapKeyRef: &kedav1alpha1.TriggerMetadataConfigMapKeyRef{Parameter: "consumerGroup", Name: "kafka-shared", Key: "topic"}},
+				},
+			},
+			expected: map[string]string{"bootstrapServers": "kafka:9092", "lagThreshold": "10", "topic": "inline", "consumerGroup": "shared"},
+		},
+		{
+			name: "missing optional sources are ignored",
+			trigger: kedav1alpha1.ScaleTriggers{
+				Type: "kafka",
+				MetadataFrom: []kedav1alpha1.TriggerMetadataSource{
+					{ConfigMapRef: &kedav1alpha1.TriggerMetadataConfigMapRef{Name: "missing", Optional: true}},
+					{ConfigMapKeyRef: &kedav1alpha1.TriggerMetadataConfigMapKeyRef{Parameter: "topic", Name: "missing", Key: "topic", Optional: true}},
+				},
+			},
+			expected: map[string]string{},
+		},
+		{
+			name: "missing ConfigMap",
+			trigger: kedav1alpha1.ScaleTriggers{
+				Type:         "kafka",
+				MetadataFrom: []kedav1alpha1.TriggerMetadataSource{{ConfigMapRef: &kedav1alpha1.TriggerMetadataConfigMapRef{Name: "missing"}}},
+			},
+			isError: true,
+		},
+		{
+			name:     "missing key",
+			existing: []runtime.Object{shared},
+			trigger: kedav1alpha1.ScaleTriggers{
+				Type: "kafka",
+				MetadataFrom: []kedav1alpha1.TriggerMetadataSource{
+					{ConfigMapKeyRef: &kedav1alpha1.TriggerMetadataConfigMapKeyRef{Parameter: "consumerGroup", Name: "kafka-shared", Key: "group"}},
+				},
+			},
+			isError: true,
+		},
+		{
+			name:    "source without reference",
+			trigger: kedav1alpha1.ScaleTriggers{Type: "kafka", MetadataFrom: []kedav1alpha1.TriggerMetadataSource{{}}},
+			isError: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(test.existing...).Build()
+			metadata, err := ResolveTriggerMetadata(context.TODO(), client, test.trigger, namespace)
+			if test.isError {
+				if err == nil {
+					t.Errorf("Expected an error, got metadata %v", metadata)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(test.expected, metadata); diff != "" {
+				t.Errorf("Returned metadata is different: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckTriggerAuthSpec(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: namespace},
+		Data:       map[string][]byte{secretKey: []byte(secretData)},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "trust-bundle", Namespace: namespace},
+		Data:       map[string]string{"ca.crt": "bundle"},
+	}
+
+	tests := []struct {
+		name     string
+		spec     kedav1alpha1.TriggerAuthenticationSpec
+		expected []string
+	}{
+		{
+			name: "resolvable secret",
+			spec: kedav1alpha1.TriggerAuthenticationSpec{
+				SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "host", Name: secretName, Key: secretKey}},
+			},
+		},
+		{
+			name: "missing secret",
+			spec: kedav1alpha1.TriggerAuthenticationSpec{
+				SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "host", Name: "missing", Key: secretKey}},
+			},
+			expected: []string{AuthReasonSecretNotResolvable},
+		},
+		{
+			name: "missing key",
+			spec: kedav1alpha1.TriggerAuthenticationSpec{
+				SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+					{Parameter: "host", Name: secretName, Key: secretKey},
+					{Parameter: "password", Name: secretName, Key: "missing"},
+				},
+			},
+			expected: []string{AuthReasonSecretNotResolvable},
+		},
+		{
+			name: "missing optional secret",
+			spec: kedav1alpha1.TriggerAuthenticationSpec{
+				SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "host", Name: "missing", Key: secretKey, Optional: true}},
+			},
+		},
+		{
+			name: "resolvable config map",
+			spec: kedav1alpha1.TriggerAuthenticationSpec{
+				ConfigMapTargetRef: []kedav1alpha1.AuthConfigMapTargetRef{{Parameter: "ca", Name: "trust-bundle", Key: "ca.crt"}},
+			},
+		},
+		{
+			name: "missing config map key",
+			spec: kedav1alpha1.TriggerAuthenticationSpec{
+				ConfigMapTargetRef: []kedav1alpha1.AuthConfigMapTargetRef{{Parameter: "ca", Name: "trust-bundle", Key: "missing"}},
+			},
+			expected: []string{AuthReasonConfigMapNotResolvable},
+		},
+		{
+			name: "unknown pod identity provider",
+			spec: kedav1alpha1.TriggerAuthenticationSpec{
+				PodIdentity: &kedav1alpha1.AuthPodIdentity{Provider: "unknown"},
+			},
+			expected: []string{AuthReasonPodIdentityInvalid},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(secret, configMap).Build()
+			var reasons []string
+			for _, problem := range CheckTriggerAuthSpec(context.TODO(), client, logf.Log.WithName("test"), &test.spec, namespace) {
+				reasons = append(reasons, problem.Reason)
+			}
+			if diff := cmp.Diff(test.expected, reasons); diff != "" {
+				t.Errorf("Returned problems are different: %s", diff)
+			}
+		})
+	}
+}
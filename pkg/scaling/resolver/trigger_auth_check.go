@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// Reasons of the Ready condition of a TriggerAuthentication that can't be resolved
+const (
+	AuthReasonPodIdentityInvalid             = "PodIdentityInvalid"
+	AuthReasonSecretNotResolvable            = "SecretNotResolvable"
+	AuthReasonConfigMapNotResolvable         = "ConfigMapNotResolvable"
+	AuthReasonHashiCorpVaultNotResolvable    = "HashiCorpVaultNotResolvable"
+	AuthReasonAzureKeyVaultNotResolvable     = "AzureKeyVaultNotResolvable"
+	AuthReasonAwsSecretManagerNotResolvable  = "AwsSecretManagerNotResolvable"
+	AuthReasonAwsParameterStoreNotResolvable = "AwsParameterStoreNotResolvable"
+	AuthReasonGcpSecretManagerNotResolvable  = "GcpSecretManagerNotResolvable"
+)
+
+// AuthProblem is a part of a TriggerAuthentication spec that can't be resolved
+type AuthProblem struct {
+	Reason  string
+	Message string
+}
+
+// CheckTriggerAuthSpec resolves the pod identity and the secrets of the spec and returns the problems found, the Secrets
+// and ConfigMaps are read from the passed namespace. The env parameters and the AWS sources read with the aws-eks or aws-kiam
+// pod identity depend on the scale target and aren't checked, neither are optional parameters.
+func CheckTriggerAuthSpec(ctx context.Context, client client.Client, logger logr.Logger, spec *kedav1alpha1.TriggerAuthenticationSpec, namespace string) []AuthProblem {
+	var problems []AuthProblem
+	podIdentity := kedav1alpha1.PodIdentityProviderNone
+	if spec.PodIdentity != nil {
+		podIdentity = spec.PodIdentity.Provider
+		if !podIdentity.IsValid() {
+			problems = append(problems, AuthProblem{
+				Reason:  AuthReasonPodIdentityInvalid,
+				Message: fmt.Sprintf("unknown pod identity provider %q", podIdentity),
+			})
+		}
+	}
+
+	if isAwsPodIdentity(podIdentity) {
+		spec = withoutWorkloadAwsSources(spec)
+	}
+	for _, failed := range resolveAuthSecrets(ctx, client, logger, spec, podIdentity, "", namespace, map[string]string{}) {
+		problems = append(problems, AuthProblem{
+			Reason:  authProblemReasons[failed.Source],
+			Message: failed.Error(),
+		})
+	}
+	return problems
+}
+
+var authProblemReasons = map[AuthSource]string{
+	AuthSourceSecret:            AuthReasonSecretNotResolvable,
+	AuthSourceConfigMap:         AuthReasonConfigMapNotResolvable,
+	AuthSourceHashiCorpVault:    AuthReasonHashiCorpVaultNotResolvable,
+	AuthSourceAzureKeyVault:     AuthReasonAzureKeyVaultNotResolvable,
+	AuthSourceAwsSecretManager:  AuthReasonAwsSecretManagerNotResolvable,
+	AuthSourceAwsParameterStore: AuthReasonAwsParameterStoreNotResolvable,
+	AuthSourceGcpSecretManager:  AuthReasonGcpSecretManagerNotResolvable,
+}
+
+// withoutWorkloadAwsSources returns a copy of spec without the AWS sources that are read with the role of the scale target,
+// they have neither credentials nor a role ARN
+func withoutWorkloadAwsSources(spec *kedav1alpha1.TriggerAuthenticationSpec) *kedav1alpha1.TriggerAuthenticationSpec {
+	spec = spec.DeepCopy()
+	if sm := spec.AwsSecretManager; sm != nil && sm.Credentials == nil && sm.RoleArn == "" {
+		spec.AwsSecretManager = nil
+	}
+	if ps := spec.AwsParameterStore; ps != nil && ps.Credentials == nil && ps.RoleArn == "" {
+		spec.AwsParameterStore = nil
+	}
+	return spec
+}
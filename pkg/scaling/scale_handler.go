@@ -18,12 +18,18 @@ package scaling
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/scale"
@@ -33,10 +39,13 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/metrics"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache"
 	"github.com/kedacore/keda/v2/pkg/scaling/executor"
 	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
+	"github.com/kedacore/keda/v2/pkg/scaling/scheduler"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 )
 
 // ScaleHandler encapsulates the logic of calling the right scalers for
@@ -46,30 +55,90 @@ type ScaleHandler interface {
 	DeleteScalableObject(ctx context.Context, scalableObject interface{}) error
 	GetScalersCache(ctx context.Context, scalableObject interface{}) (*cache.ScalersCache, error)
 	ClearScalersCache(ctx context.Context, scalableObject interface{}) error
+	RefreshDependentScalers(ctx context.Context, dependency cache.Dependency)
+	DebugScalableObject(ctx context.Context, scalableObject interface{}) (*DebugReport, error)
 }
 
+// ErrScalersCacheNotFound is returned by DebugScalableObject when the scalable object has no live scalers cache
+var ErrScalersCacheNotFound = errors.New("scalers cache not found, the object is not handled by a scale loop yet")
+
+// DebugReport is the on demand evaluation of the live scalers of a ScaledObject or ScaledJob
+type DebugReport struct {
+	Kind             string                    `json:"kind"`
+	Namespace        string                    `json:"namespace"`
+	Name             string                    `json:"name"`
+	ObjectGeneration int64                     `json:"objectGeneration"`
+	CacheGeneration  int64                     `json:"cacheGeneration"`
+	CacheBuiltAt     time.Time                 `json:"cacheBuiltAt"`
+	SinceLastRebuild string                    `json:"sinceLastRebuild"`
+	Triggers         []cache.TriggerEvaluation `json:"triggers"`
+	LastDecision     *executor.ScaleDecision   `json:"lastDecision,omitempty"`
+}
+
+var metricsServer metrics.PrometheusMetricServer
+
 type scaleHandler struct {
 	client            client.Client
 	logger            logr.Logger
 	scaleLoopContexts *sync.Map
+	scalingMutexes    *sync.Map
 	scaleExecutor     executor.ScaleExecutor
 	globalHTTPTimeout time.Duration
 	recorder          record.EventRecorder
 	scalerCaches      map[string]*cache.ScalersCache
 	lock              *sync.RWMutex
+	scheduler         *scheduler.Scheduler
 }
 
-// NewScaleHandler creates a ScaleHandler object
-func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, globalHTTPTimeout time.Duration, recorder record.EventRecorder) ScaleHandler {
-	return &scaleHandler{
+// NewScaleHandler creates a ScaleHandler object, scaleLoopScheduler can be nil if scale loops don't need to be throttled
+func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, globalHTTPTimeout time.Duration, recorder record.EventRecorder, scaleLoopScheduler *scheduler.Scheduler) ScaleHandler {
+	h := &scaleHandler{
 		client:            client,
 		logger:            logf.Log.WithName("scalehandler"),
 		scaleLoopContexts: &sync.Map{},
+		scalingMutexes:    &sync.Map{},
 		scaleExecutor:     executor.NewScaleExecutor(client, scaleClient, reconcilerScheme, recorder),
 		globalHTTPTimeout: globalHTTPTimeout,
 		recorder:          recorder,
 		scalerCaches:      map[string]*cache.ScalersCache{},
 		lock:              &sync.RWMutex{},
+		scheduler:         scaleLoopScheduler,
+	}
+	return h
+}
+
+var (
+	// the rotated HashiCorp Vault secrets are dispatched by a single callback to the handlers with scalers caches,
+	// so a handler isn't kept reachable by the resolver once its scalers are gone
+	vaultRotationOnce     sync.Once
+	vaultRotationLock     sync.RWMutex
+	vaultRotationHandlers = map[*scaleHandler]struct{}{}
+)
+
+// watchVaultRotations adds the handler to the receivers of the rotated HashiCorp Vault secrets while it has scalers caches,
+// and removes it once the last one is cleared. It's called with h.lock held.
+func (h *scaleHandler) watchVaultRotations() {
+	vaultRotationOnce.Do(func() {
+		resolver.OnHashiCorpVaultSecretRotated(dispatchVaultSecretRotated)
+	})
+	vaultRotationLock.Lock()
+	defer vaultRotationLock.Unlock()
+	if len(h.scalerCaches) > 0 {
+		vaultRotationHandlers[h] = struct{}{}
+	} else {
+		delete(vaultRotationHandlers, h)
+	}
+}
+
+func dispatchVaultSecretRotated(dependency string) {
+	vaultRotationLock.RLock()
+	handlers := make([]*scaleHandler, 0, len(vaultRotationHandlers))
+	for h := range vaultRotationHandlers {
+		handlers = append(handlers, h)
+	}
+	vaultRotationLock.RUnlock()
+	for _, h := range handlers {
+		h.RefreshDependentScalers(context.Background(), cache.Dependency{Kind: resolver.HashiCorpVaultSecretDependencyKind, Name: dependency})
 	}
 }
 
@@ -97,6 +166,7 @@ func (h *scaleHandler) HandleScalableObject(ctx context.Context, scalableObject
 
 	// a mutex is used to synchronize scale requests per scalableObject
 	scalingMutex := &sync.Mutex{}
+	h.scalingMutexes.Store(key, scalingMutex)
 
 	// passing deep copy of ScaledObject/ScaledJob to the scaleLoop go routines, it's a precaution to not have global objects shared between threads
 	switch obj := scalableObject.(type) {
@@ -125,10 +195,12 @@ func (h *scaleHandler) DeleteScalableObject(ctx context.Context, scalableObject
 			cancel()
 		}
 		h.scaleLoopContexts.Delete(key)
+		h.scaleExecutor.DeleteLastDecision(withTriggers.Kind, withTriggers.Namespace, withTriggers.Name)
 		err := h.ClearScalersCache(ctx, scalableObject)
 		if err != nil {
 			h.logger.Error(err, "error clearing scalers cache")
 		}
+		h.scalingMutexes.Delete(key)
 		h.recorder.Event(withTriggers, corev1.EventTypeNormal, eventreason.KEDAScalersStopped, "Stopped scalers watch")
 	} else {
 		h.logger.V(1).Info("ScaledObject was not found in controller cache", "key", key)
@@ -137,6 +209,17 @@ func (h *scaleHandler) DeleteScalableObject(ctx context.Context, scalableObject
 	return nil
 }
 
+// getScalingMutex returns the mutex serializing the use of the scalers of the object, the scale loop stores it when it starts.
+// Objects without a scale loop, like in the metrics server, get a new mutex.
+func (h *scaleHandler) getScalingMutex(key string) sync.Locker {
+	if value, ok := h.scalingMutexes.Load(key); ok {
+		if scalingMutex, ok := value.(sync.Locker); ok {
+			return scalingMutex
+		}
+	}
+	return &sync.Mutex{}
+}
+
 // startScaleLoop blocks forever and checks the scaledObject based on its pollingInterval
 func (h *scaleHandler) startScaleLoop(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, scalableObject interface{}, scalingMutex sync.Locker) {
 	logger := h.logger.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name)
@@ -144,15 +227,72 @@ func (h *scaleHandler) startScaleLoop(ctx context.Context, withTriggers *kedav1a
 	pollingInterval := withTriggers.GetPollingInterval()
 	logger.V(1).Info("Watching with pollingInterval", "PollingInterval", pollingInterval)
 
+	triggerTypes := make([]string, 0, len(withTriggers.Spec.Triggers))
+	for _, trigger := range withTriggers.Spec.Triggers {
+		triggerTypes = append(triggerTypes, trigger.Type)
+	}
+
+	// spread the first check of the loops, so loops started at the same time (eg. after operator restart)
+	// don't hit the scaler sources in lockstep
+	if startDelay := h.scheduler.StartDelay(pollingInterval); startDelay > 0 {
+		logger.V(1).Info("Delaying the first check of the scale loop", "StartDelay", startDelay)
+		tmr := time.NewTimer(startDelay)
+		select {
+		case <-tmr.C:
+		case <-ctx.Done():
+			tmr.Stop()
+			logger.V(1).Info("Context canceled")
+			return
+		}
+	}
+
+	// every check is traced as a new trace linked to the reconcile that started the loop,
+	// otherwise all checks would end up in one endless trace
+	spanOptions := []trace.SpanOption{
+		trace.WithNewRoot(),
+		trace.WithAttributes(tracing.ScalableObjectAttributes(withTriggers.Kind, withTriggers.Namespace, withTriggers.Name)...),
+	}
+	if reconcileSpan := trace.SpanContextFromContext(ctx); reconcileSpan.IsValid() {
+		spanOptions = append(spanOptions, trace.WithLinks(trace.Link{SpanContext: reconcileSpan}))
+	}
+
+	idleChecks := 0
+	due := time.Now()
 	for {
-		tmr := time.NewTimer(pollingInterval)
-		h.checkScalers(ctx, scalableObject, scalingMutex)
+		start := time.Now()
+		isActive := false
+		checkCtx, span := tracing.StartSpan(ctx, "ScaleLoop.Check", spanOptions...)
+		release, err := h.scheduler.Acquire(checkCtx, triggerTypes)
+		if err == nil {
+			checkStart := time.Now()
+			isActive = h.checkScalers(checkCtx, scalableObject, scalingMutex)
+			release()
+			metricsServer.RecordScaleLoopCheck(withTriggers.Namespace, withTriggers.Kind, withTriggers.Name, time.Since(checkStart), checkStart.Sub(due))
+		}
+		span.SetAttributes(attribute.Bool("keda.is_active", isActive))
+		tracing.EndSpan(span, err)
+
+		if isActive {
+			idleChecks = 0
+		} else {
+			idleChecks++
+		}
+		interval := withTriggers.GetAdaptivePollingInterval(isActive, idleChecks)
+		scalingMutex.Lock()
+		h.updateEffectivePollingInterval(ctx, logger, scalableObject, interval)
+		scalingMutex.Unlock()
 
+		due = start.Add(interval)
+		tmr := time.NewTimer(time.Until(due))
 		select {
 		case <-tmr.C:
 			tmr.Stop()
 		case <-ctx.Done():
 			logger.V(1).Info("Context canceled")
+			metricsServer.DeleteScaleLoop(withTriggers.Namespace, withTriggers.Kind, withTriggers.Name)
+			if withTriggers.Kind == "ScaledJob" {
+				metricsServer.DeleteScaledJobJobs(withTriggers.Namespace, withTriggers.Name)
+			}
 			err := h.ClearScalersCache(ctx, scalableObject)
 			if err != nil {
 				logger.Error(err, "error clearing scalers cache")
@@ -178,6 +318,10 @@ func (h *scaleHandler) GetScalersCache(ctx context.Context, scalableObject inter
 	}
 	h.lock.RUnlock()
 
+	// the scalers of the previous generation aren't closed while they are evaluated by a debug request or rebuilt
+	scalingMutex := h.getScalingMutex(key)
+	scalingMutex.Lock()
+	defer scalingMutex.Unlock()
 	h.lock.Lock()
 	defer h.lock.Unlock()
 	if cache, ok := h.scalerCaches[key]; ok && cache.Generation == withTriggers.Generation {
@@ -197,11 +341,18 @@ func (h *scaleHandler) GetScalersCache(ctx context.Context, scalableObject inter
 	}
 
 	h.scalerCaches[key] = &cache.ScalersCache{
-		Generation: withTriggers.Generation,
-		Scalers:    scalers,
-		Logger:     h.logger,
-		Recorder:   h.recorder,
+		Generation:      withTriggers.Generation,
+		BuiltAt:         time.Now(),
+		Scalers:         scalers,
+		Logger:          h.logger,
+		Recorder:        h.recorder,
+		Object:          withTriggers,
+		ObjectKind:      withTriggers.Kind,
+		ObjectNamespace: withTriggers.Namespace,
+		ObjectName:      withTriggers.Name,
 	}
+	h.watchVaultRotations()
+	metricsServer.RecordScalersCacheRebuild(withTriggers.Namespace, withTriggers.Kind, withTriggers.Name, metrics.ScalersCacheRebuildObject)
 
 	return h.scalerCaches[key], nil
 }
@@ -214,17 +365,130 @@ func (h *scaleHandler) ClearScalersCache(ctx context.Context, scalableObject int
 
 	key := withTriggers.GenerateIdenitifier()
 
+	// the scalers aren't closed while they are evaluated by a debug request or rebuilt
+	scalingMutex := h.getScalingMutex(key)
+	scalingMutex.Lock()
+	defer scalingMutex.Unlock()
 	h.lock.Lock()
 	defer h.lock.Unlock()
 
 	if cache, ok := h.scalerCaches[key]; ok {
 		cache.Close(ctx)
 		delete(h.scalerCaches, key)
+		h.watchVaultRotations()
 	}
 
 	return nil
 }
 
+// RefreshDependentScalers rebuilds the scalers of the triggers built from the passed object, so changed credentials
+// or metadata are used right away instead of after the next failure of the scaler
+func (h *scaleHandler) RefreshDependentScalers(ctx context.Context, dependency cache.Dependency) {
+	var keys []string
+	h.lock.RLock()
+	for key, scalersCache := range h.scalerCaches {
+		if scalersCache.DependsOn(dependency) {
+			keys = append(keys, key)
+		}
+	}
+	h.lock.RUnlock()
+
+	for _, key := range keys {
+		h.refreshDependentScalers(ctx, key, dependency)
+	}
+}
+
+func (h *scaleHandler) refreshDependentScalers(ctx context.Context, key string, dependency cache.Dependency) {
+	// the scale loop doesn't use the scalers while they are replaced
+	scalingMutex := h.getScalingMutex(key)
+	scalingMutex.Lock()
+	defer scalingMutex.Unlock()
+
+	h.lock.RLock()
+	scalersCache, ok := h.scalerCaches[key]
+	h.lock.RUnlock()
+	if !ok {
+		return
+	}
+
+	source := fmt.Sprintf("%s %s", dependency.Kind, dependency.Name)
+	if dependency.Namespace != "" {
+		source = fmt.Sprintf("%s %s/%s", dependency.Kind, dependency.Namespace, dependency.Name)
+	}
+	// building the scalers reads Secrets and external secret stores, the caches of the other objects stay available meanwhile
+	rebuilt, refreshed, err := scalersCache.RebuildDependentScalers(ctx, dependency)
+
+	h.lock.Lock()
+	current := h.scalerCaches[key] == scalersCache
+	var replaced []scalers.Scaler
+	if current {
+		replaced = scalersCache.ReplaceScalers(rebuilt)
+	}
+	h.lock.Unlock()
+
+	if !current {
+		// the cache was rebuilt or cleared meanwhile, its new scalers are already built from the changed object
+		for _, scaler := range rebuilt {
+			scaler.Close(ctx)
+		}
+		return
+	}
+	for _, scaler := range replaced {
+		scaler.Close(ctx)
+	}
+
+	if len(refreshed) > 0 {
+		h.logger.V(1).Info("Rebuilt scalers, a dependency changed", "type", scalersCache.ObjectKind, "namespace", scalersCache.ObjectNamespace,
+			"name", scalersCache.ObjectName, "dependency", source, "triggers", refreshed)
+		if scalersCache.Object != nil {
+			h.recorder.Eventf(scalersCache.Object, corev1.EventTypeNormal, eventreason.KEDAScalersRefreshed, "Rebuilt triggers %s after %s changed", strings.Join(refreshed, ", "), source)
+		}
+	}
+	if err != nil {
+		h.logger.Error(err, "Error rebuilding scalers after a dependency changed", "type", scalersCache.ObjectKind, "namespace", scalersCache.ObjectNamespace,
+			"name", scalersCache.ObjectName, "dependency", source)
+		if scalersCache.Object != nil {
+			h.recorder.Eventf(scalersCache.Object, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "Error rebuilding triggers after %s changed: %s", source, err)
+		}
+	}
+}
+
+// DebugScalableObject evaluates the scalers of the live scalers cache of the scalable object, the cache is never built or rebuilt.
+// The scaling mutex of the object is held during the evaluation, so its scalers aren't used by the scale loop, rebuilt or closed meanwhile.
+func (h *scaleHandler) DebugScalableObject(ctx context.Context, scalableObject interface{}) (*DebugReport, error) {
+	withTriggers, err := asDuckWithTriggers(scalableObject)
+	if err != nil {
+		return nil, err
+	}
+
+	key := withTriggers.GenerateIdenitifier()
+
+	scalingMutex := h.getScalingMutex(key)
+	scalingMutex.Lock()
+	defer scalingMutex.Unlock()
+	h.lock.RLock()
+	scalersCache, ok := h.scalerCaches[key]
+	h.lock.RUnlock()
+	if !ok {
+		return nil, ErrScalersCacheNotFound
+	}
+
+	report := &DebugReport{
+		Kind:             withTriggers.Kind,
+		Namespace:        withTriggers.Namespace,
+		Name:             withTriggers.Name,
+		ObjectGeneration: withTriggers.Generation,
+		CacheGeneration:  scalersCache.Generation,
+		CacheBuiltAt:     scalersCache.BuiltAt,
+		SinceLastRebuild: time.Since(scalersCache.BuiltAt).Round(time.Millisecond).String(),
+		Triggers:         cache.EvaluateScalers(ctx, scalersCache.Scalers),
+	}
+	if decision, ok := h.scaleExecutor.GetLastDecision(withTriggers.Kind, withTriggers.Namespace, withTriggers.Name); ok {
+		report.LastDecision = &decision
+	}
+	return report, nil
+}
+
 func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, scalableObject interface{}, scalingMutex sync.Locker) {
 	logger := h.logger.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name)
 	cache, err := h.GetScalersCache(ctx, scalableObject)
@@ -258,75 +522,142 @@ func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav
 }
 
 // checkScalers contains the main logic for the ScaleHandler scaling logic.
-// It'll check each trigger active status then call RequestScale
-func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interface{}, scalingMutex sync.Locker) {
+// It'll check each trigger active status then call RequestScale, the returned value reports whether any trigger was active
+func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interface{}, scalingMutex sync.Locker) bool {
 	cache, err := h.GetScalersCache(ctx, scalableObject)
 	if err != nil {
 		h.logger.Error(err, "Error getting scalers", "object", scalableObject)
-		return
+		return false
 	}
 
 	scalingMutex.Lock()
 	defer scalingMutex.Unlock()
 	switch obj := scalableObject.(type) {
 	case *kedav1alpha1.ScaledObject:
+		previousFallback := obj.Status.Conditions.GetFallbackCondition()
 		err = h.client.Get(ctx, types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}, obj)
 		if err != nil {
 			h.logger.Error(err, "Error getting scaledObject", "object", scalableObject)
-			return
+			return false
 		}
-		isActive, isError, _ := cache.IsScaledObjectActive(ctx, obj)
+		isActive, isError, metricValues := cache.IsScaledObjectActive(ctx, obj)
 		h.scaleExecutor.RequestScale(ctx, obj, isActive, isError)
+		if obj.Spec.ScaleTargetRef.ReplicasPath != "" {
+			// no HPA scales targets with a replicasPath, KEDA calculates their replicas from the trigger metrics
+			h.scaleExecutor.RequestReplicas(ctx, obj, metricValues)
+		}
+		h.recordFallbackTransition(obj, previousFallback)
+		h.updateCircuitBreakers(ctx, obj, cache.GetCircuitBreakerStatuses())
+		return isActive
 	case *kedav1alpha1.ScaledJob:
 		err = h.client.Get(ctx, types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}, obj)
 		if err != nil {
 			h.logger.Error(err, "Error getting scaledJob", "object", scalableObject)
-			return
+			return false
 		}
 		isActive, scaleTo, maxScale := cache.IsScaledJobActive(ctx, obj)
 		h.scaleExecutor.RequestJobScale(ctx, obj, isActive, scaleTo, maxScale)
+		h.updateCircuitBreakers(ctx, obj, cache.GetCircuitBreakerStatuses())
+		return isActive
+	}
+	return false
+}
+
+// updateEffectivePollingInterval reports the polling interval used for the next check in the status of the object
+func (h *scaleHandler) updateEffectivePollingInterval(ctx context.Context, logger logr.Logger, scalableObject interface{}, interval time.Duration) {
+	seconds := int32(interval / time.Second)
+
+	var patch client.Patch
+	runtimeObj := scalableObject.(client.Object)
+	switch obj := runtimeObj.(type) {
+	case *kedav1alpha1.ScaledObject:
+		if obj.Status.EffectivePollingInterval != nil && *obj.Status.EffectivePollingInterval == seconds {
+			return
+		}
+		patch = client.MergeFrom(obj.DeepCopy())
+		obj.Status.EffectivePollingInterval = &seconds
+	case *kedav1alpha1.ScaledJob:
+		if obj.Status.EffectivePollingInterval != nil && *obj.Status.EffectivePollingInterval == seconds {
+			return
+		}
+		patch = client.MergeFrom(obj.DeepCopy())
+		obj.Status.EffectivePollingInterval = &seconds
+	default:
+		return
+	}
+
+	if err := h.client.Status().Patch(ctx, runtimeObj, patch); err != nil {
+		logger.Error(err, "Failed to patch effective polling interval in Objects Status")
+	}
+}
+
+// recordFallbackTransition records an event when the Fallback condition changed since the previous check,
+// the condition is set by the metrics server as well as by the scale executor
+func (h *scaleHandler) recordFallbackTransition(scaledObject *kedav1alpha1.ScaledObject, previous kedav1alpha1.Condition) {
+	current := scaledObject.Status.Conditions.GetFallbackCondition()
+	if !(previous.IsTrue() || previous.IsFalse()) || !(current.IsTrue() || current.IsFalse()) || previous.IsTrue() == current.IsTrue() {
+		return
+	}
+	if current.IsTrue() {
+		h.recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetFallbackEntered, "At least one trigger is falling back on this scaled object")
+	} else {
+		h.recorder.Event(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetFallbackExited, "No fallbacks are active on this scaled object")
+	}
+}
+
+// updateCircuitBreakers reports the trigger circuit breakers that are not closed in the status of the object
+func (h *scaleHandler) updateCircuitBreakers(ctx context.Context, scalableObject interface{}, circuitBreakers map[string]kedav1alpha1.TriggerCircuitBreakerStatus) {
+	var patch client.Patch
+	runtimeObj := scalableObject.(client.Object)
+	switch obj := runtimeObj.(type) {
+	case *kedav1alpha1.ScaledObject:
+		if equality.Semantic.DeepEqual(obj.Status.CircuitBreakers, circuitBreakers) {
+			return
+		}
+		patch = client.MergeFrom(obj.DeepCopy())
+		obj.Status.CircuitBreakers = circuitBreakers
+	case *kedav1alpha1.ScaledJob:
+		if equality.Semantic.DeepEqual(obj.Status.CircuitBreakers, circuitBreakers) {
+			return
+		}
+		patch = client.MergeFrom(obj.DeepCopy())
+		obj.Status.CircuitBreakers = circuitBreakers
+	default:
+		return
+	}
+
+	if err := h.client.Status().Patch(ctx, runtimeObj, patch); err != nil {
+		h.logger.Error(err, "Failed to patch trigger circuit breakers in Objects Status", "object", scalableObject)
 	}
 }
 
 // buildScalers returns list of Scalers for the specified triggers
 func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, podTemplateSpec *corev1.PodTemplateSpec, containerName string) ([]cache.ScalerBuilder, error) {
 	logger := h.logger.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name)
-	var err error
-	resolvedEnv := make(map[string]string)
 	result := make([]cache.ScalerBuilder, 0, len(withTriggers.Spec.Triggers))
 
 	for i, t := range withTriggers.Spec.Triggers {
 		triggerIndex, trigger := i, t
 
-		factory := func() (scalers.Scaler, error) {
-			if podTemplateSpec != nil {
-				resolvedEnv, err = resolver.ResolveContainerEnv(ctx, h.client, logger, &podTemplateSpec.Spec, containerName, withTriggers.Namespace)
-				if err != nil {
-					return nil, fmt.Errorf("error resolving secrets for ScaleTarget: %s", err)
-				}
-			}
-			config := &scalers.ScalerConfig{
-				Name:              withTriggers.Name,
-				Namespace:         withTriggers.Namespace,
-				TriggerMetadata:   trigger.Metadata,
-				ResolvedEnv:       resolvedEnv,
-				AuthParams:        make(map[string]string),
-				GlobalHTTPTimeout: h.globalHTTPTimeout,
-				ScalerIndex:       triggerIndex,
-				MetricType:        trigger.MetricType,
-			}
-
-			config.AuthParams, config.PodIdentity, err = resolver.ResolveAuthRefAndPodIdentity(ctx, h.client, logger, trigger.AuthenticationRef, podTemplateSpec, withTriggers.Namespace)
+		dependencies := &cache.Dependencies{}
+		// the scaler is rebuilt with the context of the caller, the context of the first build may be gone by then
+		factory := func(ctx context.Context) (scalers.Scaler, error) {
+			recordingClient := newDependencyRecordingClient(h.client)
+			config, err := h.resolveScalerConfig(ctx, recordingClient, logger, withTriggers, podTemplateSpec, containerName, triggerIndex, trigger)
+			dependencies.Replace(recordingClient.recorded())
 			if err != nil {
 				return nil, err
 			}
-
-			return buildScaler(ctx, h.client, trigger.Type, config)
+			return BuildScaler(ctx, h.client, trigger.Type, config)
 		}
 
-		scaler, err := factory()
+		scaler, err := factory(ctx)
 		if err != nil {
-			h.recorder.Event(withTriggers, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
+			reason := eventreason.KEDAScalerFailed
+			if resolver.IsAuthResolutionError(err) {
+				reason = eventreason.TriggerAuthenticationResolutionFailed
+			}
+			h.recorder.Event(withTriggers, corev1.EventTypeWarning, reason, err.Error())
 			h.logger.Error(err, "error resolving auth params", "scalerIndex", triggerIndex, "object", withTriggers)
 			if scaler != nil {
 				scaler.Close(ctx)
@@ -337,16 +668,86 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 			return nil, err
 		}
 
+		triggerName := trigger.Name
+		if triggerName == "" {
+			triggerName = fmt.Sprintf("s%d-%s", triggerIndex, trigger.Type)
+		}
 		result = append(result, cache.ScalerBuilder{
-			Scaler:  scaler,
-			Factory: factory,
+			Scaler:       scaler,
+			Factory:      factory,
+			TriggerName:  triggerName,
+			TriggerType:  trigger.Type,
+			BuiltAt:      time.Now(),
+			Breaker:      cache.NewCircuitBreaker(cache.DefaultCircuitBreakerConfig, withTriggers.Namespace, withTriggers.Name, triggerName),
+			Dependencies: dependencies,
 		})
 	}
 
 	return result, nil
 }
 
-func buildScaler(ctx context.Context, client client.Client, triggerType string, config *scalers.ScalerConfig) (scalers.Scaler, error) {
+// resolveScalerConfig resolves the environment of the scale target, the metadata and the authentication of the trigger into its ScalerConfig,
+// all objects are read with the passed client
+func (h *scaleHandler) resolveScalerConfig(ctx context.Context, kubeClient client.Client, logger logr.Logger, withTriggers *kedav1alpha1.WithTriggers, podTemplateSpec *corev1.PodTemplateSpec, containerName string, triggerIndex int, trigger kedav1alpha1.ScaleTriggers) (*scalers.ScalerConfig, error) {
+	resolvedEnv := make(map[string]string)
+	if podTemplateSpec != nil {
+		var err error
+		resolvedEnv, err = resolver.ResolveContainerEnv(ctx, kubeClient, logger, &podTemplateSpec.Spec, containerName, withTriggers.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving secrets for ScaleTarget: %s", err)
+		}
+	}
+	metadata, err := resolver.ResolveTriggerMetadata(ctx, kubeClient, trigger, withTriggers.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	config := &scalers.ScalerConfig{
+		Name:              withTriggers.Name,
+		Namespace:         withTriggers.Namespace,
+		TriggerMetadata:   metadata,
+		ResolvedEnv:       resolvedEnv,
+		AuthParams:        make(map[string]string),
+		GlobalHTTPTimeout: h.globalHTTPTimeout,
+		ScalerIndex:       triggerIndex,
+		MetricType:        trigger.MetricType,
+	}
+
+	config.AuthParams, config.PodIdentity, err = resolver.ResolveAuthRefAndPodIdentity(ctx, kubeClient, logger, trigger.AuthenticationRef, podTemplateSpec, withTriggers.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// ResolveScalerConfigs resolves the ScalerConfig of every trigger of the ScaledObject or ScaledJob the same way the scale loop
+// does before building its scalers, the returned slices are indexed by trigger. It lets tooling validate trigger definitions.
+func ResolveScalerConfigs(ctx context.Context, client client.Client, scalableObject interface{}, globalHTTPTimeout time.Duration) ([]*scalers.ScalerConfig, []error, error) {
+	withTriggers, err := asDuckWithTriggers(scalableObject)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	h := &scaleHandler{
+		client:            client,
+		logger:            logf.Log.WithName("scalehandler"),
+		globalHTTPTimeout: globalHTTPTimeout,
+	}
+	logger := h.logger.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name)
+	podTemplateSpec, containerName, err := resolver.ResolveScaleTargetPodSpec(ctx, client, logger, scalableObject)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	configs := make([]*scalers.ScalerConfig, len(withTriggers.Spec.Triggers))
+	errs := make([]error, len(withTriggers.Spec.Triggers))
+	for i, trigger := range withTriggers.Spec.Triggers {
+		configs[i], errs[i] = h.resolveScalerConfig(ctx, client, logger, withTriggers, podTemplateSpec, containerName, i, trigger)
+	}
+	return configs, errs, nil
+}
+
+// BuildScaler creates the scaler of the passed trigger type from its resolved ScalerConfig
+func BuildScaler(ctx context.Context, client client.Client, triggerType string, config *scalers.ScalerConfig) (scalers.Scaler, error) {
 	// TRIGGERS-START
 	switch triggerType {
 	case "activemq":
@@ -464,21 +865,28 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 func asDuckWithTriggers(scalableObject interface{}) (*kedav1alpha1.WithTriggers, error) {
 	switch obj := scalableObject.(type) {
 	case *kedav1alpha1.ScaledObject:
+		// the kind is set explicitly, objects read through the client don't always carry their TypeMeta
 		return &kedav1alpha1.WithTriggers{
-			TypeMeta:   obj.TypeMeta,
+			TypeMeta:   metav1.TypeMeta{APIVersion: obj.APIVersion, Kind: "ScaledObject"},
 			ObjectMeta: obj.ObjectMeta,
 			Spec: kedav1alpha1.WithTriggersSpec{
-				PollingInterval: obj.Spec.PollingInterval,
-				Triggers:        obj.Spec.Triggers,
+				PollingInterval:        obj.Spec.PollingInterval,
+				ActivePollingInterval:  obj.Spec.ActivePollingInterval,
+				IdlePollingInterval:    obj.Spec.IdlePollingInterval,
+				MaxIdlePollingInterval: obj.Spec.MaxIdlePollingInterval,
+				Triggers:               obj.Spec.Triggers,
 			},
 		}, nil
 	case *kedav1alpha1.ScaledJob:
 		return &kedav1alpha1.WithTriggers{
-			TypeMeta:   obj.TypeMeta,
+			TypeMeta:   metav1.TypeMeta{APIVersion: obj.APIVersion, Kind: "ScaledJob"},
 			ObjectMeta: obj.ObjectMeta,
 			Spec: kedav1alpha1.WithTriggersSpec{
-				PollingInterval: obj.Spec.PollingInterval,
-				Triggers:        obj.Spec.Triggers,
+				PollingInterval:        obj.Spec.PollingInterval,
+				ActivePollingInterval:  obj.Spec.ActivePollingInterval,
+				IdlePollingInterval:    obj.Spec.IdlePollingInterval,
+				MaxIdlePollingInterval: obj.Spec.MaxIdlePollingInterval,
+				Triggers:               obj.Spec.Triggers,
 			},
 		}, nil
 	default:
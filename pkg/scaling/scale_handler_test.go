@@ -19,17 +19,25 @@ package scaling
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
-	"k8s.io/api/autoscaling/v2beta2"
+	v2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
 	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache"
@@ -37,15 +45,15 @@ import (
 
 func TestCheckScaledObjectScalersWithError(t *testing.T) {
 	ctrl := gomock.NewController(t)
-	recorder := record.NewFakeRecorder(1)
+	recorder := record.NewFakeRecorder(2)
 
-	factory := func() (scalers.Scaler, error) {
+	factory := func(context.Context) (scalers.Scaler, error) {
 		scaler := mock_scalers.NewMockScaler(ctrl)
 		scaler.EXPECT().IsActive(gomock.Any()).Return(false, errors.New("some error"))
 		scaler.EXPECT().Close(gomock.Any())
 		return scaler, nil
 	}
-	scaler, err := factory()
+	scaler, err := factory(context.TODO())
 	assert.Nil(t, err)
 
 	scaledObject := kedav1alpha1.ScaledObject{
@@ -74,31 +82,33 @@ func TestCheckScaledObjectScalersWithError(t *testing.T) {
 
 	assert.Equal(t, false, isActive)
 	assert.Equal(t, true, isError)
+	assert.Contains(t, <-recorder.Events, eventreason.KEDATriggerFailing)
+	assert.Contains(t, <-recorder.Events, eventreason.KEDAScalerFailed)
 }
 
 func TestCheckScaledObjectFindFirstActiveNotIgnoreOthers(t *testing.T) {
 	ctrl := gomock.NewController(t)
-	recorder := record.NewFakeRecorder(1)
+	recorder := record.NewFakeRecorder(2)
 
-	metricsSpecs := []v2beta2.MetricSpec{createMetricSpec(1)}
+	metricsSpecs := []v2.MetricSpec{createMetricSpec(1)}
 
-	activeFactory := func() (scalers.Scaler, error) {
+	activeFactory := func(context.Context) (scalers.Scaler, error) {
 		scaler := mock_scalers.NewMockScaler(ctrl)
 		scaler.EXPECT().IsActive(gomock.Any()).Return(true, nil)
 		scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Times(2).Return(metricsSpecs)
 		scaler.EXPECT().Close(gomock.Any())
 		return scaler, nil
 	}
-	activeScaler, err := activeFactory()
+	activeScaler, err := activeFactory(context.TODO())
 	assert.Nil(t, err)
 
-	failingFactory := func() (scalers.Scaler, error) {
+	failingFactory := func(context.Context) (scalers.Scaler, error) {
 		scaler := mock_scalers.NewMockScaler(ctrl)
 		scaler.EXPECT().IsActive(gomock.Any()).Return(false, errors.New("some error"))
 		scaler.EXPECT().Close(gomock.Any())
 		return scaler, nil
 	}
-	failingScaler, err := failingFactory()
+	failingScaler, err := failingFactory(context.TODO())
 	assert.Nil(t, err)
 
 	scaledObject := &kedav1alpha1.ScaledObject{
@@ -134,13 +144,215 @@ func TestCheckScaledObjectFindFirstActiveNotIgnoreOthers(t *testing.T) {
 	assert.Equal(t, true, isError)
 }
 
-func createMetricSpec(averageValue int64) v2beta2.MetricSpec {
+func createMetricSpec(averageValue int64) v2.MetricSpec {
 	qty := resource.NewQuantity(averageValue, resource.DecimalSI)
-	return v2beta2.MetricSpec{
-		External: &v2beta2.ExternalMetricSource{
-			Target: v2beta2.MetricTarget{
+	return v2.MetricSpec{
+		External: &v2.ExternalMetricSource{
+			Target: v2.MetricTarget{
 				AverageValue: qty,
 			},
 		},
 	}
 }
+
+func TestRefreshDependentScalers(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+
+	schedule := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "schedule", Namespace: "test"},
+		Data:       map[string]string{"start": "0 6 * * *", "end": "0 20 * * *"},
+	}
+	triggerAuth := &kedav1alpha1.TriggerAuthentication{
+		ObjectMeta: metav1.ObjectMeta{Name: "auth", Namespace: "test"},
+		Spec: kedav1alpha1.TriggerAuthenticationSpec{
+			SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "password", Name: "credentials", Key: "password", Optional: true}},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(schedule, triggerAuth).Build()
+	cronTrigger := kedav1alpha1.ScaleTriggers{
+		Type:         "cron",
+		Metadata:     map[string]string{"timezone": "UTC", "desiredReplicas": "2"},
+		MetadataFrom: []kedav1alpha1.TriggerMetadataSource{{ConfigMapRef: &kedav1alpha1.TriggerMetadataConfigMapRef{Name: "schedule"}}},
+	}
+	authenticatedTrigger := cronTrigger
+	authenticatedTrigger.Name = "authenticated"
+	authenticatedTrigger.AuthenticationRef = &kedav1alpha1.ScaledObjectAuthRef{Name: "auth"}
+	scaledJob := &kedav1alpha1.ScaledJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", Generation: 1},
+		Spec: kedav1alpha1.ScaledJobSpec{
+			JobTargetRef: &batchv1.JobSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "job"}}}},
+			},
+			Triggers: []kedav1alpha1.ScaleTriggers{cronTrigger, authenticatedTrigger},
+		},
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	h := NewScaleHandler(client, nil, scheme, 0, recorder, nil)
+	scalersCache, err := h.GetScalersCache(context.TODO(), scaledJob)
+	assert.NoError(t, err)
+	assert.True(t, scalersCache.DependsOn(cache.Dependency{Kind: "ConfigMap", Namespace: "test", Name: "schedule"}))
+	// the missing namespace defaults and optional Secret are recorded, so creating them refreshes the scalers as well
+	assert.True(t, scalersCache.DependsOn(cache.Dependency{Kind: "ConfigMap", Namespace: "test", Name: "keda-trigger-defaults"}))
+	assert.True(t, scalersCache.Scalers[1].Dependencies.Contains(cache.Dependency{Kind: "Secret", Namespace: "test", Name: "credentials"}))
+	assert.True(t, scalersCache.Scalers[1].Dependencies.Contains(cache.Dependency{Kind: "TriggerAuthentication", Namespace: "test", Name: "auth"}))
+	assert.False(t, scalersCache.Scalers[0].Dependencies.Contains(cache.Dependency{Kind: "Secret", Namespace: "test", Name: "credentials"}))
+
+	unauthenticated, authenticated := scalersCache.Scalers[0].Scaler, scalersCache.Scalers[1].Scaler
+	h.RefreshDependentScalers(context.TODO(), cache.Dependency{Kind: "Secret", Namespace: "other", Name: "credentials"})
+	assert.Same(t, authenticated, scalersCache.Scalers[1].Scaler)
+
+	h.RefreshDependentScalers(context.TODO(), cache.Dependency{Kind: "Secret", Namespace: "test", Name: "credentials"})
+	same, err := h.GetScalersCache(context.TODO(), scaledJob)
+	assert.NoError(t, err)
+	assert.Same(t, scalersCache, same)
+	assert.Same(t, unauthenticated, scalersCache.Scalers[0].Scaler)
+	assert.NotSame(t, authenticated, scalersCache.Scalers[1].Scaler)
+	assert.Contains(t, <-recorder.Events, "Normal KEDAScalersRefreshed Rebuilt triggers authenticated after Secret test/credentials changed")
+
+	h.RefreshDependentScalers(context.TODO(), cache.Dependency{Kind: "ConfigMap", Namespace: "test", Name: "schedule"})
+	assert.NotSame(t, unauthenticated, scalersCache.Scalers[0].Scaler)
+	assert.Contains(t, <-recorder.Events, "Rebuilt triggers s0-cron, authenticated after ConfigMap test/schedule changed")
+	assert.NoError(t, h.ClearScalersCache(context.TODO(), scaledJob))
+}
+
+func TestRefreshDependentScalersDoesNotBlockOtherObjects(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scheme := runtime.NewScheme()
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	h := NewScaleHandler(fake.NewClientBuilder().WithScheme(scheme).Build(), nil, scheme, 0, record.NewFakeRecorder(10), nil).(*scaleHandler)
+
+	dependency := cache.Dependency{Kind: "Secret", Namespace: "test", Name: "credentials"}
+	dependencies := &cache.Dependencies{}
+	dependencies.Replace(map[cache.Dependency]bool{dependency: true})
+
+	building, release := make(chan struct{}), make(chan struct{})
+	rebuilt := mock_scalers.NewMockScaler(ctrl)
+	factory := func(context.Context) (scalers.Scaler, error) {
+		close(building)
+		<-release
+		return rebuilt, nil
+	}
+	previous := mock_scalers.NewMockScaler(ctrl)
+	previous.EXPECT().Close(gomock.Any())
+
+	dependent := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "dependent", Namespace: "test", Generation: 1}}
+	other := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "test", Generation: 1}}
+	for _, obj := range []*kedav1alpha1.ScaledObject{dependent, other} {
+		withTriggers, err := asDuckWithTriggers(obj)
+		assert.NoError(t, err)
+		scalersCache := &cache.ScalersCache{Generation: 1, Logger: h.logger}
+		if obj == dependent {
+			scalersCache.Scalers = []cache.ScalerBuilder{{Scaler: previous, Factory: factory, Dependencies: dependencies, TriggerName: "s0-test"}}
+		}
+		h.scalerCaches[withTriggers.GenerateIdenitifier()] = scalersCache
+	}
+
+	refreshed := make(chan struct{})
+	go func() {
+		h.RefreshDependentScalers(context.TODO(), dependency)
+		close(refreshed)
+	}()
+	<-building
+
+	found := make(chan struct{})
+	go func() {
+		_, err := h.GetScalersCache(context.TODO(), other)
+		assert.NoError(t, err)
+		close(found)
+	}()
+	select {
+	case <-found:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the scalers cache of another object to be available while the scalers are rebuilt")
+	}
+
+	close(release)
+	<-refreshed
+	scalersCache, err := h.GetScalersCache(context.TODO(), dependent)
+	assert.NoError(t, err)
+	assert.Same(t, rebuilt, scalersCache.Scalers[0].Scaler)
+}
+
+func TestDebugScalableObjectKeepsScalersOpen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scheme := runtime.NewScheme()
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	h := NewScaleHandler(fake.NewClientBuilder().WithScheme(scheme).Build(), nil, scheme, 0, record.NewFakeRecorder(10), nil).(*scaleHandler)
+
+	evaluating, release := make(chan struct{}), make(chan struct{})
+	var closed bool
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	scaler.EXPECT().IsActive(gomock.Any()).DoAndReturn(func(context.Context) (bool, error) {
+		close(evaluating)
+		<-release
+		return true, nil
+	})
+	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(nil)
+	scaler.EXPECT().Close(gomock.Any()).Do(func(context.Context) {
+		closed = true
+	})
+
+	scaledObject := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", Generation: 1}}
+	withTriggers, err := asDuckWithTriggers(scaledObject)
+	assert.NoError(t, err)
+	key := withTriggers.GenerateIdenitifier()
+	h.scalingMutexes.Store(key, &sync.Mutex{})
+	h.scalerCaches[key] = &cache.ScalersCache{
+		Generation: 1,
+		Scalers:    []cache.ScalerBuilder{{Scaler: scaler, TriggerName: "s0-test"}},
+		Logger:     h.logger,
+	}
+
+	reported := make(chan *DebugReport)
+	go func() {
+		report, err := h.DebugScalableObject(context.TODO(), scaledObject)
+		assert.NoError(t, err)
+		reported <- report
+	}()
+	<-evaluating
+
+	cleared := make(chan struct{})
+	go func() {
+		assert.NoError(t, h.ClearScalersCache(context.TODO(), scaledObject))
+		close(cleared)
+	}()
+	select {
+	case <-cleared:
+		t.Fatal("Expected the scalers not to be closed while they are evaluated")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	report := <-reported
+	<-cleared
+	assert.True(t, *report.Triggers[0].IsActive)
+	assert.True(t, closed)
+}
+
+func TestVaultRotationsReachHandlersWithScalersOnly(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	idle := NewScaleHandler(fake.NewClientBuilder().WithScheme(scheme).Build(), nil, scheme, 0, record.NewFakeRecorder(10), nil).(*scaleHandler)
+	h := NewScaleHandler(fake.NewClientBuilder().WithScheme(scheme).Build(), nil, scheme, 0, record.NewFakeRecorder(10), nil).(*scaleHandler)
+	watched := func(h *scaleHandler) bool {
+		vaultRotationLock.RLock()
+		defer vaultRotationLock.RUnlock()
+		_, ok := vaultRotationHandlers[h]
+		return ok
+	}
+
+	scaledJob := &kedav1alpha1.ScaledJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", Generation: 1},
+		Spec:       kedav1alpha1.ScaledJobSpec{JobTargetRef: &batchv1.JobSpec{}},
+	}
+	_, err := h.GetScalersCache(context.TODO(), scaledJob)
+	assert.NoError(t, err)
+	assert.True(t, watched(h))
+	assert.False(t, watched(idle))
+
+	assert.NoError(t, h.ClearScalersCache(context.TODO(), scaledJob))
+	assert.False(t, watched(h))
+}
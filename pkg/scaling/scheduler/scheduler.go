@@ -0,0 +1,210 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/kedacore/keda/v2/pkg/metrics"
+)
+
+// Config holds the settings of the scale loop Scheduler
+type Config struct {
+	// StartJitter is the fraction (0-1) of the polling interval used as the upper bound
+	// for the random delay before the first check of a scale loop
+	StartJitter float64
+	// MaxConcurrentChecks is the maximum number of scale loop checks executed at the same time, 0 means unlimited
+	MaxConcurrentChecks int
+	// TriggerRateLimits holds the maximum number of checks per second for each trigger type
+	TriggerRateLimits map[string]float64
+}
+
+// Scheduler spreads scale loop checks over time and limits how many of them run concurrently
+type Scheduler struct {
+	startJitter float64
+	slots       chan struct{}
+	limiters    map[string]*rate.Limiter
+
+	lock     sync.Mutex
+	waiting  int
+	inFlight int
+	random   *rand.Rand
+}
+
+// NewScheduler creates a Scheduler from the passed Config
+func NewScheduler(config Config) (*Scheduler, error) {
+	s := &Scheduler{
+		random: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	if err := s.Update(config); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Update replaces the settings of the Scheduler, checks that already hold a concurrency slot
+// release it on the limit they were started with
+func (s *Scheduler) Update(config Config) error {
+	if config.StartJitter < 0 || config.StartJitter > 1 {
+		return fmt.Errorf("start jitter must be between 0 and 1, got %v", config.StartJitter)
+	}
+	if config.MaxConcurrentChecks < 0 {
+		return fmt.Errorf("max concurrent checks must not be negative, got %d", config.MaxConcurrentChecks)
+	}
+
+	limiters := make(map[string]*rate.Limiter, len(config.TriggerRateLimits))
+	for triggerType, limit := range config.TriggerRateLimits {
+		if limit <= 0 {
+			return fmt.Errorf("rate limit for trigger type %s must be positive, got %v", triggerType, limit)
+		}
+		limiters[triggerType] = rate.NewLimiter(rate.Limit(limit), int(math.Max(1, math.Ceil(limit))))
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.startJitter = config.StartJitter
+	s.limiters = limiters
+	if config.MaxConcurrentChecks == 0 {
+		s.slots = nil
+	} else if s.slots == nil || cap(s.slots) != config.MaxConcurrentChecks {
+		s.slots = make(chan struct{}, config.MaxConcurrentChecks)
+	}
+	return nil
+}
+
+// ParseTriggerRateLimits parses rate limits in the "type=limit,type=limit" format
+func ParseTriggerRateLimits(value string) (map[string]float64, error) {
+	result := make(map[string]float64)
+	if strings.TrimSpace(value) == "" {
+		return result, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid trigger rate limit %q, expected type=limit", pair)
+		}
+		limit, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trigger rate limit %q: %s", pair, err)
+		}
+		result[parts[0]] = limit
+	}
+
+	return result, nil
+}
+
+// StartDelay returns a random delay before the first check of a scale loop with the given polling interval
+func (s *Scheduler) StartDelay(pollingInterval time.Duration) time.Duration {
+	if s == nil || pollingInterval <= 0 {
+		return 0
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.startJitter == 0 {
+		return 0
+	}
+	return time.Duration(s.random.Float64() * s.startJitter * float64(pollingInterval))
+}
+
+// Acquire blocks until a check for a scale loop with the passed trigger types is allowed to run.
+// The returned function must be called once the check is done to release the concurrency slot.
+func (s *Scheduler) Acquire(ctx context.Context, triggerTypes []string) (func(), error) {
+	if s == nil {
+		return func() {}, nil
+	}
+
+	start := time.Now()
+	s.updateWaiting(1)
+
+	s.lock.Lock()
+	limiters, slots := s.limiters, s.slots
+	s.lock.Unlock()
+
+	for _, triggerType := range uniqueTypes(triggerTypes) {
+		limiter, ok := limiters[triggerType]
+		if !ok {
+			continue
+		}
+		if !limiter.Allow() {
+			metrics.RecordScaleLoopRateLimited(triggerType)
+			if err := limiter.Wait(ctx); err != nil {
+				s.updateWaiting(-1)
+				return nil, err
+			}
+		}
+	}
+
+	if slots != nil {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			s.updateWaiting(-1)
+			return nil, ctx.Err()
+		}
+	}
+
+	s.updateWaiting(-1)
+	s.updateInFlight(1)
+	metrics.RecordScaleLoopWait(time.Since(start).Seconds())
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if slots != nil {
+				<-slots
+			}
+			s.updateInFlight(-1)
+		})
+	}, nil
+}
+
+func (s *Scheduler) updateWaiting(delta int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.waiting += delta
+	metrics.RecordScaleLoopQueueDepth(s.waiting)
+}
+
+func (s *Scheduler) updateInFlight(delta int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.inFlight += delta
+	metrics.RecordScaleLoopInFlight(s.inFlight)
+}
+
+func uniqueTypes(triggerTypes []string) []string {
+	seen := make(map[string]bool, len(triggerTypes))
+	result := make([]string, 0, len(triggerTypes))
+	for _, t := range triggerTypes {
+		if !seen[t] {
+			seen[t] = true
+			result = append(result, t)
+		}
+	}
+	return result
+}
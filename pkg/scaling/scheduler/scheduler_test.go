@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type parseTriggerRateLimitsTestData struct {
+	value    string
+	expected map[string]float64
+	isError  bool
+}
+
+var parseTriggerRateLimitsTestDataset = []parseTriggerRateLimitsTestData{
+	{"", map[string]float64{}, false},
+	{"kafka=10", map[string]float64{"kafka": 10}, false},
+	{"kafka=10, prometheus=0.5", map[string]float64{"kafka": 10, "prometheus": 0.5}, false},
+	{"kafka", nil, true},
+	{"=10", nil, true},
+	{"kafka=ten", nil, true},
+}
+
+func TestParseTriggerRateLimits(t *testing.T) {
+	for _, testData := range parseTriggerRateLimitsTestDataset {
+		result, err := ParseTriggerRateLimits(testData.value)
+		if testData.isError {
+			assert.NotNil(t, err, testData.value)
+			continue
+		}
+		assert.Nil(t, err, testData.value)
+		assert.Equal(t, testData.expected, result, testData.value)
+	}
+}
+
+func TestNewSchedulerValidation(t *testing.T) {
+	_, err := NewScheduler(Config{StartJitter: 1.5})
+	assert.NotNil(t, err)
+
+	_, err = NewScheduler(Config{MaxConcurrentChecks: -1})
+	assert.NotNil(t, err)
+
+	_, err = NewScheduler(Config{TriggerRateLimits: map[string]float64{"kafka": 0}})
+	assert.NotNil(t, err)
+}
+
+func TestStartDelay(t *testing.T) {
+	s, err := NewScheduler(Config{StartJitter: 0.5})
+	assert.Nil(t, err)
+
+	for i := 0; i < 100; i++ {
+		delay := s.StartDelay(10 * time.Second)
+		assert.GreaterOrEqual(t, delay, time.Duration(0))
+		assert.Less(t, delay, 5*time.Second)
+	}
+
+	s, err = NewScheduler(Config{})
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), s.StartDelay(10*time.Second))
+
+	var nilScheduler *Scheduler
+	assert.Equal(t, time.Duration(0), nilScheduler.StartDelay(10*time.Second))
+}
+
+func TestAcquireLimitsConcurrency(t *testing.T) {
+	s, err := NewScheduler(Config{MaxConcurrentChecks: 1})
+	assert.Nil(t, err)
+
+	release, err := s.Acquire(context.Background(), nil)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = s.Acquire(ctx, nil)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	release()
+	release()
+
+	release, err = s.Acquire(context.Background(), nil)
+	assert.Nil(t, err)
+	release()
+}
+
+func TestAcquireRateLimitsTriggerType(t *testing.T) {
+	s, err := NewScheduler(Config{TriggerRateLimits: map[string]float64{"kafka": 1}})
+	assert.Nil(t, err)
+
+	release, err := s.Acquire(context.Background(), []string{"kafka", "kafka"})
+	assert.Nil(t, err)
+	release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = s.Acquire(ctx, []string{"kafka"})
+	assert.NotNil(t, err)
+
+	// other trigger types are not limited
+	release, err = s.Acquire(context.Background(), []string{"prometheus"})
+	assert.Nil(t, err)
+	release()
+}
+
+func TestUpdate(t *testing.T) {
+	s, err := NewScheduler(Config{MaxConcurrentChecks: 1})
+	assert.Nil(t, err)
+
+	release, err := s.Acquire(context.Background(), nil)
+	assert.Nil(t, err)
+
+	assert.NotNil(t, s.Update(Config{StartJitter: 2}))
+	assert.Nil(t, s.Update(Config{MaxConcurrentChecks: 2, TriggerRateLimits: map[string]float64{"kafka": 1}}))
+
+	// the new limit doesn't count the check started before the update
+	first, err := s.Acquire(context.Background(), nil)
+	assert.Nil(t, err)
+	second, err := s.Acquire(context.Background(), nil)
+	assert.Nil(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = s.Acquire(ctx, nil)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	release()
+	first()
+	second()
+
+	release, err = s.Acquire(context.Background(), []string{"kafka"})
+	assert.Nil(t, err)
+	release()
+	_, err = s.Acquire(ctx, []string{"kafka"})
+	assert.NotNil(t, err)
+}
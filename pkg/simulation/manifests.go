@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/triggertest"
+)
+
+// LoadScaledObject selects the ScaledObject of the manifests and builds its triggers offline to read their metric specs.
+// targets sets the metric targets by trigger name, it is required for triggers that can't be built offline.
+// activationThresholds sets the activation thresholds by trigger name, triggers are active above 0 by default.
+func LoadScaledObject(ctx context.Context, manifests *triggertest.Manifests, name string, targets, activationThresholds map[string]float64) (*kedav1alpha1.ScaledObject, []Trigger, error) {
+	report, err := triggertest.Run(ctx, manifests, triggertest.Options{Name: name, Offline: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	if report.Kind != "ScaledObject" {
+		return nil, nil, fmt.Errorf("only ScaledObjects can be simulated, %s is a %s", report.Name, report.Kind)
+	}
+
+	var scaledObject *kedav1alpha1.ScaledObject
+	for _, obj := range manifests.ScalableObjects {
+		if so, ok := obj.(*kedav1alpha1.ScaledObject); ok && so.Name == report.Name && so.Namespace == report.Namespace {
+			scaledObject = so.DeepCopy()
+		}
+	}
+
+	known := map[string]bool{}
+	triggers := make([]Trigger, 0, len(report.Triggers))
+	for _, triggerReport := range report.Triggers {
+		trigger := Trigger{
+			Name:                triggerReport.Name,
+			Type:                triggerReport.Type,
+			ActivationThreshold: activationThresholds[triggerReport.Name],
+		}
+		if trigger.Name == "" {
+			trigger.Name = fmt.Sprintf("s%d-%s", triggerReport.Index, triggerReport.Type)
+			trigger.ActivationThreshold = activationThresholds[trigger.Name]
+		}
+		known[trigger.Name] = true
+
+		if len(triggerReport.MetricSpecs) > 0 {
+			trigger.MetricSpec = triggerReport.MetricSpecs[0]
+		}
+		target, hasTarget := targets[trigger.Name]
+		switch {
+		case hasTarget:
+			trigger.MetricSpec = withTarget(trigger.MetricSpec, trigger.Name, target)
+		case len(triggerReport.Errors) > 0:
+			return nil, nil, fmt.Errorf("trigger %s is invalid: %s", trigger.Name, strings.Join(triggerReport.Errors, "; "))
+		case len(triggerReport.MetricSpecs) == 0:
+			return nil, nil, fmt.Errorf("the target of trigger %s can't be read offline, set it explicitly", trigger.Name)
+		}
+		triggers = append(triggers, trigger)
+	}
+
+	for name := range targets {
+		if !known[name] {
+			return nil, nil, fmt.Errorf("target set for unknown trigger %s", name)
+		}
+	}
+	for name := range activationThresholds {
+		if !known[name] {
+			return nil, nil, fmt.Errorf("activation threshold set for unknown trigger %s", name)
+		}
+	}
+	return scaledObject, triggers, nil
+}
+
+// withTarget replaces the target value of the metric spec, triggers without a metric spec get an AverageValue target
+func withTarget(spec v2.MetricSpec, triggerName string, target float64) v2.MetricSpec {
+	spec = *spec.DeepCopy()
+	quantity := resource.NewMilliQuantity(int64(math.Round(target*1000)), resource.DecimalSI)
+	switch {
+	case spec.Resource != nil && spec.Resource.Target.Type == v2.UtilizationMetricType:
+		utilization := int32(target)
+		spec.Resource.Target.AverageUtilization = &utilization
+	case spec.Resource != nil:
+		spec.Resource.Target.AverageValue = quantity
+	case spec.External != nil && spec.External.Target.Type == v2.ValueMetricType:
+		spec.External.Target.Value = quantity
+	case spec.External != nil:
+		spec.External.Target.AverageValue = quantity
+	default:
+		spec = v2.MetricSpec{
+			Type: v2.ExternalMetricSourceType,
+			External: &v2.ExternalMetricSource{
+				Metric: v2.MetricIdentifier{Name: triggerName},
+				Target: v2.MetricTarget{Type: v2.AverageValueMetricType, AverageValue: quantity},
+			},
+		}
+	}
+	return spec
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulation
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const timeColumn = "time"
+
+// errorValue marks a sample where the trigger failed to return its value
+const errorValue = "error"
+
+// Series is a time series of trigger values, the value of a trigger holds until its next sample
+type Series struct {
+	// Start is the time of the first sample when the samples have absolute timestamps, zero otherwise
+	Start time.Time
+	// Samples are ordered by their offset
+	Samples []Sample
+}
+
+// Sample holds the values of the triggers at an offset from the start of the series
+type Sample struct {
+	Offset time.Duration
+	// Values are keyed by trigger name, a nil value means the trigger returned an error.
+	// Triggers missing in the sample keep their previous value.
+	Values map[string]*float64
+}
+
+// valueAt returns the last value of the trigger at or before the offset
+func (s *Series) valueAt(trigger string, offset time.Duration) (float64, error) {
+	var value *float64
+	found := false
+	for _, sample := range s.Samples {
+		if sample.Offset > offset {
+			break
+		}
+		if v, ok := sample.Values[trigger]; ok {
+			value, found = v, true
+		}
+	}
+	switch {
+	case !found:
+		return 0, fmt.Errorf("no value recorded for trigger %s yet", trigger)
+	case value == nil:
+		return 0, fmt.Errorf("trigger %s failed to return its value", trigger)
+	default:
+		return *value, nil
+	}
+}
+
+// end returns the offset of the last sample
+func (s *Series) end() time.Duration {
+	if len(s.Samples) == 0 {
+		return 0
+	}
+	return s.Samples[len(s.Samples)-1].Offset
+}
+
+// triggers returns the names of all triggers with at least one sample
+func (s *Series) triggers() map[string]bool {
+	result := map[string]bool{}
+	for _, sample := range s.Samples {
+		for name := range sample.Values {
+			result[name] = true
+		}
+	}
+	return result
+}
+
+// LoadSeriesFile reads a CSV or JSON series, the format is chosen by the file extension
+func LoadSeriesFile(path string) (*Series, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var series *Series
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		series, err = ParseJSON(bytes.NewReader(data))
+	case ".csv":
+		series, err = ParseCSV(bytes.NewReader(data))
+	default:
+		return nil, fmt.Errorf("%s: unsupported series format, expected a .csv or .json file", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	return series, nil
+}
+
+// ParseCSV reads a series with a header row, a "time" column and a column per trigger.
+// Empty cells keep the previous value of the trigger, "error" marks a failed trigger.
+func ParseCSV(reader io.Reader) (*Series, error) {
+	records, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("the series is empty")
+	}
+
+	header := records[0]
+	timeIndex := -1
+	for i, name := range header {
+		header[i] = strings.TrimSpace(name)
+		if strings.EqualFold(header[i], timeColumn) {
+			timeIndex = i
+		}
+	}
+	if timeIndex < 0 {
+		return nil, fmt.Errorf("the series has no %q column", timeColumn)
+	}
+
+	var times []string
+	var samples []Sample
+	for row, record := range records[1:] {
+		sample := Sample{Values: map[string]*float64{}}
+		for i, cell := range record {
+			cell = strings.TrimSpace(cell)
+			if i == timeIndex {
+				times = append(times, cell)
+				continue
+			}
+			if cell == "" {
+				continue
+			}
+			value, err := parseValue(cell)
+			if err != nil {
+				return nil, fmt.Errorf("row %d, column %s: %s", row+2, header[i], err)
+			}
+			sample.Values[header[i]] = value
+		}
+		samples = append(samples, sample)
+	}
+	return newSeries(times, samples)
+}
+
+// ParseJSON reads a series from an array of objects with a "time" field and a field per trigger.
+// Missing fields keep the previous value of the trigger, null or "error" marks a failed trigger.
+func ParseJSON(reader io.Reader) (*Series, error) {
+	var rows []map[string]interface{}
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	if err := decoder.Decode(&rows); err != nil {
+		return nil, err
+	}
+
+	var times []string
+	var samples []Sample
+	for row, fields := range rows {
+		sample := Sample{Values: map[string]*float64{}}
+		rawTime, ok := fields[timeColumn]
+		if !ok {
+			return nil, fmt.Errorf("sample %d has no %q field", row, timeColumn)
+		}
+		times = append(times, fmt.Sprint(rawTime))
+		for name, raw := range fields {
+			if name == timeColumn {
+				continue
+			}
+			if raw == nil {
+				sample.Values[name] = nil
+				continue
+			}
+			value, err := parseValue(fmt.Sprint(raw))
+			if err != nil {
+				return nil, fmt.Errorf("sample %d, field %s: %s", row, name, err)
+			}
+			sample.Values[name] = value
+		}
+		samples = append(samples, sample)
+	}
+	return newSeries(times, samples)
+}
+
+func parseValue(value string) (*float64, error) {
+	if strings.EqualFold(value, errorValue) {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q, expected a number or %q", value, errorValue)
+	}
+	return &parsed, nil
+}
+
+// newSeries sets the offsets of the samples, times are either all RFC3339 timestamps or all offsets
+// in seconds or as durations
+func newSeries(times []string, samples []Sample) (*Series, error) {
+	if len(samples) == 0 {
+		return nil, errors.New("the series has no samples")
+	}
+	series := &Series{Samples: samples}
+
+	absolute := false
+	if _, err := time.Parse(time.RFC3339, times[0]); err == nil {
+		absolute = true
+	}
+	for i, value := range times {
+		if absolute {
+			timestamp, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("sample %d: invalid time %q, all times have to be RFC3339 timestamps", i, value)
+			}
+			if i == 0 {
+				series.Start = timestamp
+			}
+			samples[i].Offset = timestamp.Sub(series.Start)
+		} else {
+			offset, err := parseOffset(value)
+			if err != nil {
+				return nil, fmt.Errorf("sample %d: %s", i, err)
+			}
+			samples[i].Offset = offset
+		}
+		if samples[i].Offset < 0 || (i > 0 && samples[i].Offset < samples[i-1].Offset) {
+			return nil, fmt.Errorf("sample %d: the samples have to be ordered by time", i)
+		}
+	}
+	return series, nil
+}
+
+func parseOffset(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	offset, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected seconds, a duration or a RFC3339 timestamp", value)
+	}
+	return offset, nil
+}
@@ -0,0 +1,571 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulation replays a time series of trigger values through the scaling decisions of a ScaledObject:
+// the scale loop and the scale executor of KEDA, the fallback of the metrics server and the replica calculation
+// of the HPA, and returns the resulting replica timeline without a cluster
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	fakescale "k8s.io/client-go/scale/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/fallback"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+	"github.com/kedacore/keda/v2/pkg/scaling/executor"
+	"github.com/kedacore/keda/v2/pkg/scaling/hpa"
+)
+
+const (
+	// SourceScaleLoop marks the steps of the KEDA scale loop
+	SourceScaleLoop = "ScaleLoop"
+	// SourceHPA marks the steps of the HPA controller
+	SourceHPA = "HPA"
+
+	defaultHPASyncPeriod = 15 * time.Second
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(kedav1alpha1.AddToScheme(scheme))
+}
+
+// Trigger describes how a trigger of the ScaledObject is replayed
+type Trigger struct {
+	// Name is the column of the trigger in the series, the scale loop names triggers
+	// by their name or s<index>-<type> if they don't have one
+	Name string
+	Type string
+	// MetricSpec is the metric the trigger exposes to the HPA, with the target the HPA scales on
+	MetricSpec v2.MetricSpec
+	// ActivationThreshold is the value the trigger has to exceed to be active
+	ActivationThreshold float64
+}
+
+// Options configures Run
+type Options struct {
+	// Start is the time of the first step, it defaults to the start of the series or the current time
+	Start time.Time
+	// Duration is the simulated time span, it defaults to the offset of the last sample of the series
+	Duration time.Duration
+	// InitialReplicas is the replica count of the scale target at the start
+	InitialReplicas int32
+	// HPASyncPeriod is the interval of the HPA controller, 15s by default
+	HPASyncPeriod time.Duration
+}
+
+// Timeline is the result of a simulation
+type Timeline struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Steps     []Step `json:"steps"`
+}
+
+// Step is a single check of the scale loop or sync of the HPA
+type Step struct {
+	Offset metav1.Duration `json:"offset"`
+	Time   metav1.Time     `json:"time"`
+	// Source is either ScaleLoop or HPA
+	Source string `json:"source"`
+	// Values are the trigger values at the step, keyed by trigger name, nil if the trigger failed
+	Values map[string]*float64 `json:"values"`
+	// IsActive and IsError are the results of the trigger checks of the scale loop
+	IsActive bool `json:"isActive,omitempty"`
+	IsError  bool `json:"isError,omitempty"`
+	// Action is the scaling action of the scale executor
+	Action string `json:"action,omitempty"`
+	// DesiredReplicas is the replica count calculated by the HPA
+	DesiredReplicas *int32 `json:"desiredReplicas,omitempty"`
+	// Fallback is set while at least one trigger is falling back
+	Fallback         bool    `json:"fallback,omitempty"`
+	PreviousReplicas int32   `json:"previousReplicas"`
+	Replicas         int32   `json:"replicas"`
+	Message          string  `json:"message,omitempty"`
+	Events           []Event `json:"events,omitempty"`
+}
+
+// Event is a Kubernetes event recorded during a step
+type Event struct {
+	Type    string `json:"type"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// clock is the simulated time, shared by the scale executor and the replayed scalers
+type clock struct {
+	start  time.Time
+	offset time.Duration
+}
+
+func (c *clock) now() time.Time {
+	return c.start.Add(c.offset)
+}
+
+type simulation struct {
+	clock        *clock
+	client       client.Client
+	key          types.NamespacedName
+	targetKey    types.NamespacedName
+	withTriggers *kedav1alpha1.WithTriggers
+	triggers     []Trigger
+	series       *Series
+	scalers      []*replayScaler
+	cache        *cache.ScalersCache
+	executor     executor.ScaleExecutor
+	recorder     *timelineRecorder
+	hpa          hpa.Autoscaler
+}
+
+// Run replays the series through the scaling decisions of the ScaledObject. The scale loop checks the triggers every
+// polling interval and hands the result to the scale executor, the HPA syncs every HPASyncPeriod while the scale target
+// has replicas. The scale target is simulated as a Deployment, whatever its kind.
+func Run(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, triggers []Trigger, series *Series, options Options) (*Timeline, error) {
+	if err := validate(scaledObject, triggers, series); err != nil {
+		return nil, err
+	}
+	if options.HPASyncPeriod <= 0 {
+		options.HPASyncPeriod = defaultHPASyncPeriod
+	}
+	if options.Duration <= 0 {
+		options.Duration = series.end()
+	}
+	if options.Start.IsZero() {
+		options.Start = series.Start
+	}
+	if options.Start.IsZero() {
+		options.Start = time.Now()
+	}
+
+	s, err := newSimulation(scaledObject, triggers, series, options)
+	if err != nil {
+		return nil, err
+	}
+	timeline := &Timeline{Namespace: s.key.Namespace, Name: s.key.Name}
+
+	nextCheck, nextSync := time.Duration(0), time.Duration(0)
+	idleChecks := 0
+	for nextCheck <= options.Duration || nextSync <= options.Duration {
+		// the scale loop goes first when both are due
+		if nextCheck <= nextSync {
+			s.clock.offset = nextCheck
+			step, isActive, err := s.checkScalers(ctx)
+			if err != nil {
+				return nil, err
+			}
+			timeline.Steps = append(timeline.Steps, step)
+			if isActive {
+				idleChecks = 0
+			} else {
+				idleChecks++
+			}
+			nextCheck += s.withTriggers.GetAdaptivePollingInterval(isActive, idleChecks)
+		} else {
+			s.clock.offset = nextSync
+			step, err := s.syncHPA(ctx)
+			if err != nil {
+				return nil, err
+			}
+			timeline.Steps = append(timeline.Steps, step)
+			nextSync += options.HPASyncPeriod
+		}
+	}
+	return timeline, nil
+}
+
+func validate(scaledObject *kedav1alpha1.ScaledObject, triggers []Trigger, series *Series) error {
+	if scaledObject.Spec.ScaleTargetRef == nil || scaledObject.Spec.ScaleTargetRef.Name == "" {
+		return fmt.Errorf("the ScaledObject has no scale target")
+	}
+	if len(triggers) == 0 {
+		return fmt.Errorf("the ScaledObject has no triggers")
+	}
+
+	columns := series.triggers()
+	known := map[string]bool{}
+	for _, trigger := range triggers {
+		known[trigger.Name] = true
+		if !columns[trigger.Name] {
+			return fmt.Errorf("the series has no values for trigger %s", trigger.Name)
+		}
+		var target v2.MetricTarget
+		switch {
+		case trigger.MetricSpec.External != nil:
+			target = trigger.MetricSpec.External.Target
+		case trigger.MetricSpec.Resource != nil:
+			target = trigger.MetricSpec.Resource.Target
+		default:
+			return fmt.Errorf("trigger %s has no External or Resource metric", trigger.Name)
+		}
+		if targetValue(target) <= 0 {
+			return fmt.Errorf("trigger %s has no positive %s target", trigger.Name, target.Type)
+		}
+	}
+
+	var unknown []string
+	for column := range columns {
+		if !known[column] {
+			unknown = append(unknown, column)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("the series has values for unknown triggers: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+func targetValue(target v2.MetricTarget) float64 {
+	switch {
+	case target.Type == v2.AverageValueMetricType && target.AverageValue != nil:
+		return target.AverageValue.AsApproximateFloat64()
+	case target.Type == v2.ValueMetricType && target.Value != nil:
+		return target.Value.AsApproximateFloat64()
+	case target.Type == v2.UtilizationMetricType && target.AverageUtilization != nil:
+		return float64(*target.AverageUtilization)
+	default:
+		return 0
+	}
+}
+
+func newSimulation(scaledObject *kedav1alpha1.ScaledObject, triggers []Trigger, series *Series, options Options) (*simulation, error) {
+	scaledObject = scaledObject.DeepCopy()
+	if scaledObject.Namespace == "" {
+		scaledObject.Namespace = "default"
+	}
+	scaledObject.ResourceVersion = ""
+	scaledObject.Status = kedav1alpha1.ScaledObjectStatus{
+		Conditions:      *kedav1alpha1.GetInitializedConditions(),
+		ScaleTargetKind: "apps/v1.Deployment",
+		ScaleTargetGVKR: &kedav1alpha1.GroupVersionKindResource{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments"},
+	}
+
+	replicas := options.InitialReplicas
+	target := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: scaledObject.Spec.ScaleTargetRef.Name, Namespace: scaledObject.Namespace},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+
+	s := &simulation{
+		clock:     &clock{start: options.Start.Truncate(time.Second)},
+		client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(scaledObject, target).Build(),
+		key:       types.NamespacedName{Namespace: scaledObject.Namespace, Name: scaledObject.Name},
+		targetKey: types.NamespacedName{Namespace: target.Namespace, Name: target.Name},
+		withTriggers: &kedav1alpha1.WithTriggers{
+			TypeMeta:   metav1.TypeMeta{APIVersion: scaledObject.APIVersion, Kind: "ScaledObject"},
+			ObjectMeta: scaledObject.ObjectMeta,
+			Spec: kedav1alpha1.WithTriggersSpec{
+				PollingInterval:        scaledObject.Spec.PollingInterval,
+				ActivePollingInterval:  scaledObject.Spec.ActivePollingInterval,
+				IdlePollingInterval:    scaledObject.Spec.IdlePollingInterval,
+				MaxIdlePollingInterval: scaledObject.Spec.MaxIdlePollingInterval,
+				Triggers:               scaledObject.Spec.Triggers,
+			},
+		},
+		triggers: triggers,
+		series:   series,
+		recorder: &timelineRecorder{},
+	}
+
+	scaleClient := &fakescale.FakeScaleClient{}
+	scaleClient.AddReactor("get", "*", func(k8stesting.Action) (bool, runtime.Object, error) {
+		replicas, err := s.replicas(context.Background())
+		return true, &autoscalingv1.Scale{Spec: autoscalingv1.ScaleSpec{Replicas: replicas}}, err
+	})
+	scaleClient.AddReactor("update", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		scale := action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.Scale)
+		return true, scale, s.setReplicas(context.Background(), scale.Spec.Replicas)
+	})
+	s.executor = executor.NewScaleExecutorWithClock(s.client, scaleClient, scheme, s.recorder, s.clock.now)
+
+	builders := make([]cache.ScalerBuilder, 0, len(triggers))
+	for _, trigger := range triggers {
+		scaler := &replayScaler{trigger: trigger, series: series, clock: s.clock}
+		s.scalers = append(s.scalers, scaler)
+		builders = append(builders, cache.ScalerBuilder{
+			Scaler: scaler,
+			// rebuilding a replayed scaler doesn't change its values
+			Factory:     func(context.Context) (scalers.Scaler, error) { return scaler, nil },
+			TriggerName: trigger.Name,
+			TriggerType: trigger.Type,
+		})
+	}
+	s.cache = &cache.ScalersCache{
+		Scalers:         builders,
+		Logger:          logf.Log.WithName("simulation"),
+		Recorder:        s.recorder,
+		ObjectKind:      "ScaledObject",
+		ObjectNamespace: scaledObject.Namespace,
+		ObjectName:      scaledObject.Name,
+	}
+	return s, nil
+}
+
+// checkScalers is a single check of the scale loop, like scaleHandler.checkScalers
+func (s *simulation) checkScalers(ctx context.Context) (Step, bool, error) {
+	step, err := s.newStep(ctx, SourceScaleLoop)
+	if err != nil {
+		return step, false, err
+	}
+
+	scaledObject, err := s.scaledObject(ctx)
+	if err != nil {
+		return step, false, err
+	}
+	step.IsActive, step.IsError, _ = s.cache.IsScaledObjectActive(ctx, scaledObject)
+	s.executor.RequestScale(ctx, scaledObject, step.IsActive, step.IsError)
+	if decision, ok := s.executor.GetLastDecision("ScaledObject", s.key.Namespace, s.key.Name); ok {
+		step.Action = decision.Action
+	}
+
+	return step, step.IsActive, s.finishStep(ctx, &step)
+}
+
+// syncHPA is a single sync of the HPA controller, the metrics of External triggers are read through the
+// fallback of the metrics server
+func (s *simulation) syncHPA(ctx context.Context) (Step, error) {
+	step, err := s.newStep(ctx, SourceHPA)
+	if err != nil {
+		return step, err
+	}
+
+	scaledObject, err := s.scaledObject(ctx)
+	if err != nil {
+		return step, err
+	}
+	minReplicas, maxReplicas, err := hpaLimits(scaledObject)
+	if err != nil {
+		return step, err
+	}
+	if step.PreviousReplicas == 0 {
+		step.Message = "scaling is disabled since the replica count of the target is zero"
+		return step, s.finishStep(ctx, &step)
+	}
+
+	var proposals []int32
+	invalidMetrics := 0
+	for i, trigger := range s.triggers {
+		value, err := s.metricValue(ctx, i, trigger)
+		if err == nil {
+			var replicas int32
+			replicas, err = hpa.ReplicasForMetric(trigger.MetricSpec, value, step.PreviousReplicas)
+			proposals = append(proposals, replicas)
+		}
+		if err != nil {
+			invalidMetrics++
+		}
+	}
+
+	now := s.clock.now()
+	behavior := hpa.Behavior(scaledObject)
+	desired, ok := s.hpa.DesiredReplicas(now, behavior, step.PreviousReplicas, minReplicas, maxReplicas, proposals, invalidMetrics)
+	if !ok {
+		step.Message = "the HPA doesn't scale because some metrics are invalid"
+		return step, s.finishStep(ctx, &step)
+	}
+	step.DesiredReplicas = &desired
+	if desired != step.PreviousReplicas {
+		if err := s.setReplicas(ctx, desired); err != nil {
+			return step, err
+		}
+		s.hpa.RecordScale(now, behavior, step.PreviousReplicas, desired)
+	}
+	return step, s.finishStep(ctx, &step)
+}
+
+// hpaLimits returns the minReplicas and maxReplicas of the HPA, like the ScaledObject controller sets them
+func hpaLimits(scaledObject *kedav1alpha1.ScaledObject) (int32, int32, error) {
+	pausedCount, err := executor.GetPausedReplicaCount(scaledObject)
+	if err != nil {
+		return 0, 0, err
+	}
+	if pausedCount != nil {
+		// MinReplicas on HPA can't be 0
+		if *pausedCount == 0 {
+			return 1, 1, nil
+		}
+		return *pausedCount, *pausedCount, nil
+	}
+	minReplicas, maxReplicas := hpa.Limits(scaledObject)
+	return minReplicas, maxReplicas, nil
+}
+
+func (s *simulation) metricValue(ctx context.Context, index int, trigger Trigger) (float64, error) {
+	if trigger.MetricSpec.External == nil {
+		// resource metrics are read by the HPA from the metrics server of the cluster, not from KEDA
+		return s.scalers[index].value()
+	}
+
+	// every metric is requested separately by the HPA, the metrics server reads the ScaledObject for each of them
+	scaledObject, err := s.scaledObject(ctx)
+	if err != nil {
+		return 0, err
+	}
+	metricName := trigger.MetricSpec.External.Metric.Name
+	metrics, err := s.cache.GetMetricsForScaler(ctx, index, metricName, labels.Everything())
+	metrics, err = fallback.GetMetricsWithFallback(ctx, s.client, metrics, err, metricName, scaledObject, trigger.MetricSpec)
+	if err != nil {
+		return 0, err
+	}
+	var value float64
+	for _, metric := range metrics {
+		value += metric.Value.AsApproximateFloat64()
+	}
+	return value, nil
+}
+
+func (s *simulation) newStep(ctx context.Context, source string) (Step, error) {
+	s.recorder.events = nil
+	step := Step{
+		Offset: metav1.Duration{Duration: s.clock.offset},
+		Time:   metav1.NewTime(s.clock.now()),
+		Source: source,
+		Values: map[string]*float64{},
+	}
+	for _, scaler := range s.scalers {
+		if value, err := scaler.value(); err == nil {
+			step.Values[scaler.trigger.Name] = &value
+		} else {
+			step.Values[scaler.trigger.Name] = nil
+		}
+	}
+
+	replicas, err := s.replicas(ctx)
+	step.PreviousReplicas = replicas
+	return step, err
+}
+
+func (s *simulation) finishStep(ctx context.Context, step *Step) error {
+	replicas, err := s.replicas(ctx)
+	if err != nil {
+		return err
+	}
+	scaledObject, err := s.scaledObject(ctx)
+	if err != nil {
+		return err
+	}
+	step.Replicas = replicas
+	fallback := scaledObject.Status.Conditions.GetFallbackCondition()
+	step.Fallback = fallback.IsTrue()
+	step.Events = s.recorder.events
+	return nil
+}
+
+func (s *simulation) scaledObject(ctx context.Context) (*kedav1alpha1.ScaledObject, error) {
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	return scaledObject, s.client.Get(ctx, s.key, scaledObject)
+}
+
+func (s *simulation) replicas(ctx context.Context) (int32, error) {
+	target := &appsv1.Deployment{}
+	if err := s.client.Get(ctx, s.targetKey, target); err != nil {
+		return 0, err
+	}
+	return *target.Spec.Replicas, nil
+}
+
+func (s *simulation) setReplicas(ctx context.Context, replicas int32) error {
+	target := &appsv1.Deployment{}
+	if err := s.client.Get(ctx, s.targetKey, target); err != nil {
+		return err
+	}
+	target.Spec.Replicas = &replicas
+	return s.client.Update(ctx, target)
+}
+
+// replayScaler returns the values of a trigger from the series at the simulated time
+type replayScaler struct {
+	trigger Trigger
+	series  *Series
+	clock   *clock
+}
+
+func (s *replayScaler) value() (float64, error) {
+	return s.series.valueAt(s.trigger.Name, s.clock.offset)
+}
+
+func (s *replayScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	value, err := s.value()
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+	return []external_metrics.ExternalMetricValue{{
+		MetricName: metricName,
+		Value:      *resource.NewMilliQuantity(int64(math.Round(value*1000)), resource.DecimalSI),
+		Timestamp:  metav1.NewTime(s.clock.now()),
+	}}, nil
+}
+
+func (s *replayScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	return []v2.MetricSpec{s.trigger.MetricSpec}
+}
+
+func (s *replayScaler) IsActive(ctx context.Context) (bool, error) {
+	if s.trigger.MetricSpec.Resource != nil {
+		// like the cpu and memory scalers, resource metric triggers are always active
+		return true, nil
+	}
+	value, err := s.value()
+	if err != nil {
+		return false, err
+	}
+	return value > s.trigger.ActivationThreshold, nil
+}
+
+func (s *replayScaler) Close(context.Context) error {
+	return nil
+}
+
+// timelineRecorder collects the events of the current step
+type timelineRecorder struct {
+	events []Event
+}
+
+func (r *timelineRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.events = append(r.events, Event{Type: eventtype, Reason: reason, Message: message})
+}
+
+func (r *timelineRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *timelineRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Eventf(object, eventtype, reason, messageFmt, args...)
+}
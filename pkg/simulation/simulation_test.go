@@ -0,0 +1,227 @@
+package simulation
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metrics"
+	"github.com/kedacore/keda/v2/pkg/triggertest"
+)
+
+func int32Ptr(value int32) *int32 {
+	return &value
+}
+
+func queueTrigger(target int64) Trigger {
+	return Trigger{
+		Name: "queue",
+		Type: "external",
+		MetricSpec: v2.MetricSpec{
+			Type: v2.ExternalMetricSourceType,
+			External: &v2.ExternalMetricSource{
+				Metric: v2.MetricIdentifier{Name: "s0-queue"},
+				Target: v2.MetricTarget{Type: v2.AverageValueMetricType, AverageValue: resource.NewQuantity(target, resource.DecimalSI)},
+			},
+		},
+	}
+}
+
+func testScaledObject() *kedav1alpha1.ScaledObject {
+	return &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef:  &kedav1alpha1.ScaleTarget{Name: "app"},
+			PollingInterval: int32Ptr(30),
+			CooldownPeriod:  int32Ptr(60),
+			MaxReplicaCount: int32Ptr(10),
+		},
+	}
+}
+
+func stepAt(timeline *Timeline, source string, offset time.Duration) Step {
+	for _, step := range timeline.Steps {
+		if step.Source == source && step.Offset.Duration == offset {
+			return step
+		}
+	}
+	return Step{}
+}
+
+func TestRunActivationAndCooldown(t *testing.T) {
+	series, err := ParseCSV(strings.NewReader("time,queue\n0,0\n30,50\n120,0\n240,0\n"))
+	assert.NoError(t, err)
+
+	timeline, err := Run(context.Background(), testScaledObject(), []Trigger{queueTrigger(10)}, series, Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, "app", timeline.Name)
+
+	assert.Equal(t, int32(0), stepAt(timeline, SourceScaleLoop, 0).Replicas)
+	assert.Equal(t, int32(0), stepAt(timeline, SourceHPA, 0).Replicas)
+
+	activated := stepAt(timeline, SourceScaleLoop, 30*time.Second)
+	assert.True(t, activated.IsActive)
+	assert.Equal(t, metrics.ScalingActionActivated, activated.Action)
+	assert.Equal(t, int32(1), activated.Replicas)
+	assert.Len(t, activated.Events, 1)
+
+	// the HPA scales up at most to max(2 * current replicas, 4) per sync without a behavior
+	assert.Equal(t, int32(4), stepAt(timeline, SourceHPA, 30*time.Second).Replicas)
+	assert.Equal(t, int32(5), stepAt(timeline, SourceHPA, 45*time.Second).Replicas)
+
+	// the downscale stabilization window of the HPA keeps the replicas once the queue is empty
+	assert.Equal(t, int32(5), stepAt(timeline, SourceHPA, 135*time.Second).Replicas)
+
+	// the trigger was last active at 90s, the cooldown period expires at 150s
+	assert.Equal(t, int32(5), stepAt(timeline, SourceScaleLoop, 150*time.Second).Replicas)
+	deactivated := stepAt(timeline, SourceScaleLoop, 180*time.Second)
+	assert.Equal(t, metrics.ScalingActionDeactivated, deactivated.Action)
+	assert.Equal(t, int32(0), deactivated.Replicas)
+	assert.Equal(t, "scaling is disabled since the replica count of the target is zero", stepAt(timeline, SourceHPA, 195*time.Second).Message)
+}
+
+func TestRunFallback(t *testing.T) {
+	series, err := ParseCSV(strings.NewReader("time,queue\n0,15\n60,error\n180,error\n"))
+	assert.NoError(t, err)
+
+	scaledObject := testScaledObject()
+	scaledObject.Spec.MinReplicaCount = int32Ptr(1)
+	scaledObject.Spec.Fallback = &kedav1alpha1.Fallback{FailureThreshold: 2, Replicas: 6}
+
+	timeline, err := Run(context.Background(), scaledObject, []Trigger{queueTrigger(10)}, series, Options{InitialReplicas: 1})
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(2), stepAt(timeline, SourceHPA, 0).Replicas)
+
+	failing := stepAt(timeline, SourceScaleLoop, 60*time.Second)
+	assert.True(t, failing.IsError)
+	assert.False(t, failing.IsActive)
+	assert.Nil(t, failing.Values["queue"])
+	assert.Equal(t, metrics.ScalingActionFallback, failing.Action)
+	assert.Equal(t, int32(6), failing.Replicas)
+
+	// the metric is invalid for the HPA until it failed more often than the failure threshold
+	assert.Equal(t, "the HPA doesn't scale because some metrics are invalid", stepAt(timeline, SourceHPA, 60*time.Second).Message)
+	fallback := stepAt(timeline, SourceHPA, 120*time.Second)
+	assert.True(t, fallback.Fallback)
+	assert.Equal(t, int32(6), *fallback.DesiredReplicas)
+}
+
+func TestRunBehavior(t *testing.T) {
+	series, err := ParseJSON(strings.NewReader(`[{"time": "0s", "queue": 100}, {"time": "3m", "queue": 100}]`))
+	assert.NoError(t, err)
+
+	scaledObject := testScaledObject()
+	scaledObject.Spec.Advanced = &kedav1alpha1.AdvancedConfig{
+		HorizontalPodAutoscalerConfig: &kedav1alpha1.HorizontalPodAutoscalerConfig{
+			Behavior: &v2.HorizontalPodAutoscalerBehavior{
+				ScaleUp: &v2.HPAScalingRules{
+					Policies: []v2.HPAScalingPolicy{{Type: v2.PodsScalingPolicy, Value: 1, PeriodSeconds: 60}},
+				},
+			},
+		},
+	}
+
+	timeline, err := Run(context.Background(), scaledObject, []Trigger{queueTrigger(10)}, series, Options{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(2), stepAt(timeline, SourceHPA, 0).Replicas)
+	assert.Equal(t, int32(2), stepAt(timeline, SourceHPA, 45*time.Second).Replicas)
+	assert.Equal(t, int32(3), stepAt(timeline, SourceHPA, 60*time.Second).Replicas)
+	assert.Equal(t, int32(4), stepAt(timeline, SourceHPA, 120*time.Second).Replicas)
+	assert.Equal(t, int32(5), stepAt(timeline, SourceHPA, 180*time.Second).Replicas)
+}
+
+func TestRunValidation(t *testing.T) {
+	series, err := ParseCSV(strings.NewReader("time,queue,other\n0,1,2\n"))
+	assert.NoError(t, err)
+
+	_, err = Run(context.Background(), testScaledObject(), []Trigger{queueTrigger(10)}, series, Options{})
+	assert.EqualError(t, err, "the series has values for unknown triggers: other")
+
+	_, err = Run(context.Background(), testScaledObject(), []Trigger{queueTrigger(0)}, series, Options{})
+	assert.Error(t, err)
+}
+
+func TestParseSeries(t *testing.T) {
+	series, err := ParseCSV(strings.NewReader("time,a,b\n2022-05-01T10:00:00Z,1,error\n2022-05-01T10:01:00Z,,3\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC), series.Start)
+	assert.Equal(t, time.Minute, series.end())
+
+	value, err := series.valueAt("a", 90*time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, value)
+	_, err = series.valueAt("b", 30*time.Second)
+	assert.Error(t, err)
+	value, err = series.valueAt("b", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 3.0, value)
+
+	series, err = ParseJSON(strings.NewReader(`[{"time": 0, "a": 1}, {"time": 1.5, "a": null}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, 1500*time.Millisecond, series.end())
+	_, err = series.valueAt("a", 2*time.Second)
+	assert.Error(t, err)
+
+	_, err = ParseCSV(strings.NewReader("time,a\n60,1\n30,2\n"))
+	assert.Error(t, err)
+	_, err = ParseCSV(strings.NewReader("offset,a\n0,1\n"))
+	assert.Error(t, err)
+	_, err = ParseCSV(strings.NewReader("time,a\n0,many\n"))
+	assert.Error(t, err)
+}
+
+const testManifests = `
+apiVersion: keda.sh/v1alpha1
+kind: ScaledObject
+metadata:
+  name: app
+spec:
+  scaleTargetRef:
+    name: app
+  triggers:
+  - type: prometheus
+    name: requests
+    metadata:
+      serverAddress: http://prometheus:9090
+      metricName: http_requests_total
+      query: sum(rate(http_requests_total[2m]))
+      threshold: "100"
+  - type: kafka
+    metadata:
+      bootstrapServers: kafka:9092
+      consumerGroup: group
+      topic: topic
+`
+
+func TestLoadScaledObject(t *testing.T) {
+	manifests := triggertest.NewManifests("default")
+	assert.NoError(t, manifests.Load(strings.NewReader(testManifests)))
+
+	_, _, err := LoadScaledObject(context.Background(), manifests, "", nil, nil)
+	assert.EqualError(t, err, "the target of trigger s1-kafka can't be read offline, set it explicitly")
+
+	scaledObject, triggers, err := LoadScaledObject(context.Background(), manifests, "", map[string]float64{"s1-kafka": 5}, map[string]float64{"requests": 10})
+	assert.NoError(t, err)
+	assert.Equal(t, "app", scaledObject.Name)
+	assert.Len(t, triggers, 2)
+
+	assert.Equal(t, "requests", triggers[0].Name)
+	assert.Equal(t, 10.0, triggers[0].ActivationThreshold)
+	assert.Equal(t, "s0-prometheus-http_requests_total", triggers[0].MetricSpec.External.Metric.Name)
+	assert.Equal(t, 100.0, targetValue(triggers[0].MetricSpec.External.Target))
+
+	assert.Equal(t, "s1-kafka", triggers[1].Name)
+	assert.Equal(t, 5.0, targetValue(triggers[1].MetricSpec.External.Target))
+
+	_, _, err = LoadScaledObject(context.Background(), manifests, "", map[string]float64{"s1-kafka": 5, "other": 1}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kedacore/keda/v2/version"
+)
+
+const tracerName = "github.com/kedacore/keda/v2"
+
+// Span attribute keys shared by all KEDA spans
+const (
+	ScalableObjectKindKey      = attribute.Key("keda.scalable_object.kind")
+	ScalableObjectNamespaceKey = attribute.Key("keda.scalable_object.namespace")
+	ScalableObjectNameKey      = attribute.Key("keda.scalable_object.name")
+	TriggerTypeKey             = attribute.Key("keda.trigger.type")
+	TriggerIndexKey            = attribute.Key("keda.trigger.index")
+	OutcomeKey                 = attribute.Key("keda.outcome")
+)
+
+// Outcome values recorded with the OutcomeKey attribute
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+)
+
+// Config holds the settings of the OTLP trace exporter
+type Config struct {
+	// Endpoint is the host:port of the OTLP gRPC collector, tracing is disabled when empty
+	Endpoint string
+	// Insecure disables TLS for the connection to the collector
+	Insecure bool
+	// SampleRatio is the fraction (0-1) of new traces that are sampled, spans with a sampled parent are always sampled
+	SampleRatio float64
+	// ServiceName is reported as the service.name resource attribute
+	ServiceName string
+}
+
+// Init installs the global tracer provider exporting spans via OTLP.
+// The returned function flushes and stops the exporter, it is a no-op when tracing is disabled.
+func Init(ctx context.Context, config Config) (func(context.Context) error, error) {
+	if config.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return nil, fmt.Errorf("tracing sample ratio must be between 0 and 1, got %v", config.SampleRatio)
+	}
+
+	opts := []otlpgrpc.Option{otlpgrpc.WithEndpoint(config.Endpoint)}
+	if config.Insecure {
+		opts = append(opts, otlpgrpc.WithInsecure())
+	}
+	exporter, err := otlp.NewExporter(ctx, otlpgrpc.NewDriver(opts...))
+	if err != nil {
+		return nil, fmt.Errorf("error creating OTLP trace exporter: %s", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.ServiceNameKey.String(config.ServiceName),
+			semconv.ServiceVersionKey.String(version.Version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a span with the KEDA tracer, it is a no-op span when tracing is disabled
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// ScalableObjectAttributes returns the span attributes identifying a ScaledObject or ScaledJob
+func ScalableObjectAttributes(kind, namespace, name string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		ScalableObjectKindKey.String(kind),
+		ScalableObjectNamespaceKey.String(namespace),
+		ScalableObjectNameKey.String(name),
+	}
+}
+
+// TriggerAttributes returns the span attributes identifying a trigger
+func TriggerAttributes(triggerType string, triggerIndex int) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		TriggerTypeKey.String(triggerType),
+		TriggerIndexKey.Int(triggerIndex),
+	}
+}
+
+// EndSpan records the outcome of the traced operation and ends the span
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(OutcomeKey.String(OutcomeError))
+	} else {
+		span.SetAttributes(OutcomeKey.String(OutcomeSuccess))
+	}
+	span.End()
+}
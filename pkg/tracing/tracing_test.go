@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInitDisabled(t *testing.T) {
+	shutdown, err := Init(context.Background(), Config{})
+	assert.Nil(t, err)
+	assert.Nil(t, shutdown(context.Background()))
+}
+
+func TestInitInvalidSampleRatio(t *testing.T) {
+	_, err := Init(context.Background(), Config{Endpoint: "localhost:4317", SampleRatio: 2})
+	assert.NotNil(t, err)
+}
+
+func TestEndSpanRecordsOutcome(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	ctx, parent := StartSpan(context.Background(), "parent", trace.WithAttributes(ScalableObjectAttributes("ScaledObject", "default", "test")...))
+	_, child := StartSpan(ctx, "child", trace.WithAttributes(TriggerAttributes("kafka", 1)...))
+	EndSpan(child, errors.New("connection refused"))
+	EndSpan(parent, nil)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(t, codes.Error, spans[0].StatusCode)
+	assert.Contains(t, spans[0].Attributes, TriggerTypeKey.String("kafka"))
+	assert.Contains(t, spans[0].Attributes, TriggerIndexKey.Int(1))
+	assert.Contains(t, spans[0].Attributes, OutcomeKey.String(OutcomeError))
+
+	assert.Equal(t, "parent", spans[1].Name)
+	assert.Contains(t, spans[1].Attributes, ScalableObjectNameKey.String("test"))
+	assert.Contains(t, spans[1].Attributes, OutcomeKey.String(OutcomeSuccess))
+}
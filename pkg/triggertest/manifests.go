@@ -0,0 +1,173 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package triggertest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(kedav1alpha1.AddToScheme(scheme))
+}
+
+// Manifests holds the objects read from manifest files
+type Manifests struct {
+	// ScalableObjects are the ScaledObjects and ScaledJobs
+	ScalableObjects []client.Object
+	// Objects are all the other objects: TriggerAuthentications, ClusterTriggerAuthentications, Secrets, ConfigMaps and scale targets
+	Objects []client.Object
+
+	namespace string
+}
+
+// NewManifests creates empty Manifests, objects without a namespace are placed in the passed namespace
+func NewManifests(namespace string) *Manifests {
+	return &Manifests{namespace: namespace}
+}
+
+// Load reads all YAML or JSON documents of the reader
+func (m *Manifests) Load(reader io.Reader) error {
+	decoder := yaml.NewYAMLOrJSONDecoder(reader, 4096)
+	deserializer := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	for {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("error reading manifest: %s", err)
+		}
+		if len(bytes.TrimSpace(raw.Raw)) == 0 || bytes.Equal(bytes.TrimSpace(raw.Raw), []byte("null")) {
+			continue
+		}
+
+		obj, _, err := deserializer.Decode(raw.Raw, nil, nil)
+		if runtime.IsNotRegisteredError(err) {
+			// custom scale targets are kept as unstructured objects
+			unstruct := &unstructured.Unstructured{}
+			if err := unstruct.UnmarshalJSON(raw.Raw); err != nil {
+				return fmt.Errorf("error decoding manifest: %s", err)
+			}
+			obj = unstruct
+		} else if err != nil {
+			return fmt.Errorf("error decoding manifest: %s", err)
+		}
+		m.add(obj)
+	}
+}
+
+// LoadFile reads all YAML or JSON documents of the file
+func (m *Manifests) LoadFile(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := m.Load(bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("%s: %s", path, err)
+	}
+	return nil
+}
+
+// AddSecretValue sets the key of the Secret with the passed name, the Secret is created if it wasn't loaded from a manifest
+func (m *Manifests) AddSecretValue(name, key string, value []byte) {
+	for _, obj := range m.Objects {
+		if secret, ok := obj.(*corev1.Secret); ok && secret.Name == name && secret.Namespace == m.namespace {
+			if secret.Data == nil {
+				secret.Data = map[string][]byte{}
+			}
+			secret.Data[key] = value
+			return
+		}
+	}
+	m.Objects = append(m.Objects, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: m.namespace},
+		Data:       map[string][]byte{key: value},
+	})
+}
+
+// AddSecretValueFromFile parses a name/key=path definition and sets the key of the Secret to the content of the file
+func (m *Manifests) AddSecretValueFromFile(definition string) error {
+	ref, path, ok := cut(definition, "=")
+	name, key, ok2 := cut(ref, "/")
+	if !ok || !ok2 || name == "" || key == "" || path == "" {
+		return fmt.Errorf("invalid secret value %q, expected <secret name>/<key>=<file>", definition)
+	}
+	value, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	m.AddSecretValue(name, key, value)
+	return nil
+}
+
+func (m *Manifests) add(obj runtime.Object) {
+	clientObj, ok := obj.(client.Object)
+	if !ok {
+		return
+	}
+	if clientObj.GetNamespace() == "" && !isClusterScoped(clientObj) {
+		clientObj.SetNamespace(m.namespace)
+	}
+
+	switch o := clientObj.(type) {
+	case *kedav1alpha1.ScaledObject, *kedav1alpha1.ScaledJob:
+		m.ScalableObjects = append(m.ScalableObjects, o)
+		return
+	case *corev1.Secret:
+		// stringData is merged into data by the API server, the fake client doesn't
+		for key, value := range o.StringData {
+			if o.Data == nil {
+				o.Data = map[string][]byte{}
+			}
+			o.Data[key] = []byte(value)
+		}
+		o.StringData = nil
+	}
+	m.Objects = append(m.Objects, clientObj)
+}
+
+func isClusterScoped(obj client.Object) bool {
+	_, ok := obj.(*kedav1alpha1.ClusterTriggerAuthentication)
+	return ok
+}
+
+func cut(s, sep string) (string, string, bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,358 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package triggertest builds the scalers of a ScaledObject or ScaledJob from manifest files, without a cluster,
+// so trigger definitions can be validated and tried locally
+package triggertest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+	"github.com/kedacore/keda/v2/pkg/scaling"
+)
+
+const redacted = "<redacted>"
+
+// connectingTriggerTypes are the scalers that connect to their event source when they are built,
+// they are not built in offline mode
+var connectingTriggerTypes = map[string]bool{
+	"cassandra":              true,
+	"elasticsearch":          true,
+	"gcp-storage":            true,
+	"kafka":                  true,
+	"mongodb":                true,
+	"mssql":                  true,
+	"mysql":                  true,
+	"openstack-metric":       true,
+	"openstack-swift":        true,
+	"postgresql":             true,
+	"predictkube":            true,
+	"rabbitmq":               true,
+	"redis":                  true,
+	"redis-cluster":          true,
+	"redis-sentinel":         true,
+	"redis-streams":          true,
+	"redis-cluster-streams":  true,
+	"redis-sentinel-streams": true,
+}
+
+// Options configures Run
+type Options struct {
+	// Name selects the ScaledObject or ScaledJob when the manifests contain several of them
+	Name string
+	// Offline only validates the triggers, scalers connecting to their event source when built are skipped
+	Offline bool
+	// Live queries the activity and the metric values of the triggers
+	Live bool
+	// ShowSecrets prints the resolved authentication parameters and environment values instead of redacting them
+	ShowSecrets bool
+	// Timeout bounds building and querying every single scaler
+	Timeout time.Duration
+}
+
+// Report describes the triggers of a ScaledObject or ScaledJob
+type Report struct {
+	Kind      string          `json:"kind"`
+	Namespace string          `json:"namespace"`
+	Name      string          `json:"name"`
+	Warnings  []string        `json:"warnings,omitempty"`
+	Triggers  []TriggerReport `json:"triggers"`
+}
+
+// TriggerReport describes a single trigger, its resolved configuration and what its scaler reported
+type TriggerReport struct {
+	Index       int                                    `json:"index"`
+	Name        string                                 `json:"name,omitempty"`
+	Type        string                                 `json:"type"`
+	Metadata    map[string]string                      `json:"metadata,omitempty"`
+	ResolvedEnv map[string]string                      `json:"resolvedEnv,omitempty"`
+	AuthParams  map[string]string                      `json:"authParams,omitempty"`
+	PodIdentity kedav1alpha1.PodIdentityProvider       `json:"podIdentity,omitempty"`
+	MetricType  v2.MetricTargetType                    `json:"metricType,omitempty"`
+	MetricSpecs []v2.MetricSpec                        `json:"metricSpecs,omitempty"`
+	MetricNames []string                               `json:"metricNames,omitempty"`
+	IsActive    *bool                                  `json:"isActive,omitempty"`
+	Metrics     []external_metrics.ExternalMetricValue `json:"metrics,omitempty"`
+	Skipped     string                                 `json:"skipped,omitempty"`
+	Errors      []string                               `json:"errors,omitempty"`
+}
+
+// HasErrors reports whether any trigger failed
+func (r *Report) HasErrors() bool {
+	for _, trigger := range r.Triggers {
+		if len(trigger.Errors) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Run builds the scalers of the selected ScaledObject or ScaledJob through the same path as the scale loop,
+// the other manifests are served by a fake client to resolve the scale target, the authentication and the environment
+func Run(ctx context.Context, manifests *Manifests, options Options) (*Report, error) {
+	if options.Offline && options.Live {
+		return nil, fmt.Errorf("live values can't be queried offline")
+	}
+	scalableObject, err := selectScalableObject(manifests, options.Name)
+	if err != nil {
+		return nil, err
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = 30 * time.Second
+	}
+
+	objects := make([]client.Object, 0, len(manifests.Objects)+1)
+	for _, obj := range manifests.Objects {
+		objects = append(objects, obj.DeepCopyObject().(client.Object))
+	}
+
+	report := &Report{
+		Namespace: scalableObject.GetNamespace(),
+		Name:      scalableObject.GetName(),
+	}
+	var triggers []kedav1alpha1.ScaleTriggers
+	switch obj := scalableObject.(type) {
+	case *kedav1alpha1.ScaledObject:
+		report.Kind = "ScaledObject"
+		triggers = obj.Spec.Triggers
+		if target, warning := prepareScaleTarget(obj, objects); target != nil {
+			objects = append(objects, target)
+			report.Warnings = append(report.Warnings, warning)
+		}
+	case *kedav1alpha1.ScaledJob:
+		report.Kind = "ScaledJob"
+		triggers = obj.Spec.Triggers
+	}
+	if options.Offline {
+		report.Warnings = append(report.Warnings, stripSecretStores(objects)...)
+	}
+
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	configs, errs, err := scaling.ResolveScalerConfigs(ctx, kubeClient, scalableObject, options.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving the scale target: %s", err)
+	}
+
+	for i, trigger := range triggers {
+		triggerReport := TriggerReport{
+			Index:      i,
+			Name:       trigger.Name,
+			Type:       trigger.Type,
+			Metadata:   trigger.Metadata,
+			MetricType: trigger.MetricType,
+		}
+		if errs[i] != nil {
+			triggerReport.Errors = append(triggerReport.Errors, errs[i].Error())
+		} else {
+			// metadataFrom and the namespace defaults are merged into the metadata
+			triggerReport.Metadata = configs[i].TriggerMetadata
+			triggerReport.ResolvedEnv = redact(configs[i].ResolvedEnv, options.ShowSecrets)
+			triggerReport.AuthParams = redact(configs[i].AuthParams, options.ShowSecrets)
+			triggerReport.PodIdentity = configs[i].PodIdentity
+			if options.Offline && connectingTriggerTypes[trigger.Type] {
+				triggerReport.Skipped = "the scaler connects to its event source when it is built, its metadata can't be validated offline"
+			} else {
+				testScaler(ctx, kubeClient, trigger.Type, configs[i], options, &triggerReport)
+			}
+		}
+		report.Triggers = append(report.Triggers, triggerReport)
+	}
+	return report, nil
+}
+
+func testScaler(ctx context.Context, kubeClient client.Client, triggerType string, config *scalers.ScalerConfig, options Options, report *TriggerReport) {
+	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	scaler, err := scaling.BuildScaler(ctx, kubeClient, triggerType, config)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+		return
+	}
+	defer scaler.Close(ctx)
+
+	report.MetricSpecs = scaler.GetMetricSpecForScaling(ctx)
+	for _, spec := range report.MetricSpecs {
+		switch {
+		case spec.External != nil:
+			report.MetricNames = append(report.MetricNames, spec.External.Metric.Name)
+		case spec.Resource != nil:
+			report.MetricNames = append(report.MetricNames, spec.Resource.Name.String())
+		}
+	}
+	if !options.Live {
+		return
+	}
+
+	isActive, err := scaler.IsActive(ctx)
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("IsActive: %s", err))
+	} else {
+		report.IsActive = &isActive
+	}
+	for _, spec := range report.MetricSpecs {
+		if spec.External == nil {
+			continue
+		}
+		metrics, err := scaler.GetMetrics(ctx, spec.External.Metric.Name, nil)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("GetMetrics %s: %s", spec.External.Metric.Name, err))
+			continue
+		}
+		report.Metrics = append(report.Metrics, metrics...)
+	}
+}
+
+func selectScalableObject(manifests *Manifests, name string) (client.Object, error) {
+	var candidates []client.Object
+	for _, obj := range manifests.ScalableObjects {
+		if name == "" || obj.GetName() == name {
+			candidates = append(candidates, obj)
+		}
+	}
+	switch {
+	case len(candidates) == 1:
+		return candidates[0].DeepCopyObject().(client.Object), nil
+	case len(candidates) == 0 && name != "":
+		return nil, fmt.Errorf("no ScaledObject or ScaledJob named %q found in the manifests", name)
+	case len(candidates) == 0:
+		return nil, fmt.Errorf("no ScaledObject or ScaledJob found in the manifests")
+	default:
+		names := make([]string, 0, len(candidates))
+		for _, obj := range candidates {
+			names = append(names, obj.GetName())
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("several ScaledObjects or ScaledJobs found, select one by name: %v", names)
+	}
+}
+
+// prepareScaleTarget sets the scale target kind the controller would otherwise resolve and returns a placeholder
+// without containers when the scale target isn't part of the manifests
+func prepareScaleTarget(scaledObject *kedav1alpha1.ScaledObject, objects []client.Object) (client.Object, string) {
+	if scaledObject.Spec.ScaleTargetRef == nil {
+		return nil, ""
+	}
+	gv, err := schema.ParseGroupVersion(scaledObject.Spec.ScaleTargetRef.APIVersion)
+	if err != nil || scaledObject.Spec.ScaleTargetRef.APIVersion == "" {
+		gv = appsv1.SchemeGroupVersion
+	}
+	kind := scaledObject.Spec.ScaleTargetRef.Kind
+	if kind == "" {
+		kind = "Deployment"
+	}
+	gvk := gv.WithKind(kind)
+	scaledObject.Status.ScaleTargetGVKR = &kedav1alpha1.GroupVersionKindResource{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind}
+
+	for _, obj := range objects {
+		objGVK, _ := objectGVK(obj)
+		if objGVK.Group == gvk.Group && objGVK.Kind == gvk.Kind &&
+			obj.GetNamespace() == scaledObject.Namespace && obj.GetName() == scaledObject.Spec.ScaleTargetRef.Name {
+			return nil, ""
+		}
+	}
+
+	meta := metav1.ObjectMeta{Name: scaledObject.Spec.ScaleTargetRef.Name, Namespace: scaledObject.Namespace}
+	warning := fmt.Sprintf("scale target %s %s isn't part of the manifests, environment variables of its containers are not resolved", gvk.Kind, meta.Name)
+	switch {
+	case gvk.Group == "apps" && gvk.Kind == "Deployment":
+		return &appsv1.Deployment{ObjectMeta: meta}, warning
+	case gvk.Group == "apps" && gvk.Kind == "StatefulSet":
+		return &appsv1.StatefulSet{ObjectMeta: meta}, warning
+	default:
+		placeholder := &unstructured.Unstructured{}
+		placeholder.SetGroupVersionKind(gvk)
+		placeholder.SetName(meta.Name)
+		placeholder.SetNamespace(meta.Namespace)
+		return placeholder, warning
+	}
+}
+
+func objectGVK(obj client.Object) (schema.GroupVersionKind, error) {
+	if gvk := obj.GetObjectKind().GroupVersionKind(); !gvk.Empty() {
+		return gvk, nil
+	}
+	gvks, _, err := scheme.ObjectKinds(obj)
+	if err != nil || len(gvks) == 0 {
+		return schema.GroupVersionKind{}, err
+	}
+	return gvks[0], nil
+}
+
+// stripSecretStores removes the external secret stores from the trigger authentications, they can't be reached offline
+func stripSecretStores(objects []client.Object) []string {
+	var warnings []string
+	strip := func(kind, name string, spec *kedav1alpha1.TriggerAuthenticationSpec) {
+		if spec.HashiCorpVault != nil {
+			spec.HashiCorpVault = nil
+			warnings = append(warnings, fmt.Sprintf("%s %s: secrets from HashiCorp Vault are not resolved offline", kind, name))
+		}
+		if spec.AzureKeyVault != nil {
+			spec.AzureKeyVault = nil
+			warnings = append(warnings, fmt.Sprintf("%s %s: secrets from Azure Key Vault are not resolved offline", kind, name))
+		}
+		if spec.AwsSecretManager != nil {
+			spec.AwsSecretManager = nil
+			warnings = append(warnings, fmt.Sprintf("%s %s: secrets from AWS Secrets Manager are not resolved offline", kind, name))
+		}
+		if spec.AwsParameterStore != nil {
+			spec.AwsParameterStore = nil
+			warnings = append(warnings, fmt.Sprintf("%s %s: parameters from AWS Parameter Store are not resolved offline", kind, name))
+		}
+		if spec.GcpSecretManager != nil {
+			spec.GcpSecretManager = nil
+			warnings = append(warnings, fmt.Sprintf("%s %s: secrets from GCP Secret Manager are not resolved offline", kind, name))
+		}
+	}
+	for _, obj := range objects {
+		switch auth := obj.(type) {
+		case *kedav1alpha1.TriggerAuthentication:
+			strip("TriggerAuthentication", auth.Name, &auth.Spec)
+		case *kedav1alpha1.ClusterTriggerAuthentication:
+			strip("ClusterTriggerAuthentication", auth.Name, &auth.Spec)
+		}
+	}
+	return warnings
+}
+
+func redact(values map[string]string, show bool) map[string]string {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		if show || value == "" {
+			result[key] = value
+		} else {
+			result[key] = redacted
+		}
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package triggertest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testManifests = `
+apiVersion: keda.sh/v1alpha1
+kind: ScaledObject
+metadata:
+  name: app
+spec:
+  scaleTargetRef:
+    name: app
+  triggers:
+  - type: prometheus
+    metadata:
+      serverAddress: http://prometheus:9090
+      metricName: http_requests_total
+      query: sum(rate(http_requests_total[2m]))
+      threshold: "100"
+    authenticationRef:
+      name: prometheus-auth
+  - type: prometheus
+    name: typo
+    metadata:
+      serverAddress: http://prometheus:9090
+      metricName: http_requests_total
+      query: sum(rate(http_requests_total[2m]))
+      treshold: "100"
+  - type: kafka
+    metadata:
+      bootstrapServers: kafka:9092
+      consumerGroup: group
+      topic: topic
+---
+apiVersion: keda.sh/v1alpha1
+kind: TriggerAuthentication
+metadata:
+  name: prometheus-auth
+spec:
+  secretTargetRef:
+  - parameter: bearerToken
+    name: prometheus-secret
+    key: token
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  selector:
+    matchLabels:
+      app: app
+  template:
+    metadata:
+      labels:
+        app: app
+    spec:
+      containers:
+      - name: app
+        image: app
+        env:
+        - name: PROMETHEUS_URL
+          value: http://prometheus:9090
+`
+
+func TestRunOffline(t *testing.T) {
+	manifests := NewManifests("default")
+	assert.NoError(t, manifests.Load(strings.NewReader(testManifests)))
+	manifests.AddSecretValue("prometheus-secret", "token", []byte("secret-token"))
+
+	report, err := Run(context.Background(), manifests, Options{Offline: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "ScaledObject", report.Kind)
+	assert.Equal(t, "default", report.Namespace)
+	assert.Empty(t, report.Warnings)
+	assert.Len(t, report.Triggers, 3)
+	assert.True(t, report.HasErrors())
+
+	valid := report.Triggers[0]
+	assert.Empty(t, valid.Errors)
+	assert.Equal(t, []string{"s0-prometheus-http_requests_total"}, valid.MetricNames)
+	assert.Equal(t, map[string]string{"bearerToken": redacted}, valid.AuthParams)
+	assert.Equal(t, map[string]string{"PROMETHEUS_URL": redacted}, valid.ResolvedEnv)
+
+	typo := report.Triggers[1]
+	assert.Equal(t, "typo", typo.Name)
+	assert.Len(t, typo.Errors, 1)
+	assert.Contains(t, typo.Errors[0], "threshold")
+
+	kafka := report.Triggers[2]
+	assert.Empty(t, kafka.Errors)
+	assert.NotEmpty(t, kafka.Skipped)
+}
+
+func TestRunWithoutScaleTarget(t *testing.T) {
+	manifests := NewManifests("apps")
+	assert.NoError(t, manifests.Load(strings.NewReader(strings.Split(testManifests, "---")[0])))
+
+	report, err := Run(context.Background(), manifests, Options{Offline: true, ShowSecrets: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "apps", report.Namespace)
+	assert.Len(t, report.Warnings, 1)
+	// the TriggerAuthentication is missing, so the authentication parameters are not resolved
+	assert.Empty(t, report.Triggers[0].AuthParams)
+	assert.Len(t, report.Triggers[0].Errors, 1)
+	assert.Contains(t, report.Triggers[0].Errors[0], "error reading TriggerAuthentication prometheus-auth")
+}
+
+func TestSelectScalableObject(t *testing.T) {
+	manifests := NewManifests("default")
+	_, err := Run(context.Background(), manifests, Options{})
+	assert.Error(t, err)
+
+	assert.NoError(t, manifests.Load(strings.NewReader(testManifests+"---\n"+strings.Replace(strings.Split(testManifests, "---")[0], "name: app\nspec", "name: other\nspec", 1))))
+	_, err = Run(context.Background(), manifests, Options{Offline: true})
+	assert.Error(t, err)
+
+	report, err := Run(context.Background(), manifests, Options{Offline: true, Name: "other"})
+	assert.NoError(t, err)
+	assert.Equal(t, "other", report.Name)
+
+	_, err = Run(context.Background(), manifests, Options{Offline: true, Live: true, Name: "other"})
+	assert.Error(t, err)
+}
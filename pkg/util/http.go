@@ -18,8 +18,14 @@ package util
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"net/http"
+	"net/url"
+	"sync"
 	"time"
+
+	"golang.org/x/net/http/httpproxy"
 )
 
 // HTTPDoer is an interface that matches the Do method on
@@ -29,21 +35,168 @@ type HTTPDoer interface {
 	Do(*http.Request) (*http.Response, error)
 }
 
+// ProxyConfig is the proxy of the HTTP clients, the proxy environment variables are used when it is empty
+type ProxyConfig struct {
+	// HTTPProxy and HTTPSProxy are the proxy URLs for http and https requests
+	HTTPProxy  string
+	HTTPSProxy string
+	// NoProxy is a comma separated list of hosts, domains, IPs and CIDRs requested without proxy
+	NoProxy string
+}
+
+// HTTPClientOptions configures an HTTP client created by NewHTTPClient,
+// the proxy, CA and TLS version override the cluster-wide settings
+type HTTPClientOptions struct {
+	// Timeout of the requests, 300 milliseconds if <= 0
+	Timeout time.Duration
+	// UnsafeSsl skips the validation of the server certificate
+	UnsafeSsl bool
+	// Proxy replaces the cluster-wide proxy for http and https requests
+	Proxy string
+	// NoProxy replaces the cluster-wide list of hosts requested without proxy, it requires Proxy
+	NoProxy string
+	// CA is a PEM bundle trusted in addition to the cluster-wide CA bundle
+	CA string
+	// Cert and Key are the PEM client certificate and key
+	Cert string
+	Key  string
+	// MinTLSVersion replaces the cluster-wide minimum TLS version when it is not 0
+	MinTLSVersion uint16
+}
+
+var (
+	httpSettingsLock sync.RWMutex
+	proxyConfig      ProxyConfig
+	caBundle         []byte
+)
+
+// SetProxyConfig changes the proxy of the HTTP clients created afterwards
+func SetProxyConfig(config ProxyConfig) error {
+	for _, proxy := range []string{config.HTTPProxy, config.HTTPSProxy} {
+		if _, err := parseProxyURL(proxy); err != nil {
+			return err
+		}
+	}
+	httpSettingsLock.Lock()
+	defer httpSettingsLock.Unlock()
+	proxyConfig = config
+	return nil
+}
+
+// SetCABundle changes the PEM bundle trusted in addition to the system CAs by the HTTP clients created afterwards
+func SetCABundle(bundle []byte) error {
+	if len(bundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(bundle) {
+		return fmt.Errorf("the CA bundle contains no PEM certificates")
+	}
+	httpSettingsLock.Lock()
+	defer httpSettingsLock.Unlock()
+	caBundle = bundle
+	return nil
+}
+
 // CreateHTTPClient returns a new HTTP client with the timeout set to
 // timeoutMS milliseconds, or 300 milliseconds if timeoutMS <= 0.
 // unsafeSsl parameter allows to avoid tls cert validation if it's required
 func CreateHTTPClient(timeout time.Duration, unsafeSsl bool) *http.Client {
+	// the options have no certificates or proxy that could be invalid
+	httpClient, _ := NewHTTPClient(HTTPClientOptions{Timeout: timeout, UnsafeSsl: unsafeSsl})
+	return httpClient
+}
+
+// NewHTTPClient returns a new HTTP client using the cluster-wide proxy, CA bundle and minimum TLS version
+// with the overrides of the options
+func NewHTTPClient(options HTTPClientOptions) (*http.Client, error) {
 	// default the timeout to 300ms
-	if timeout <= 0 {
-		timeout = 300 * time.Millisecond
+	if options.Timeout <= 0 {
+		options.Timeout = 300 * time.Millisecond
 	}
-	httpClient := &http.Client{
-		Timeout: timeout,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: unsafeSsl},
-			Proxy:           http.ProxyFromEnvironment,
-		},
+	transport, err := NewHTTPTransport(options)
+	if err != nil {
+		return nil, err
 	}
+	return &http.Client{
+		Timeout:   options.Timeout,
+		Transport: transport,
+	}, nil
+}
 
-	return httpClient
+// NewHTTPTransport returns a new http.Transport using the cluster-wide proxy, CA bundle and minimum TLS version
+// with the overrides of the options, the timeout of the options is ignored
+func NewHTTPTransport(options HTTPClientOptions) (*http.Transport, error) {
+	httpSettingsLock.RLock()
+	proxy, bundle := proxyConfig, caBundle
+	httpSettingsLock.RUnlock()
+
+	if options.Proxy != "" {
+		proxy = ProxyConfig{HTTPProxy: options.Proxy, HTTPSProxy: options.Proxy, NoProxy: options.NoProxy}
+	} else if options.NoProxy != "" {
+		return nil, fmt.Errorf("a no proxy list requires a proxy")
+	}
+	proxyFunc, err := newProxyFunc(proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: options.UnsafeSsl,
+		MinVersion:         GetMinTLSVersion(),
+	}
+	if options.MinTLSVersion != 0 {
+		tlsConfig.MinVersion = options.MinTLSVersion
+	}
+	if len(bundle) > 0 || options.CA != "" {
+		// the system pool is a copy, adding certificates doesn't change it for other clients
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		pool.AppendCertsFromPEM(bundle)
+		if options.CA != "" && !pool.AppendCertsFromPEM([]byte(options.CA)) {
+			return nil, fmt.Errorf("the CA contains no PEM certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if options.Cert != "" || options.Key != "" {
+		cert, err := tls.X509KeyPair([]byte(options.Cert), []byte(options.Key))
+		if err != nil {
+			return nil, fmt.Errorf("error parse X509KeyPair: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return &http.Transport{
+		TLSClientConfig: tlsConfig,
+		Proxy:           proxyFunc,
+	}, nil
+}
+
+// newProxyFunc returns the proxy function of the config, the one reading the environment variables if it is empty
+func newProxyFunc(config ProxyConfig) (func(*http.Request) (*url.URL, error), error) {
+	if config.HTTPProxy == "" && config.HTTPSProxy == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+	for _, proxy := range []string{config.HTTPProxy, config.HTTPSProxy} {
+		if _, err := parseProxyURL(proxy); err != nil {
+			return nil, err
+		}
+	}
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  config.HTTPProxy,
+		HTTPSProxy: config.HTTPSProxy,
+		NoProxy:    config.NoProxy,
+	}).ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
+
+func parseProxyURL(proxy string) (*url.URL, error) {
+	if proxy == "" {
+		return nil, nil
+	}
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q", proxy)
+	}
+	return proxyURL, nil
 }
@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHTTPClientCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	defer func() { _ = SetCABundle(nil) }()
+
+	_, err := CreateHTTPClient(0, false).Get(server.URL)
+	assert.Error(t, err)
+
+	client, err := NewHTTPClient(HTTPClientOptions{CA: string(ca)})
+	assert.NoError(t, err)
+	_, err = client.Get(server.URL)
+	assert.NoError(t, err)
+
+	assert.NoError(t, SetCABundle(ca))
+	_, err = CreateHTTPClient(0, false).Get(server.URL)
+	assert.NoError(t, err)
+
+	assert.Error(t, SetCABundle([]byte("not a certificate")))
+	_, err = NewHTTPClient(HTTPClientOptions{CA: "not a certificate"})
+	assert.Error(t, err)
+}
+
+func TestNewHTTPClientProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+	}))
+	defer proxy.Close()
+	defer func() { _ = SetProxyConfig(ProxyConfig{}) }()
+
+	assert.NoError(t, SetProxyConfig(ProxyConfig{HTTPProxy: proxy.URL, NoProxy: "direct.example.com"}))
+	_, err := CreateHTTPClient(0, false).Get("http://prometheus.example.com/api")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"http://prometheus.example.com/api"}, proxied)
+
+	transport, err := NewHTTPTransport(HTTPClientOptions{})
+	assert.NoError(t, err)
+	req, _ := http.NewRequest(http.MethodGet, "http://direct.example.com", nil)
+	proxyURL, err := transport.Proxy(req)
+	assert.NoError(t, err)
+	assert.Nil(t, proxyURL)
+
+	// the proxy of the options replaces the cluster-wide proxy and no proxy list
+	transport, err = NewHTTPTransport(HTTPClientOptions{Proxy: "http://other-proxy:3128"})
+	assert.NoError(t, err)
+	proxyURL, err = transport.Proxy(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "other-proxy:3128", proxyURL.Host)
+
+	assert.Error(t, SetProxyConfig(ProxyConfig{HTTPSProxy: "proxy:3128"}))
+	_, err = NewHTTPClient(HTTPClientOptions{NoProxy: "example.com"})
+	assert.Error(t, err)
+}
+
+func TestNewHTTPClientMinTLSVersion(t *testing.T) {
+	transport, err := NewHTTPTransport(HTTPClientOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS12), transport.TLSClientConfig.MinVersion)
+
+	transport, err = NewHTTPTransport(HTTPClientOptions{MinTLSVersion: tls.VersionTLS13, UnsafeSsl: true})
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), transport.TLSClientConfig.MinVersion)
+	assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+}
@@ -53,3 +53,12 @@ func NewK8sVersion(version *version.Info) K8sVersion {
 
 	return *k8sVersion
 }
+
+// SupportsAutoscalingV2 returns whether the cluster serves autoscaling/v2 HorizontalPodAutoscalers, added in Kubernetes 1.23.
+// An unknown version is assumed to be recent, autoscaling/v2beta2 isn't served anymore since Kubernetes 1.26.
+func (v K8sVersion) SupportsAutoscalingV2() bool {
+	if !v.Parsed || v.Version == nil {
+		return true
+	}
+	return v.Version.Major != "1" || v.MinorVersion >= 23
+}
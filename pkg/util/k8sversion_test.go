@@ -92,3 +92,21 @@ func TestResolveK8sVersion(t *testing.T) {
 		}
 	}
 }
+
+func TestSupportsAutoscalingV2(t *testing.T) {
+	tests := []struct {
+		version  K8sVersion
+		expected bool
+	}{
+		{version: NewK8sVersion(&version.Info{Major: "1", Minor: "22"}), expected: false},
+		{version: NewK8sVersion(&version.Info{Major: "1", Minor: "23+"}), expected: true},
+		{version: NewK8sVersion(&version.Info{Major: "1", Minor: "26"}), expected: true},
+		{version: NewK8sVersion(&version.Info{Major: "2", Minor: "0"}), expected: true},
+		{version: K8sVersion{}, expected: true},
+	}
+	for _, test := range tests {
+		if supported := test.version.SupportsAutoscalingV2(); supported != test.expected {
+			t.Errorf("Expected autoscaling/v2 support %v for version %q, got %v", test.expected, test.version.PrettyVersion, supported)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ReplicasPathFields splits a replicas path like .spec.replicas into its fields, the leading dot is optional
+func ReplicasPathFields(path string) ([]string, error) {
+	fields := strings.Split(strings.TrimPrefix(path, "."), ".")
+	for _, field := range fields {
+		if field == "" || strings.ContainsAny(field, "[]") {
+			return nil, fmt.Errorf("invalid replicas path %q, only dot separated fields are supported", path)
+		}
+	}
+	return fields, nil
+}
+
+// GetReplicasFromPath returns the replica count at the path of the object, false if the field isn't set
+func GetReplicasFromPath(obj *unstructured.Unstructured, path string) (int32, bool, error) {
+	fields, err := ReplicasPathFields(path)
+	if err != nil {
+		return 0, false, err
+	}
+	replicas, found, err := unstructured.NestedInt64(obj.Object, fields...)
+	if err != nil || !found {
+		return 0, found, err
+	}
+	if replicas < 0 || replicas > math.MaxInt32 {
+		return 0, true, fmt.Errorf("replica count %d at %s is out of range", replicas, path)
+	}
+	return int32(replicas), true, nil
+}
+
+// SetReplicasAtPath sets the replica count at the path of the object
+func SetReplicasAtPath(obj *unstructured.Unstructured, path string, replicas int32) error {
+	fields, err := ReplicasPathFields(path)
+	if err != nil {
+		return err
+	}
+	return unstructured.SetNestedField(obj.Object, int64(replicas), fields...)
+}
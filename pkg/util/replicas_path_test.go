@@ -0,0 +1,53 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestReplicasPath(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"cluster": map[string]interface{}{"instances": int64(3)}},
+	}}
+
+	replicas, found, err := GetReplicasFromPath(obj, ".spec.cluster.instances")
+	if err != nil || !found || replicas != 3 {
+		t.Errorf("Expected 3 replicas, got %d, found %t, error %v", replicas, found, err)
+	}
+	if _, found, err = GetReplicasFromPath(obj, "status.replicas"); err != nil || found {
+		t.Errorf("Expected no replicas at status.replicas, got found %t, error %v", found, err)
+	}
+	if _, _, err = GetReplicasFromPath(obj, ".spec.cluster"); err == nil {
+		t.Error("Expected an error for a path to an object")
+	}
+
+	if err = SetReplicasAtPath(obj, "spec.cluster.instances", 5); err != nil {
+		t.Errorf("Expected no error setting replicas, got %v", err)
+	}
+	if replicas, _, _ = GetReplicasFromPath(obj, "spec.cluster.instances"); replicas != 5 {
+		t.Errorf("Expected 5 replicas, got %d", replicas)
+	}
+
+	for _, path := range []string{"", ".", "spec..replicas", ".spec.members[0].replicas"} {
+		if _, err := ReplicasPathFields(path); err == nil {
+			t.Errorf("Expected an error for path %q", path)
+		}
+	}
+}
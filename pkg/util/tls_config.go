@@ -20,8 +20,37 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"sync/atomic"
 )
 
+// minTLSVersion is the minimum TLS version of the HTTP clients created by CreateHTTPClient and NewTLSConfig
+var minTLSVersion = uint32(tls.VersionTLS12)
+
+// SetMinTLSVersion changes the minimum TLS version of the HTTP clients created afterwards
+func SetMinTLSVersion(version uint16) {
+	atomic.StoreUint32(&minTLSVersion, uint32(version))
+}
+
+// GetMinTLSVersion returns the minimum TLS version of the HTTP clients created by CreateHTTPClient and NewTLSConfig
+func GetMinTLSVersion() uint16 {
+	return uint16(atomic.LoadUint32(&minTLSVersion))
+}
+
+// ParseTLSVersion parses a TLS version in the 1.0, 1.1, 1.2 or 1.3 format
+func ParseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, fmt.Errorf("unsupported TLS version %q, expected 1.0, 1.1, 1.2 or 1.3", version)
+}
+
 // NewTLSConfig returns a *tls.Config using the given ceClient cert, ceClient key,
 // and CA certificate. If none are appropriate, a nil *tls.Config is returned.
 func NewTLSConfig(clientCert, clientKey, caCert string) (*tls.Config, error) {
@@ -30,7 +59,7 @@ func NewTLSConfig(clientCert, clientKey, caCert string) (*tls.Config, error) {
 	skipVerify := true
 	valid := false
 
-	config := &tls.Config{}
+	config := &tls.Config{MinVersion: GetMinTLSVersion()}
 
 	if clientCert != "" && clientKey != "" {
 		cert, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))